@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"blog-api/internal/admin"
+	"blog-api/internal/config"
+	"blog-api/internal/database"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"gorm.io/gorm"
+)
+
+const usage = `usage: blog-api [command] [flags]
+
+Tanpa command, blog-api menjalankan server HTTP dan gRPC.
+
+commands:
+  migrate        up | down [n] | status | goto <version>
+  user           create | promote <email|username> | reset-password <email|username>
+  seed           buat user, post dan comment demo
+  purge-trash    hapus permanen post/comment yang sudah lama di trash
+  reindex        hitung ulang comment_count, score, mentions dan feed_entries
+  export         tulis user, post dan comment sebagai JSON
+  import         buat ulang konten dari file export
+
+Jalankan "blog-api <command> -h" untuk daftar flag.`
+
+// runCommand - Subcommand operasional, memakai konfigurasi dan database yang sama dengan server
+func runCommand(cfg *config.Config, name string, args []string) error {
+	if name == "help" || name == "-h" || name == "--help" {
+		fmt.Println(usage)
+		return nil
+	}
+
+	var run func(cfg *config.Config, db *gorm.DB, args []string) error
+	switch name {
+	case "migrate":
+		run = func(_ *config.Config, db *gorm.DB, args []string) error { return runMigrate(db, args) }
+	case "user":
+		run = runUser
+	case "seed":
+		run = runSeed
+	case "purge-trash":
+		run = runPurgeTrash
+	case "reindex":
+		run = runReindex
+	case "export":
+		run = runExport
+	case "import":
+		run = runImport
+	default:
+		return fmt.Errorf("unknown command %q\n\n%s", name, usage)
+	}
+
+	db, err := database.Connect(cfg)
+	if err != nil {
+		return err
+	}
+	return run(cfg, db, args)
+}
+
+func runUser(cfg *config.Config, db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: blog-api user create | promote <email|username> | reset-password <email|username>")
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+		input := service.RegisterInput{}
+		fs.StringVar(&input.Email, "email", "", "email user (wajib)")
+		fs.StringVar(&input.Name, "name", "", "nama user (wajib)")
+		fs.StringVar(&input.Password, "password", "", "password (wajib, minimal 6 karakter)")
+		fs.StringVar(&input.Username, "username", "", "username, dibuat dari email jika kosong")
+		role := fs.String("role", models.RoleUser, "role: user atau admin")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		user, err := admin.CreateUser(ctx, db, input, *role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("created user %d (%s, @%s, %s)\n", user.ID, user.Email, user.Username, user.Role)
+		return nil
+
+	case "promote":
+		fs := flag.NewFlagSet("user promote", flag.ContinueOnError)
+		role := fs.String("role", models.RoleAdmin, "role baru: user atau admin")
+		login, err := parseWithLogin(fs, args[1:])
+		if err != nil {
+			return err
+		}
+
+		user, err := admin.SetRole(ctx, db, login, *role)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("user %d (%s) is now %s\n", user.ID, user.Email, *role)
+		return nil
+
+	case "reset-password":
+		fs := flag.NewFlagSet("user reset-password", flag.ContinueOnError)
+		password := fs.String("password", "", "password baru, dibuat acak jika kosong")
+		login, err := parseWithLogin(fs, args[1:])
+		if err != nil {
+			return err
+		}
+
+		newPassword, err := admin.ResetPassword(ctx, db, login, *password)
+		if err != nil {
+			return err
+		}
+		if *password == "" {
+			fmt.Printf("password for %s reset to: %s\n", login, newPassword)
+		} else {
+			fmt.Printf("password for %s updated\n", login)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown user command %q", args[0])
+}
+
+// parseWithLogin - Flag boleh ditulis sebelum atau sesudah argumen <email|username>
+func parseWithLogin(fs *flag.FlagSet, args []string) (string, error) {
+	if err := fs.Parse(args); err != nil {
+		return "", err
+	}
+	if fs.NArg() == 0 {
+		return "", fmt.Errorf("usage: blog-api %s <email|username> [flags]", fs.Name())
+	}
+
+	login := fs.Arg(0)
+	if err := fs.Parse(fs.Args()[1:]); err != nil {
+		return "", err
+	}
+	return login, nil
+}
+
+func runSeed(cfg *config.Config, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	opts := admin.SeedOptions{}
+	fs.IntVar(&opts.Users, "users", 10, "jumlah user demo")
+	fs.IntVar(&opts.PostsPerUser, "posts", 5, "jumlah post per user")
+	fs.IntVar(&opts.CommentsPerPost, "comments", 3, "jumlah comment per post")
+	fs.StringVar(&opts.Password, "password", "password123", "password semua user demo")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := admin.Seed(context.Background(), db, opts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("seeded %d users, %d posts, %d comments\n", result.Users, result.Posts, result.Comments)
+	return nil
+}
+
+func runPurgeTrash(cfg *config.Config, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	olderThan := fs.Duration("older-than", 30*24*time.Hour, "umur minimal di trash sebelum dihapus permanen")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := admin.PurgeTrash(context.Background(), db, time.Now().Add(-*olderThan))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("purged %d posts, %d comments\n", result.Posts, result.Comments)
+	return nil
+}
+
+func runReindex(cfg *config.Config, db *gorm.DB, args []string) error {
+	result, err := admin.Reindex(context.Background(), db, cfg.FeedFanout)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("counters recomputed, mentions +%d/-%d", result.MentionsAdded, result.MentionsRemoved)
+	if cfg.FeedFanout {
+		fmt.Printf(", %d feed entries", result.FeedEntries)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runExport(cfg *config.Config, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	output := fs.String("output", "-", "file tujuan, - berarti stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	dump, err := admin.Export(context.Background(), db, w)
+	if err != nil {
+		return err
+	}
+	// Ringkasan ke stderr agar stdout tetap JSON murni
+	fmt.Fprintf(os.Stderr, "exported %d users, %d posts, %d comments\n", len(dump.Users), len(dump.Posts), len(dump.Comments))
+	return nil
+}
+
+func runImport(cfg *config.Config, db *gorm.DB, args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	input := fs.String("input", "-", "file export, - berarti stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *input != "-" {
+		file, err := os.Open(*input)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	ctx := context.Background()
+	result, err := admin.Import(ctx, db, r)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %d users (%d already existed), %d posts, %d comments\n",
+		result.Users, result.ExistingUsers, result.Posts, result.Comments)
+
+	// Mention dan feed tidak ada di file export, bangun ulang dari content
+	if _, err := admin.Reindex(ctx, db, cfg.FeedFanout); err != nil {
+		return fmt.Errorf("imported, but reindex failed: %w", err)
+	}
+	return nil
+}
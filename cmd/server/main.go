@@ -1,13 +1,31 @@
 package main
 
 import (
+	"context"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"blog-api/internal/config"
 	"blog-api/internal/database"
+	"blog-api/internal/grpcserver"
 	"blog-api/internal/handlers"
+	"blog-api/internal/imaging"
+	"blog-api/internal/logging"
+	"blog-api/internal/metrics"
 	"blog-api/internal/middleware"
+	"blog-api/internal/ratelimit"
+	"blog-api/internal/realtime"
+	"blog-api/internal/repository"
+	"blog-api/internal/service"
+	"blog-api/internal/storage"
+	"blog-api/internal/tracing"
+	"blog-api/internal/webhook"
 
 	"github.com/gorilla/mux"
 )
@@ -16,59 +34,297 @@ func main() {
 	// Load konfigurasi
 	cfg := config.LoadConfig()
 
+	// Subcommand operasional: migrate, user, seed, purge-trash, reindex, export, import
+	if len(os.Args) > 1 {
+		if err := runCommand(cfg, os.Args[1], os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// Logger JSON/text untuk server (subcommand di atas tetap menulis teks biasa)
+	if err := logging.Setup(cfg); err != nil {
+		log.Fatal(err)
+	}
+
+	// Tracing OpenTelemetry (no-op jika TRACING_EXPORTER=none)
+	flushTraces, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		fatal("failed to initialize tracing", err)
+	}
+
 	// Koneksi ke database
-	if err := database.Connect(cfg); err != nil {
-		log.Fatal("Failed to connect to database:", err)
+	db, err := database.Connect(cfg)
+	if err != nil {
+		fatal("failed to connect to database", err)
+	}
+
+	// Jalankan migration (bisa dimatikan jika migrasi dijalankan sebagai release step)
+	if cfg.AutoMigrate {
+		if err := database.Migrate(db); err != nil {
+			fatal("failed to migrate database", err)
+		}
+	}
+
+	// Metric Prometheus: durasi query GORM dan statistik connection pool
+	if err := metrics.InstrumentDB(db, metrics.Registry); err != nil {
+		fatal("failed to instrument database", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		fatal("failed to instrument database", err)
+	}
+
+	// Storage untuk media upload
+	files, err := storage.New(cfg)
+	if err != nil {
+		fatal("failed to initialize storage", err)
 	}
 
-	// Jalankan migration dan seed
-	if err := database.Migrate(); err != nil {
-		log.Fatal("Failed to migrate database:", err)
+	// Context background worker, dibatalkan saat shutdown setelah request selesai di-drain
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+
+	// Pub/sub untuk stream realtime (SSE)
+	hub, waitRealtime, err := realtime.Start(workerCtx, cfg, db)
+	if err != nil {
+		fatal("failed to initialize realtime", err)
 	}
 
-	if err := database.SeedData(); err != nil {
-		log.Fatal("Failed to seed data:", err)
+	// Background worker pembuat variant gambar
+	imagingWorkers := imaging.NewWorkers(cfg, db, files)
+	waitImaging := imagingWorkers.Start(workerCtx)
+
+	// Background dispatcher webhook (outbox → delivery dengan retry)
+	dispatcher := webhook.NewDispatcher(cfg, db)
+	waitWebhooks := dispatcher.Start(workerCtx)
+
+	// Rate limiter token bucket: register dan login lewat middleware, comment di CommentService
+	// agar berlaku juga untuk GraphQL dan gRPC
+	var rateLimitStore ratelimit.Store
+	waitRateLimit := func() {}
+	if cfg.RateLimitEnabled {
+		if rateLimitStore, err = ratelimit.NewStore(cfg, db); err != nil {
+			fatal("failed to initialize rate limiter", err)
+		}
+		waitRateLimit = ratelimit.StartSweeper(workerCtx, rateLimitStore, maxRateLimitPeriod(cfg), time.Minute)
 	}
+	limiter := middleware.NewRateLimiter(cfg, rateLimitStore)
+
+	// Service di atas repository GORM, di-inject ke handler
+	store := repository.NewGormStore(db)
+	userService := service.NewUserService(store)
+	postService := service.NewPostService(cfg, store, hub, dispatcher)
+	commentService := service.NewCommentService(cfg, store, rateLimitStore, hub, dispatcher)
+	reactionService := service.NewReactionService(cfg, store)
+	bookmarkService := service.NewBookmarkService(cfg, store)
+	readingListService := service.NewReadingListService(cfg, store)
+	followService := service.NewFollowService(cfg, store)
+	notificationService := service.NewNotificationService(cfg, store)
+	mediaService := service.NewMediaService(cfg, store, files, imagingWorkers)
+	webhookService := service.NewWebhookService(cfg, store, dispatcher)
+	sitemapService := service.NewSitemapService(cfg, store)
+	presenceService := service.NewPresenceService(cfg, store, hub)
+	tokens := service.NewTokenService(cfg.JWTSecret)
+
+	authHandler := handlers.NewAuthHandler(userService, tokens)
+	userHandler := handlers.NewUserHandler(userService)
+	postHandler := handlers.NewPostHandler(cfg, postService, commentService, reactionService, bookmarkService, files)
+	commentHandler := handlers.NewCommentHandler(cfg, commentService, postService, reactionService, hub)
+	graphqlHandler := handlers.NewGraphQLHandler(cfg, userService, postService, commentService, reactionService)
+	reactionHandler := handlers.NewReactionHandler(reactionService)
+	bookmarkHandler := handlers.NewBookmarkHandler(bookmarkService)
+	readingListHandler := handlers.NewReadingListHandler(readingListService)
+	followHandler := handlers.NewFollowHandler(followService)
+	feedHandler := handlers.NewFeedHandler(postService, reactionService, bookmarkService, files)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	mediaHandler := handlers.NewMediaHandler(cfg, mediaService, files)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	syndicationHandler := handlers.NewSyndicationHandler(cfg, userService, postService)
+	sitemapHandler := handlers.NewSitemapHandler(cfg, sitemapService)
+	presenceHandler := handlers.NewPresenceHandler(cfg, presenceService, hub)
+
+	readinessHandler := handlers.NewReadinessHandler(cfg.ReadinessTimeout, cfg.ReadinessCacheTTL,
+		handlers.DefaultReadinessChecks(db, files)...)
+
+	auth := middleware.NewAuth(tokens)
 
 	// Setup router
 	router := mux.NewRouter()
+	router.Use(middleware.RouteTemplate)
 
 	// Health check endpoint
 	router.HandleFunc("/health", handlers.HealthCheck).Methods("GET")
+	router.HandleFunc("/livez", handlers.Livez).Methods("GET")
+	router.HandleFunc("/readyz", readinessHandler.Readyz).Methods("GET")
+
+	// Metric Prometheus, di port admin terpisah jika METRICS_PORT diatur
+	var metricsServer *http.Server
+	if cfg.MetricsPort == "" {
+		router.Handle("/metrics", metrics.Handler()).Methods("GET")
+	} else {
+		metricsMux := http.NewServeMux()
+		metricsMux.Handle("GET /metrics", metrics.Handler())
+		metricsServer = newHTTPServer(cfg, cfg.MetricsPort, metricsMux)
+	}
 
 	// Swagger documentation
 	router.HandleFunc("/swagger.json", handlers.SwaggerJSON).Methods("GET")
 	router.HandleFunc("/swagger", handlers.SwaggerUI).Methods("GET")
 
+	// Syndication feeds (RSS 2.0, Atom, JSON Feed 1.1)
+	for _, prefix := range []string{"", "/authors/{username}", "/tags/{tag}"} {
+		router.HandleFunc(prefix+"/feed.xml", syndicationHandler.RSSFeed).Methods("GET")
+		router.HandleFunc(prefix+"/atom.xml", syndicationHandler.AtomFeed).Methods("GET")
+		router.HandleFunc(prefix+"/feed.json", syndicationHandler.JSONFeed).Methods("GET")
+	}
+
+	// SEO: sitemap dan robots.txt
+	router.HandleFunc("/robots.txt", sitemapHandler.RobotsTxt).Methods("GET")
+	router.HandleFunc("/sitemap.xml", sitemapHandler.Sitemap).Methods("GET")
+	router.HandleFunc("/sitemaps/{section:posts|authors|tags}-{page:[0-9]+}.xml", sitemapHandler.SitemapSection).Methods("GET")
+
+	// File media (storage local)
+	router.HandleFunc("/media/{key:.+}", mediaHandler.ServeMediaFile).Methods("GET", "HEAD")
+
 	// API routes (akan ditambahkan di langkah selanjutnya)
 	api := router.PathPrefix("/api").Subrouter()
 
 	// Auth routes
-	api.HandleFunc("/register", handlers.Register).Methods("POST")
-	api.HandleFunc("/login", handlers.Login).Methods("POST")
+	api.Handle("/register", limiter.Limit("register")(http.HandlerFunc(authHandler.Register))).Methods("POST")
+	api.Handle("/login", limiter.Limit("login")(http.HandlerFunc(authHandler.Login))).Methods("POST")
 
 	// Post routes (protected)
 	protected := api.PathPrefix("").Subrouter()
-	protected.Use(middleware.AuthMiddleware)
+	protected.Use(auth.Required)
+
+	protected.HandleFunc("/posts", postHandler.CreatePost).Methods("POST")
+	protected.HandleFunc("/posts/{id}", postHandler.UpdatePost).Methods("PUT")
+	protected.HandleFunc("/posts/{id}", postHandler.DeletePost).Methods("DELETE")
+
+	// Public routes dengan token opsional (personalisasi: bookmarked, viewer_reactions)
+	optional := api.PathPrefix("").Subrouter()
+	optional.Use(auth.Optional)
+
+	optional.HandleFunc("/posts", postHandler.GetPosts).Methods("GET")
+	optional.HandleFunc("/posts/{id}", postHandler.GetPost).Methods("GET")
+
+	// Bookmark routes (protected)
+	protected.HandleFunc("/posts/{id}/bookmark", bookmarkHandler.AddBookmark).Methods("PUT")
+	protected.HandleFunc("/posts/{id}/bookmark", bookmarkHandler.RemoveBookmark).Methods("DELETE")
+	protected.HandleFunc("/me/bookmarks", bookmarkHandler.GetBookmarks).Methods("GET")
+
+	// Reading list routes (protected)
+	protected.HandleFunc("/me/reading-lists", readingListHandler.CreateReadingList).Methods("POST")
+	protected.HandleFunc("/me/reading-lists", readingListHandler.GetReadingLists).Methods("GET")
+	protected.HandleFunc("/me/reading-lists/{list_id}", readingListHandler.GetReadingList).Methods("GET")
+	protected.HandleFunc("/me/reading-lists/{list_id}", readingListHandler.UpdateReadingList).Methods("PUT")
+	protected.HandleFunc("/me/reading-lists/{list_id}", readingListHandler.DeleteReadingList).Methods("DELETE")
+	protected.HandleFunc("/me/reading-lists/{list_id}/posts/{post_id}", readingListHandler.AddReadingListItem).Methods("PUT")
+	protected.HandleFunc("/me/reading-lists/{list_id}/posts/{post_id}", readingListHandler.RemoveReadingListItem).Methods("DELETE")
+
+	// Follow & feed routes (protected)
+	protected.HandleFunc("/users/{id}/follow", followHandler.FollowUser).Methods("PUT")
+	protected.HandleFunc("/users/{id}/follow", followHandler.UnfollowUser).Methods("DELETE")
+	protected.HandleFunc("/feed", feedHandler.GetFeed).Methods("GET")
+
+	// Notification routes (protected)
+	protected.HandleFunc("/me/notifications", notificationHandler.GetNotifications).Methods("GET")
+	protected.HandleFunc("/me/notifications/read-all", notificationHandler.MarkAllNotificationsRead).Methods("POST")
+	protected.HandleFunc("/me/notifications/{id}/read", notificationHandler.MarkNotificationRead).Methods("POST")
+	protected.HandleFunc("/me/notification-preferences", notificationHandler.GetNotificationPreferences).Methods("GET")
+	protected.HandleFunc("/me/notification-preferences", notificationHandler.UpdateNotificationPreferences).Methods("PUT")
+
+	// Media routes
+	protected.HandleFunc("/media", mediaHandler.UploadMedia).Methods("POST")
+	protected.HandleFunc("/media/{id}", mediaHandler.DeleteMedia).Methods("DELETE")
+	protected.HandleFunc("/me/media", mediaHandler.GetMyMedia).Methods("GET")
+	api.HandleFunc("/media/{id}", mediaHandler.GetMedia).Methods("GET")
+
+	// Webhook routes (protected)
+	protected.HandleFunc("/me/webhooks", webhookHandler.CreateWebhook).Methods("POST")
+	protected.HandleFunc("/me/webhooks", webhookHandler.GetWebhooks).Methods("GET")
+	protected.HandleFunc("/me/webhooks/{id}", webhookHandler.GetWebhook).Methods("GET")
+	protected.HandleFunc("/me/webhooks/{id}", webhookHandler.UpdateWebhook).Methods("PUT")
+	protected.HandleFunc("/me/webhooks/{id}", webhookHandler.DeleteWebhook).Methods("DELETE")
+	protected.HandleFunc("/me/webhooks/{id}/deliveries", webhookHandler.GetWebhookDeliveries).Methods("GET")
+	protected.HandleFunc("/me/webhooks/{id}/deliveries/{delivery_id}/redeliver", webhookHandler.RedeliverWebhook).Methods("POST")
+
+	// User routes
+	protected.HandleFunc("/me/username", userHandler.UpdateUsername).Methods("PUT")
+	api.HandleFunc("/users/search", userHandler.SearchUsers).Methods("GET")
 
-	protected.HandleFunc("/posts", handlers.CreatePost).Methods("POST")
-	protected.HandleFunc("/posts/{id}", handlers.UpdatePost).Methods("PUT")
-	protected.HandleFunc("/posts/{id}", handlers.DeletePost).Methods("DELETE")
+	// Public follow lists
+	api.HandleFunc("/users/{id}/followers", followHandler.GetFollowers).Methods("GET")
+	api.HandleFunc("/users/{id}/following", followHandler.GetFollowing).Methods("GET")
 
-	// Public post routes
-	api.HandleFunc("/posts", handlers.GetPosts).Methods("GET")
-	api.HandleFunc("/posts/{id}", handlers.GetPost).Methods("GET")
+	// Public reading list (share link)
+	optional.HandleFunc("/reading-lists/shared/{token}", readingListHandler.GetSharedReadingList).Methods("GET")
 
 	// Comment routes (protected)
-	protected.HandleFunc("/posts/{post_id}/comments", handlers.CreateComment).Methods("POST")
-	protected.HandleFunc("/posts/{post_id}/comments/{comment_id}", handlers.DeleteComment).Methods("DELETE")
+	protected.HandleFunc("/posts/{post_id}/comments", commentHandler.CreateComment).Methods("POST")
+	protected.HandleFunc("/posts/{post_id}/comments/{comment_id}", commentHandler.DeleteComment).Methods("DELETE")
+	protected.HandleFunc("/posts/{post_id}/comments/{comment_id}/restore", commentHandler.RestoreComment).Methods("POST")
+
+	// Reaction routes (protected)
+	protected.HandleFunc("/posts/{id}/reactions/{kind}", reactionHandler.AddPostReaction).Methods("PUT")
+	protected.HandleFunc("/posts/{id}/reactions/{kind}", reactionHandler.RemovePostReaction).Methods("DELETE")
+	protected.HandleFunc("/posts/{post_id}/comments/{comment_id}/reactions/{kind}", reactionHandler.AddCommentReaction).Methods("PUT")
+	protected.HandleFunc("/posts/{post_id}/comments/{comment_id}/reactions/{kind}", reactionHandler.RemoveCommentReaction).Methods("DELETE")
+
+	// Editor presence (WebSocket, token lewat header atau query access_token)
+	ws := api.PathPrefix("").Subrouter()
+	ws.Use(auth.WebSocket)
+	ws.HandleFunc("/posts/{id}/presence", presenceHandler.PostPresence).Methods("GET")
 
 	// Public comment routes
-	api.HandleFunc("/posts/{post_id}/comments", handlers.GetComments).Methods("GET")
+	optional.HandleFunc("/posts/{post_id}/comments", commentHandler.GetComments).Methods("GET")
+	api.HandleFunc("/posts/{post_id}/comments/stream", commentHandler.StreamComments).Methods("GET")
+
+	// GraphQL (token opsional, mutation wajib login)
+	optional.HandleFunc("/graphql", graphqlHandler.GraphQL).Methods("POST")
+
+	// Server gRPC di port terpisah
+	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		fatal("failed to listen for gRPC", err)
+	}
+	grpcServer := grpcserver.New(cfg, tokens, postService, commentService, reactionService, hub)
+	go func() {
+		slog.Info("gRPC server starting", "port", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			fatal("failed to start gRPC server", err)
+		}
+	}()
 
 	// Start server
-	log.Printf("Server starting on port %s", cfg.ServerPort)
-	if err := http.ListenAndServe(":"+cfg.ServerPort, router); err != nil {
-		log.Fatal("Failed to start server:", err)
+	// Tracing, RequestLogger dan Metrics di luar router agar 404/405 juga tercatat
+	handler := middleware.Tracing(middleware.RequestLogger(middleware.Metrics(router)))
+	server := newHTTPServer(cfg, cfg.ServerPort, handler)
+	servers := []*http.Server{server}
+	serveHTTP("HTTP", server)
+	if metricsServer != nil {
+		servers = append(servers, metricsServer)
+		serveHTTP("metrics", metricsServer)
 	}
+
+	// Tunggu SIGINT/SIGTERM; sinyal kedua menghentikan proses langsung
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+	slog.Info("shutting down")
+	shutdown(cfg, db, hub, servers, grpcServer, stopWorkers, waitRealtime, waitImaging, waitWebhooks, waitRateLimit, flushTraces)
+}
+
+// maxRateLimitPeriod - Bucket yang tidak disentuh selama periode terpanjang sudah penuh dan bisa dihapus
+func maxRateLimitPeriod(cfg *config.Config) time.Duration {
+	longest := time.Minute
+	for _, policy := range cfg.RateLimitPolicies {
+		longest = max(longest, policy.Period)
+	}
+	return longest
+}
+
+// fatal - Catat error startup lalu keluar dengan status 1
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
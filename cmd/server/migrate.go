@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"blog-api/internal/database"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = `usage: blog-api migrate <command>
+
+commands:
+  up              terapkan semua migrasi yang belum diterapkan
+  down [n]        rollback n migrasi terakhir (default 1)
+  status          tampilkan versi migrasi dan waktu diterapkan
+  goto <version>  naik/turun sampai tepat di version (0 = rollback semua)`
+
+// runMigrate - Subcommand "migrate" untuk menjalankan migrasi di luar boot server (misal release step deploy)
+func runMigrate(db *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	var done []database.Migration
+	switch args[0] {
+	case "up":
+		done, err = migrator.Up(ctx)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+		}
+		done, err = migrator.Down(ctx, steps)
+	case "goto":
+		if len(args) < 2 {
+			return errors.New(migrateUsage)
+		}
+		version, parseErr := strconv.ParseInt(args[1], 10, 64)
+		if parseErr != nil || version < 0 {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		done, err = migrator.Goto(ctx, version)
+	case "status":
+		return printMigrationStatus(ctx, migrator)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	// Migrasi yang sudah selesai tetap dilaporkan walau migrasi berikutnya gagal
+	for _, migration := range done {
+		fmt.Printf("%s %d_%s\n", args[0], migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(done) == 0 {
+		fmt.Println("no change")
+	}
+	return nil
+}
+
+func printMigrationStatus(ctx context.Context, migrator *database.Migrator) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+	return w.Flush()
+}
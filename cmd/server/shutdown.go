@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/database"
+	"blog-api/internal/handlers"
+	"blog-api/internal/realtime"
+
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// newHTTPServer - http.Server dengan timeout dari konfigurasi (tanpa timeout, client lambat bisa menahan koneksi selamanya)
+func newHTTPServer(cfg *config.Config, port string, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              ":" + port,
+		Handler:           handler,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+	}
+}
+
+// serveHTTP - Jalankan server di goroutine, error selain ErrServerClosed menghentikan proses
+func serveHTTP(name string, srv *http.Server) {
+	go func() {
+		slog.Info(name+" server starting", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("failed to start "+name+" server", err)
+		}
+	}()
+}
+
+// shutdown - Health check menjadi 503, berhenti menerima koneksi baru, tunggu request yang berjalan
+// selesai (maksimal SHUTDOWN_TIMEOUT), hentikan background worker lalu tutup connection pool database
+func shutdown(cfg *config.Config, db *gorm.DB, hub *realtime.Hub, httpServers []*http.Server, grpcServer *grpc.Server, stopWorkers context.CancelFunc, waits ...func()) {
+	handlers.SetShuttingDown()
+	if cfg.ShutdownDelay > 0 {
+		slog.Info("waiting for load balancers to observe shutdown", "delay", cfg.ShutdownDelay.String())
+		time.Sleep(cfg.ShutdownDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Stream SSE/WebSocket/gRPC tidak pernah idle, tutup agar tidak menahan drain sampai timeout
+	hub.Shutdown()
+
+	var wg sync.WaitGroup
+	for _, httpServer := range httpServers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := httpServer.Shutdown(ctx); err != nil {
+				slog.Warn("HTTP server did not drain in time, closing remaining connections", "addr", httpServer.Addr, "error", err)
+				httpServer.Close()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if !waitUntil(ctx, grpcServer.GracefulStop) {
+			slog.Warn("gRPC server did not drain in time, closing remaining streams")
+			grpcServer.Stop()
+		}
+	}()
+	wg.Wait()
+
+	stopWorkers()
+	if !waitUntil(ctx, waits...) {
+		slog.Warn("background workers did not stop in time")
+	}
+
+	if err := database.Close(db); err != nil {
+		slog.Error("failed to close database", "error", err)
+	}
+	slog.Info("shutdown complete")
+}
+
+// waitUntil - Jalankan fns (blocking) berurutan, false jika ctx selesai lebih dulu
+func waitUntil(ctx context.Context, fns ...func()) bool {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, fn := range fns {
+			fn()
+		}
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/handlers"
+	"blog-api/internal/realtime"
+
+	"google.golang.org/grpc"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestShutdownDrainsInFlightRequests(t *testing.T) {
+	cfg := &config.Config{ShutdownTimeout: 5 * time.Second}
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", handlers.HealthCheck)
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("done"))
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := newHTTPServer(cfg, "0", mux)
+	go server.Serve(listener)
+	base := "http://" + listener.Addr().String()
+
+	result := make(chan string, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			result <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		result <- string(body)
+	}()
+	<-started
+
+	workerStopped := false
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	shutdown(cfg, db, hub, []*http.Server{server}, grpc.NewServer(), stopWorkers, func() {
+		<-workerCtx.Done()
+		workerStopped = true
+	})
+
+	if body := <-result; body != "done" {
+		t.Errorf("Expected in-flight request to complete, got %q", body)
+	}
+	if !workerStopped {
+		t.Error("Expected shutdown to wait for background workers")
+	}
+	if _, err := http.Get(base + "/health"); err == nil {
+		t.Error("Expected new connections to be refused after shutdown")
+	}
+}
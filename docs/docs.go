@@ -35,7 +35,8 @@ const SwaggerJSON = `{
             "properties": {
               "email": {"type": "string", "example": "user@example.com"},
               "password": {"type": "string", "example": "password123"},
-              "name": {"type": "string", "example": "John Doe"}
+              "name": {"type": "string", "example": "John Doe"},
+              "username": {"type": "string", "example": "john_doe"}
             }
           }
         }],
@@ -69,6 +70,9 @@ const SwaggerJSON = `{
       "get": {
         "tags": ["Posts"],
         "summary": "Get all posts",
+        "parameters": [
+          {"in": "query", "name": "sort", "type": "string", "enum": ["top"]}
+        ],
         "responses": {
           "200": {"description": "List of posts"}
         }
@@ -85,7 +89,9 @@ const SwaggerJSON = `{
             "type": "object",
             "properties": {
               "title": {"type": "string", "example": "My First Post"},
-              "content": {"type": "string", "example": "This is the content"}
+              "content": {"type": "string", "example": "This is the content"},
+              "tags": {"type": "array", "items": {"type": "string"}, "example": ["go", "web-dev"]},
+              "featured_image_id": {"type": "integer", "example": 1}
             }
           }
         }],
@@ -127,7 +133,9 @@ const SwaggerJSON = `{
               "type": "object",
               "properties": {
                 "title": {"type": "string"},
-                "content": {"type": "string"}
+                "content": {"type": "string"},
+                "tags": {"type": "array", "items": {"type": "string"}, "description": "Omit to keep existing tags"},
+                "featured_image_id": {"type": "integer", "description": "Omit to keep, 0 to remove"}
               }
             }
           }
@@ -154,13 +162,18 @@ const SwaggerJSON = `{
     "/posts/{post_id}/comments": {
       "get": {
         "tags": ["Comments"],
-        "summary": "Get comments for post",
-        "parameters": [{
-          "in": "path",
-          "name": "post_id",
-          "required": true,
-          "type": "integer"
-        }],
+        "summary": "Get comments for post (cursor pagination)",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "post_id",
+            "required": true,
+            "type": "integer"
+          },
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"},
+          {"in": "query", "name": "sort", "type": "string", "enum": ["oldest", "newest", "top"], "default": "oldest"}
+        ],
         "responses": {
           "200": {"description": "List of comments"}
         }
@@ -216,6 +229,644 @@ const SwaggerJSON = `{
           "200": {"description": "Comment deleted"}
         }
       }
+    },
+    "/posts/{post_id}/comments/{comment_id}/restore": {
+      "post": {
+        "tags": ["Comments"],
+        "summary": "Restore deleted comment",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {
+            "in": "path",
+            "name": "post_id",
+            "required": true,
+            "type": "integer"
+          },
+          {
+            "in": "path",
+            "name": "comment_id",
+            "required": true,
+            "type": "integer"
+          }
+        ],
+        "responses": {
+          "200": {"description": "Comment restored"}
+        }
+      }
+    },
+    "/posts/{id}/reactions/{kind}": {
+      "put": {
+        "tags": ["Reactions"],
+        "summary": "Add reaction to post",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "path", "name": "kind", "required": true, "type": "string", "example": "like"}
+        ],
+        "responses": {
+          "200": {"description": "Reaction counts and viewer reactions"}
+        }
+      },
+      "delete": {
+        "tags": ["Reactions"],
+        "summary": "Remove reaction from post",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "path", "name": "kind", "required": true, "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Reaction counts and viewer reactions"}
+        }
+      }
+    },
+    "/posts/{post_id}/comments/{comment_id}/reactions/{kind}": {
+      "put": {
+        "tags": ["Reactions"],
+        "summary": "Add reaction to comment",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "post_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "comment_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "kind", "required": true, "type": "string", "example": "like"}
+        ],
+        "responses": {
+          "200": {"description": "Reaction counts and viewer reactions"}
+        }
+      },
+      "delete": {
+        "tags": ["Reactions"],
+        "summary": "Remove reaction from comment",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "post_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "comment_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "kind", "required": true, "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Reaction counts and viewer reactions"}
+        }
+      }
+    },
+    "/posts/{id}/bookmark": {
+      "put": {
+        "tags": ["Bookmarks"],
+        "summary": "Bookmark post",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Post bookmarked"}
+        }
+      },
+      "delete": {
+        "tags": ["Bookmarks"],
+        "summary": "Remove bookmark",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Bookmark removed"}
+        }
+      }
+    },
+    "/me/bookmarks": {
+      "get": {
+        "tags": ["Bookmarks"],
+        "summary": "List my bookmarks (cursor pagination)",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "List of bookmarks"}
+        }
+      }
+    },
+    "/me/reading-lists": {
+      "get": {
+        "tags": ["Reading Lists"],
+        "summary": "List my reading lists",
+        "security": [{"BearerAuth": []}],
+        "responses": {
+          "200": {"description": "List of reading lists"}
+        }
+      },
+      "post": {
+        "tags": ["Reading Lists"],
+        "summary": "Create reading list",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{
+          "in": "body",
+          "name": "body",
+          "required": true,
+          "schema": {
+            "type": "object",
+            "properties": {
+              "name": {"type": "string", "example": "Weekend reads"},
+              "description": {"type": "string"},
+              "is_public": {"type": "boolean"}
+            }
+          }
+        }],
+        "responses": {
+          "201": {"description": "Reading list created"}
+        }
+      }
+    },
+    "/me/reading-lists/{list_id}": {
+      "get": {
+        "tags": ["Reading Lists"],
+        "summary": "Get reading list with posts",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "list_id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Reading list details"}
+        }
+      },
+      "put": {
+        "tags": ["Reading Lists"],
+        "summary": "Update reading list",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "list_id", "required": true, "type": "integer"},
+          {
+            "in": "body",
+            "name": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "properties": {
+                "name": {"type": "string"},
+                "description": {"type": "string"},
+                "is_public": {"type": "boolean"}
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {"description": "Reading list updated"}
+        }
+      },
+      "delete": {
+        "tags": ["Reading Lists"],
+        "summary": "Delete reading list",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "list_id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Reading list deleted"}
+        }
+      }
+    },
+    "/me/reading-lists/{list_id}/posts/{post_id}": {
+      "put": {
+        "tags": ["Reading Lists"],
+        "summary": "Add post to reading list",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "list_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "post_id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Post added"}
+        }
+      },
+      "delete": {
+        "tags": ["Reading Lists"],
+        "summary": "Remove post from reading list",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "list_id", "required": true, "type": "integer"},
+          {"in": "path", "name": "post_id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Post removed"}
+        }
+      }
+    },
+    "/reading-lists/shared/{token}": {
+      "get": {
+        "tags": ["Reading Lists"],
+        "summary": "Get public reading list by share token",
+        "parameters": [
+          {"in": "path", "name": "token", "required": true, "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Reading list details"},
+          "404": {"description": "Not found or not public"}
+        }
+      }
+    },
+    "/users/{id}/follow": {
+      "put": {
+        "tags": ["Follows"],
+        "summary": "Follow user",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Following"}
+        }
+      },
+      "delete": {
+        "tags": ["Follows"],
+        "summary": "Unfollow user",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Unfollowed"}
+        }
+      }
+    },
+    "/users/{id}/followers": {
+      "get": {
+        "tags": ["Follows"],
+        "summary": "List followers (cursor pagination)",
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "List of users"}
+        }
+      }
+    },
+    "/users/{id}/following": {
+      "get": {
+        "tags": ["Follows"],
+        "summary": "List followed users (cursor pagination)",
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "List of users"}
+        }
+      }
+    },
+    "/feed": {
+      "get": {
+        "tags": ["Follows"],
+        "summary": "Posts from followed authors (cursor pagination)",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "List of posts"}
+        }
+      }
+    },
+    "/me/notifications": {
+      "get": {
+        "tags": ["Notifications"],
+        "summary": "Notification inbox with unread count (cursor pagination)",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "query", "name": "limit", "type": "integer", "default": 20},
+          {"in": "query", "name": "cursor", "type": "string"},
+          {"in": "query", "name": "unread", "type": "boolean"}
+        ],
+        "responses": {
+          "200": {"description": "List of notifications"}
+        }
+      }
+    },
+    "/me/notifications/{id}/read": {
+      "post": {
+        "tags": ["Notifications"],
+        "summary": "Mark notification as read",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "200": {"description": "Notification marked as read"}
+        }
+      }
+    },
+    "/me/notifications/read-all": {
+      "post": {
+        "tags": ["Notifications"],
+        "summary": "Mark all notifications as read",
+        "security": [{"BearerAuth": []}],
+        "responses": {
+          "200": {"description": "Number of updated notifications"}
+        }
+      }
+    },
+    "/me/notification-preferences": {
+      "get": {
+        "tags": ["Notifications"],
+        "summary": "Get notification preferences",
+        "security": [{"BearerAuth": []}],
+        "responses": {
+          "200": {"description": "Notification preferences"}
+        }
+      },
+      "put": {
+        "tags": ["Notifications"],
+        "summary": "Update notification preferences",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{
+          "in": "body",
+          "name": "body",
+          "required": true,
+          "schema": {
+            "type": "object",
+            "properties": {
+              "comment": {"type": "boolean"},
+              "reaction": {"type": "boolean"}
+            }
+          }
+        }],
+        "responses": {
+          "200": {"description": "Notification preferences updated"}
+        }
+      }
+    },
+    "/me/username": {
+      "put": {
+        "tags": ["Users"],
+        "summary": "Change my username",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{
+          "in": "body",
+          "name": "body",
+          "required": true,
+          "schema": {
+            "type": "object",
+            "properties": {
+              "username": {"type": "string", "example": "john_doe"}
+            }
+          }
+        }],
+        "responses": {
+          "200": {"description": "Username updated"},
+          "409": {"description": "Username already taken"}
+        }
+      }
+    },
+    "/users/search": {
+      "get": {
+        "tags": ["Users"],
+        "summary": "Username autocomplete for mentions",
+        "parameters": [
+          {"in": "query", "name": "prefix", "required": true, "type": "string"},
+          {"in": "query", "name": "limit", "type": "integer", "default": 10}
+        ],
+        "responses": {
+          "200": {"description": "List of users (id, username, name)"}
+        }
+      }
+    },
+    "/media": {
+      "post": {
+        "tags": ["Media"],
+        "summary": "Upload image (jpeg, png, gif, webp)",
+        "security": [{"BearerAuth": []}],
+        "consumes": ["multipart/form-data"],
+        "parameters": [
+          {"in": "formData", "name": "file", "type": "file", "required": true}
+        ],
+        "responses": {
+          "201": {"description": "Media uploaded (id, url, content_type, size, width, height, variant_status); variants and srcset are filled once variant_status is ready"},
+          "403": {"description": "Media quota exceeded"},
+          "413": {"description": "File too large"},
+          "415": {"description": "Unsupported media type"}
+        }
+      }
+    },
+    "/media/{id}": {
+      "get": {
+        "tags": ["Media"],
+        "summary": "Get media metadata",
+        "parameters": [{"in": "path", "name": "id", "required": true, "type": "integer"}],
+        "responses": {
+          "200": {"description": "Media"},
+          "404": {"description": "Media not found"}
+        }
+      },
+      "delete": {
+        "tags": ["Media"],
+        "summary": "Delete own media",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{"in": "path", "name": "id", "required": true, "type": "integer"}],
+        "responses": {
+          "200": {"description": "Media deleted"},
+          "403": {"description": "Not the owner"}
+        }
+      }
+    },
+    "/me/media": {
+      "get": {
+        "tags": ["Media"],
+        "summary": "List my media",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "query", "name": "limit", "type": "integer"},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Paginated list of media"}
+        }
+      }
+    },
+    "/me/webhooks": {
+      "post": {
+        "tags": ["Webhooks"],
+        "summary": "Create webhook subscription",
+        "description": "Deliveries are signed with X-Webhook-Signature: sha256=hex(HMAC-SHA256(secret, timestamp + \".\" + body)). The secret is only returned here and when rotated.",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{
+          "in": "body",
+          "name": "body",
+          "required": true,
+          "schema": {
+            "type": "object",
+            "required": ["url", "events"],
+            "properties": {
+              "url": {"type": "string", "example": "https://hooks.example.com/blog"},
+              "secret": {"type": "string", "description": "Optional, generated when empty"},
+              "events": {"type": "array", "items": {"type": "string", "enum": ["*", "post.created", "post.updated", "post.deleted", "comment.created", "comment.deleted"]}},
+              "active": {"type": "boolean", "example": true}
+            }
+          }
+        }],
+        "responses": {
+          "201": {"description": "Webhook created (includes secret)"},
+          "400": {"description": "Validation error"},
+          "409": {"description": "Webhook limit reached"}
+        }
+      },
+      "get": {
+        "tags": ["Webhooks"],
+        "summary": "List my webhooks",
+        "security": [{"BearerAuth": []}],
+        "responses": {
+          "200": {"description": "List of webhooks"}
+        }
+      }
+    },
+    "/me/webhooks/{id}": {
+      "get": {
+        "tags": ["Webhooks"],
+        "summary": "Get webhook",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{"in": "path", "name": "id", "required": true, "type": "integer"}],
+        "responses": {
+          "200": {"description": "Webhook"},
+          "404": {"description": "Webhook not found"}
+        }
+      },
+      "put": {
+        "tags": ["Webhooks"],
+        "summary": "Update webhook or rotate secret",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {
+            "in": "body",
+            "name": "body",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "properties": {
+                "url": {"type": "string"},
+                "secret": {"type": "string"},
+                "events": {"type": "array", "items": {"type": "string"}},
+                "active": {"type": "boolean"}
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {"description": "Webhook updated"},
+          "400": {"description": "Validation error"},
+          "404": {"description": "Webhook not found"}
+        }
+      },
+      "delete": {
+        "tags": ["Webhooks"],
+        "summary": "Delete webhook and its delivery log",
+        "security": [{"BearerAuth": []}],
+        "parameters": [{"in": "path", "name": "id", "required": true, "type": "integer"}],
+        "responses": {
+          "200": {"description": "Webhook deleted"},
+          "404": {"description": "Webhook not found"}
+        }
+      }
+    },
+    "/me/webhooks/{id}/deliveries": {
+      "get": {
+        "tags": ["Webhooks"],
+        "summary": "Webhook delivery log (newest first)",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "query", "name": "status", "type": "string", "enum": ["pending", "succeeded", "dead"]},
+          {"in": "query", "name": "limit", "type": "integer"},
+          {"in": "query", "name": "cursor", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Paginated deliveries (status, attempts, next_attempt_at, last_status_code, last_error, last_response)"},
+          "404": {"description": "Webhook not found"}
+        }
+      }
+    },
+    "/me/webhooks/{id}/deliveries/{delivery_id}/redeliver": {
+      "post": {
+        "tags": ["Webhooks"],
+        "summary": "Redeliver the event of a delivery as a new delivery",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "path", "name": "delivery_id", "required": true, "type": "integer"}
+        ],
+        "responses": {
+          "202": {"description": "Redelivery scheduled"},
+          "404": {"description": "Delivery not found"}
+        }
+      }
+    },
+    "/posts/{post_id}/comments/stream": {
+      "get": {
+        "tags": ["Comments"],
+        "summary": "Live comment stream (Server-Sent Events)",
+        "description": "text/event-stream with events comment.created (comment object) and comment.deleted ({id, post_id}). Send Last-Event-ID to resume; event reset means the ID is no longer buffered and comments should be reloaded.",
+        "produces": ["text/event-stream"],
+        "parameters": [
+          {"in": "path", "name": "post_id", "required": true, "type": "integer"},
+          {"in": "header", "name": "Last-Event-ID", "type": "string"},
+          {"in": "query", "name": "last_event_id", "type": "string"}
+        ],
+        "responses": {
+          "200": {"description": "Event stream"},
+          "404": {"description": "Post not found"},
+          "429": {"description": "Too many open streams"}
+        }
+      }
+    },
+    "/posts/{id}/presence": {
+      "get": {
+        "tags": ["Posts"],
+        "summary": "Editor presence WebSocket",
+        "description": "Upgrade to WebSocket. Client messages: heartbeat, state (viewing|editing), lock.acquire, lock.release. Server messages: welcome, presence, lock.denied, post.saved, error. The token may be passed as the access_token query parameter.",
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {"in": "path", "name": "id", "required": true, "type": "integer"},
+          {"in": "query", "name": "access_token", "type": "string"}
+        ],
+        "responses": {
+          "101": {"description": "Switching protocols"},
+          "401": {"description": "Unauthorized"},
+          "404": {"description": "Post not found"}
+        }
+      }
+    },
+    "/graphql": {
+      "post": {
+        "tags": ["GraphQL"],
+        "summary": "GraphQL endpoint",
+        "description": "Query users, posts and comments with their relations, and run post/comment mutations. The Authorization header is optional for queries and required for mutations. Operations deeper than GRAPHQL_MAX_DEPTH or more complex than GRAPHQL_MAX_COMPLEXITY are rejected with code QUERY_TOO_COMPLEX.",
+        "consumes": ["application/json"],
+        "produces": ["application/json"],
+        "security": [{"BearerAuth": []}],
+        "parameters": [
+          {
+            "in": "body",
+            "name": "request",
+            "required": true,
+            "schema": {
+              "type": "object",
+              "required": ["query"],
+              "properties": {
+                "query": {"type": "string"},
+                "operationName": {"type": "string"},
+                "variables": {"type": "object"}
+              }
+            }
+          }
+        ],
+        "responses": {
+          "200": {"description": "GraphQL result (data and errors)"},
+          "400": {"description": "Invalid request, syntax/validation error or query too complex"},
+          "401": {"description": "Invalid or expired token"}
+        }
+      }
     }
   }
 }`
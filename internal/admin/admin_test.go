@@ -0,0 +1,214 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"blog-api/internal/database"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// setupDB - Database SQLite baru per test (file, agar aman dipakai lebih dari satu koneksi)
+func setupDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "blog.db")), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	return db
+}
+
+func TestUserCommands(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	user, err := CreateUser(ctx, db, service.RegisterInput{Email: "ops@example.com", Password: "secret123", Name: "Ops"}, models.RoleAdmin)
+	if err != nil || user.Username != "ops" {
+		t.Fatalf("Expected user ops to be created, got %+v %v", user, err)
+	}
+	if _, err := CreateUser(ctx, db, service.RegisterInput{Email: "bad", Password: "secret123", Name: "Bad"}, models.RoleUser); err == nil {
+		t.Error("Expected invalid email to be rejected")
+	}
+
+	var stored models.User
+	db.First(&stored, user.ID)
+	if stored.Role != models.RoleAdmin {
+		t.Errorf("Expected admin role, got %q", stored.Role)
+	}
+
+	if _, err := SetRole(ctx, db, "ops", models.RoleUser); err != nil {
+		t.Fatalf("SetRole by username failed: %v", err)
+	}
+	if _, err := SetRole(ctx, db, "ops@example.com", "owner"); err == nil {
+		t.Error("Expected unknown role to be rejected")
+	}
+	db.First(&stored, user.ID)
+	if stored.Role != models.RoleUser {
+		t.Errorf("Expected user role, got %q", stored.Role)
+	}
+
+	password, err := ResetPassword(ctx, db, "ops@example.com", "")
+	if err != nil || password == "" {
+		t.Fatalf("Expected generated password, got %q %v", password, err)
+	}
+	db.First(&stored, user.ID)
+	if bcrypt.CompareHashAndPassword([]byte(stored.Password), []byte(password)) != nil {
+		t.Error("Expected stored hash to match the new password")
+	}
+	if _, err := ResetPassword(ctx, db, "nobody", "secret123"); err == nil {
+		t.Error("Expected unknown user to be rejected")
+	}
+}
+
+func TestSeed(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	opts := SeedOptions{Users: 3, PostsPerUser: 2, CommentsPerPost: 2, Password: "password123"}
+	result, err := Seed(ctx, db, opts)
+	if err != nil || result != (SeedResult{Users: 3, Posts: 6, Comments: 12}) {
+		t.Fatalf("Unexpected seed result %+v %v", result, err)
+	}
+
+	// Seed kedua melanjutkan nomor user demo
+	if _, err := Seed(ctx, db, SeedOptions{Users: 1, Password: "password123"}); err != nil {
+		t.Fatalf("Second seed failed: %v", err)
+	}
+	var count int64
+	db.Model(&models.User{}).Where("username = ?", "demo4").Count(&count)
+	if count != 1 {
+		t.Error("Expected second seed to create demo4")
+	}
+
+	var post models.Post
+	db.Preload("Tags").First(&post)
+	if post.CommentCount != 2 || len(post.Tags) == 0 {
+		t.Errorf("Expected seeded post with comments and tags, got %+v", post)
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t)
+	if _, err := Seed(ctx, db, SeedOptions{Users: 1, PostsPerUser: 3, CommentsPerPost: 2, Password: "password123"}); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+
+	var posts []models.Post
+	db.Order("id").Find(&posts)
+	var comments []models.Comment
+	db.Where("post_id = ?", posts[2].ID).Order("id").Find(&comments)
+
+	old := time.Now().Add(-60 * 24 * time.Hour)
+	db.Unscoped().Model(&models.Post{}).Where("id = ?", posts[0].ID).Update("deleted_at", old)
+	db.Unscoped().Model(&models.Post{}).Where("id = ?", posts[1].ID).Update("deleted_at", time.Now())
+	db.Unscoped().Model(&models.Comment{}).Where("id = ?", comments[0].ID).Update("deleted_at", old)
+	db.Create(&models.Bookmark{UserID: posts[0].UserID, PostID: posts[0].ID})
+
+	result, err := PurgeTrash(ctx, db, time.Now().Add(-30*24*time.Hour))
+	if err != nil || result.Posts != 1 || result.Comments != 3 {
+		t.Fatalf("Expected 1 post and 3 comments purged, got %+v %v", result, err)
+	}
+
+	var count int64
+	db.Unscoped().Model(&models.Post{}).Count(&count)
+	if count != 2 {
+		t.Errorf("Expected recently deleted post to stay in trash, got %d posts", count)
+	}
+	db.Model(&models.Bookmark{}).Count(&count)
+	if count != 0 {
+		t.Errorf("Expected bookmark of purged post to be removed, got %d", count)
+	}
+	db.Raw("SELECT COUNT(*) FROM post_tags WHERE post_id = ?", posts[0].ID).Scan(&count)
+	if count != 0 {
+		t.Errorf("Expected post_tags of purged post to be removed, got %d", count)
+	}
+}
+
+func TestReindex(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	db := setupDB(t)
+
+	jane := models.User{Email: "jane@example.com", Password: "x", Name: "Jane", Username: "jane"}
+	john := models.User{Email: "john@example.com", Password: "x", Name: "John", Username: "john"}
+	db.Create(&jane)
+	db.Create(&john)
+	post := models.Post{Title: "Hello", Content: "Thanks @jane", UserID: john.ID, CommentCount: 5, Score: 7}
+	db.Create(&post)
+	db.Create(&models.Comment{Content: "cc @john", UserID: jane.ID, PostID: post.ID})
+	db.Create(&models.Reaction{UserID: jane.ID, TargetType: models.ReactionTargetPost, TargetID: post.ID, Kind: "like"})
+	db.Create(&models.Mention{UserID: john.ID, TargetType: models.ReactionTargetPost, TargetID: post.ID, PostID: post.ID, AuthorID: john.ID})
+	db.Create(&models.Follow{FollowerID: jane.ID, FolloweeID: john.ID})
+
+	result, err := Reindex(ctx, db, true)
+	if err != nil {
+		t.Fatalf("Reindex failed: %v", err)
+	}
+	if result.MentionsAdded != 2 || result.MentionsRemoved != 1 || result.FeedEntries != 1 {
+		t.Errorf("Unexpected reindex result %+v", result)
+	}
+
+	var stored models.Post
+	db.First(&stored, post.ID)
+	if stored.CommentCount != 1 || stored.Score != 1 {
+		t.Errorf("Expected comment_count 1 and score 1, got %d and %d", stored.CommentCount, stored.Score)
+	}
+
+	if result, _ := Reindex(ctx, db, false); result.MentionsAdded != 0 || result.MentionsRemoved != 0 {
+		t.Errorf("Expected second reindex to be a no-op, got %+v", result)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	source := setupDB(t)
+	if _, err := Seed(ctx, source, SeedOptions{Users: 2, PostsPerUser: 2, CommentsPerPost: 3, Password: "password123"}); err != nil {
+		t.Fatalf("Seed failed: %v", err)
+	}
+	source.Model(&models.Post{}).Where("id = ?", 1).Update("deleted_at", time.Now())
+
+	var buf bytes.Buffer
+	dump, err := Export(ctx, source, &buf)
+	if err != nil || len(dump.Users) != 2 || len(dump.Posts) != 3 || len(dump.Comments) != 9 {
+		t.Fatalf("Unexpected export %d users %d posts %d comments %v", len(dump.Users), len(dump.Posts), len(dump.Comments), err)
+	}
+
+	target := setupDB(t)
+	target.Create(&models.User{Email: "demo1@example.com", Password: "x", Name: "Existing", Username: "demo1"})
+
+	result, err := Import(ctx, target, bytes.NewReader(buf.Bytes()))
+	if err != nil || result != (ImportResult{Users: 1, ExistingUsers: 1, Posts: 3, Comments: 9}) {
+		t.Fatalf("Unexpected import result %+v %v", result, err)
+	}
+
+	var post models.Post
+	target.Preload("Tags").Order("id").First(&post)
+	if post.Title != dump.Posts[0].Title || post.CommentCount != 3 || len(post.Tags) != len(dump.Posts[0].Tags) {
+		t.Errorf("Expected imported post with comments and tags, got %+v", post)
+	}
+
+	if _, err := Import(ctx, target, bytes.NewReader([]byte(`{"version": 99}`))); err == nil {
+		t.Error("Expected unsupported version to be rejected")
+	}
+}
@@ -0,0 +1,239 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// exportVersion - Versi format file export, dinaikkan jika struktur Dump berubah
+const exportVersion = 1
+
+// Dump - Isi file export: user (dengan hash password), post beserta tag, dan comment.
+// Konten di trash, media, reaction, follow dan notifikasi tidak ikut.
+type Dump struct {
+	Version    int           `json:"version"`
+	ExportedAt time.Time     `json:"exported_at"`
+	Users      []DumpUser    `json:"users"`
+	Posts      []DumpPost    `json:"posts"`
+	Comments   []DumpComment `json:"comments"`
+}
+
+type DumpUser struct {
+	ID           uint      `json:"id"`
+	Email        string    `json:"email"`
+	PasswordHash string    `json:"password_hash"`
+	Name         string    `json:"name"`
+	Username     string    `json:"username"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type DumpPost struct {
+	ID        uint      `json:"id"`
+	UserID    uint      `json:"user_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Tags      []string  `json:"tags"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type DumpComment struct {
+	ID        uint      `json:"id"`
+	PostID    uint      `json:"post_id"`
+	UserID    uint      `json:"user_id"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ImportResult - Jumlah baris yang dibuat, user dengan email yang sudah ada dipakai ulang
+type ImportResult struct {
+	Users         int
+	ExistingUsers int
+	Posts         int
+	Comments      int
+}
+
+// Export - Tulis semua konten aktif sebagai JSON ke w
+func Export(ctx context.Context, db *gorm.DB, w io.Writer) (Dump, error) {
+	db = db.WithContext(ctx)
+	dump := Dump{Version: exportVersion, ExportedAt: time.Now().UTC()}
+
+	var users []models.User
+	if err := db.Order("id ASC").Find(&users).Error; err != nil {
+		return dump, err
+	}
+	dump.Users = make([]DumpUser, len(users))
+	for i, user := range users {
+		dump.Users[i] = DumpUser{
+			ID:           user.ID,
+			Email:        user.Email,
+			PasswordHash: user.Password,
+			Name:         user.Name,
+			Username:     user.Username,
+			Role:         user.Role,
+			CreatedAt:    user.CreatedAt,
+		}
+	}
+
+	var posts []models.Post
+	if err := db.Preload("Tags").Order("id ASC").Find(&posts).Error; err != nil {
+		return dump, err
+	}
+	dump.Posts = make([]DumpPost, len(posts))
+	for i, post := range posts {
+		tags := make([]string, len(post.Tags))
+		for j, tag := range post.Tags {
+			tags[j] = tag.Name
+		}
+		dump.Posts[i] = DumpPost{
+			ID:        post.ID,
+			UserID:    post.UserID,
+			Title:     post.Title,
+			Content:   post.Content,
+			Tags:      tags,
+			CreatedAt: post.CreatedAt,
+			UpdatedAt: post.UpdatedAt,
+		}
+	}
+
+	// Comment milik post yang sudah dihapus tidak ikut agar import tidak kehilangan parent
+	var comments []models.Comment
+	err := db.Joins("JOIN posts ON posts.id = comments.post_id AND posts.deleted_at IS NULL").
+		Order("comments.id ASC").Find(&comments).Error
+	if err != nil {
+		return dump, err
+	}
+	dump.Comments = make([]DumpComment, len(comments))
+	for i, comment := range comments {
+		dump.Comments[i] = DumpComment{
+			ID:        comment.ID,
+			PostID:    comment.PostID,
+			UserID:    comment.UserID,
+			Content:   comment.Content,
+			CreatedAt: comment.CreatedAt,
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return dump, encoder.Encode(dump)
+}
+
+// Import - Baca file export dan buat ulang kontennya dalam satu transaksi. User dicocokkan
+// lewat email; post dan comment selalu dibuat baru (ID lama dipetakan ke ID baru).
+// Jalankan Reindex setelahnya agar tabel mentions terisi.
+func Import(ctx context.Context, db *gorm.DB, r io.Reader) (ImportResult, error) {
+	var result ImportResult
+
+	var dump Dump
+	if err := json.NewDecoder(r).Decode(&dump); err != nil {
+		return result, fmt.Errorf("invalid export file: %w", err)
+	}
+	if dump.Version != exportVersion {
+		return result, fmt.Errorf("unsupported export version %d", dump.Version)
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		userIDs := make(map[uint]uint, len(dump.Users))
+		for _, u := range dump.Users {
+			var existing models.User
+			err := tx.Where("email = ?", u.Email).Limit(1).Find(&existing).Error
+			if err != nil {
+				return err
+			}
+			if existing.ID != 0 {
+				userIDs[u.ID] = existing.ID
+				result.ExistingUsers++
+				continue
+			}
+
+			role := u.Role
+			if role == "" {
+				role = models.RoleUser
+			}
+			user := models.User{
+				Email:     u.Email,
+				Password:  u.PasswordHash,
+				Name:      u.Name,
+				Username:  u.Username,
+				Role:      role,
+				CreatedAt: u.CreatedAt,
+			}
+			if err := tx.Create(&user).Error; err != nil {
+				return fmt.Errorf("user %s: %w", u.Email, err)
+			}
+			userIDs[u.ID] = user.ID
+			result.Users++
+		}
+
+		postIDs := make(map[uint]uint, len(dump.Posts))
+		comments := make(map[uint]int64)
+		for _, c := range dump.Comments {
+			comments[c.PostID]++
+		}
+
+		posts := repository.NewGormStore(tx).Posts()
+		for _, p := range dump.Posts {
+			userID, ok := userIDs[p.UserID]
+			if !ok {
+				return fmt.Errorf("post %d references unknown user %d", p.ID, p.UserID)
+			}
+
+			post := models.Post{
+				Title:        p.Title,
+				Content:      p.Content,
+				UserID:       userID,
+				CommentCount: comments[p.ID],
+				CreatedAt:    p.CreatedAt,
+				UpdatedAt:    p.UpdatedAt,
+			}
+			if err := tx.Create(&post).Error; err != nil {
+				return fmt.Errorf("post %d: %w", p.ID, err)
+			}
+			if len(p.Tags) > 0 {
+				if err := posts.ReplaceTags(ctx, &post, p.Tags); err != nil {
+					return fmt.Errorf("post %d tags: %w", p.ID, err)
+				}
+			}
+			postIDs[p.ID] = post.ID
+			result.Posts++
+		}
+
+		for _, c := range dump.Comments {
+			postID, ok := postIDs[c.PostID]
+			if !ok {
+				return fmt.Errorf("comment %d references unknown post %d", c.ID, c.PostID)
+			}
+			userID, ok := userIDs[c.UserID]
+			if !ok {
+				return fmt.Errorf("comment %d references unknown user %d", c.ID, c.UserID)
+			}
+
+			comment := models.Comment{
+				Content:   c.Content,
+				UserID:    userID,
+				PostID:    postID,
+				CreatedAt: c.CreatedAt,
+				UpdatedAt: c.CreatedAt,
+			}
+			if err := tx.Create(&comment).Error; err != nil {
+				return fmt.Errorf("comment %d: %w", c.ID, err)
+			}
+			result.Comments++
+		}
+		return nil
+	})
+	if err != nil {
+		return ImportResult{}, err
+	}
+	return result, nil
+}
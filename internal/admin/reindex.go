@@ -0,0 +1,169 @@
+package admin
+
+import (
+	"context"
+
+	"blog-api/internal/mention"
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// reindexBatchSize - Jumlah post/comment yang di-scan per query saat membangun ulang mention
+const reindexBatchSize = 500
+
+// ReindexResult - Jumlah mention yang ditambah dan dihapus, serta baris feed yang dibangun ulang
+type ReindexResult struct {
+	MentionsAdded   int
+	MentionsRemoved int
+	FeedEntries     int64
+}
+
+// Reindex - Hitung ulang data turunan dari sumbernya: comment_count, score, tabel mentions
+// (index @username yang dipakai content_html) dan feed_entries jika fanout aktif.
+// Tidak mengirim notifikasi untuk mention yang baru ditemukan.
+func Reindex(ctx context.Context, db *gorm.DB, feedFanout bool) (ReindexResult, error) {
+	var result ReindexResult
+	db = db.WithContext(ctx)
+
+	err := db.Exec(`UPDATE posts SET comment_count = (
+		SELECT COUNT(*) FROM comments WHERE comments.post_id = posts.id AND comments.deleted_at IS NULL
+	)`).Error
+	if err != nil {
+		return result, err
+	}
+	for _, table := range []string{"posts", "comments"} {
+		targetType := models.ReactionTargetPost
+		if table == "comments" {
+			targetType = models.ReactionTargetComment
+		}
+		err := db.Exec(`UPDATE `+table+` SET score = (
+			SELECT COUNT(*) FROM reactions WHERE reactions.target_type = ? AND reactions.target_id = `+table+`.id
+		)`, targetType).Error
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if err := reindexMentions(db, models.ReactionTargetPost, &result); err != nil {
+		return result, err
+	}
+	if err := reindexMentions(db, models.ReactionTargetComment, &result); err != nil {
+		return result, err
+	}
+
+	if feedFanout {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("DELETE FROM feed_entries").Error; err != nil {
+				return err
+			}
+			insert := tx.Exec(`INSERT INTO feed_entries (user_id, post_id, author_id, created_at)
+				SELECT follows.follower_id, posts.id, posts.user_id, posts.created_at
+				FROM follows JOIN posts ON posts.user_id = follows.followee_id
+				WHERE posts.deleted_at IS NULL`)
+			result.FeedEntries = insert.RowsAffected
+			return insert.Error
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// reindexMentions - Samakan tabel mentions dengan @username di content post atau comment
+func reindexMentions(db *gorm.DB, targetType string, result *ReindexResult) error {
+	var lastID uint
+	for {
+		var targets []struct {
+			ID      uint
+			PostID  uint
+			UserID  uint
+			Content string
+		}
+
+		query := db.Table("posts").Select("id, id AS post_id, user_id, content")
+		if targetType == models.ReactionTargetComment {
+			query = db.Table("comments").Select("id, post_id, user_id, content")
+		}
+		err := query.Where("id > ? AND deleted_at IS NULL", lastID).
+			Order("id ASC").Limit(reindexBatchSize).Scan(&targets).Error
+		if err != nil || len(targets) == 0 {
+			return err
+		}
+		lastID = targets[len(targets)-1].ID
+
+		ids := make([]uint, len(targets))
+		var usernames []string
+		for i, target := range targets {
+			ids[i] = target.ID
+			usernames = append(usernames, mention.Parse(target.Content)...)
+		}
+
+		userIDs := make(map[string]uint)
+		if len(usernames) > 0 {
+			var users []models.User
+			if err := db.Select("id", "username").Where("username IN ?", usernames).Find(&users).Error; err != nil {
+				return err
+			}
+			for _, user := range users {
+				userIDs[user.Username] = user.ID
+			}
+		}
+
+		var existing []models.Mention
+		if err := db.Where("target_type = ? AND target_id IN ?", targetType, ids).Find(&existing).Error; err != nil {
+			return err
+		}
+		stored := make(map[[2]uint]models.Mention, len(existing))
+		for _, m := range existing {
+			stored[[2]uint{m.TargetID, m.UserID}] = m
+		}
+
+		var missing []models.Mention
+		for _, target := range targets {
+			for _, username := range mention.Parse(target.Content) {
+				userID, ok := userIDs[username]
+				if !ok {
+					continue
+				}
+				key := [2]uint{target.ID, userID}
+				if _, ok := stored[key]; ok {
+					delete(stored, key)
+					continue
+				}
+				missing = append(missing, models.Mention{
+					UserID:     userID,
+					TargetType: targetType,
+					TargetID:   target.ID,
+					PostID:     target.PostID,
+					AuthorID:   target.UserID,
+				})
+			}
+		}
+
+		// Sisa di stored adalah mention yang sudah tidak ada di content
+		stale := make([]uint, 0, len(stored))
+		for _, m := range stored {
+			stale = append(stale, m.ID)
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if len(stale) > 0 {
+				if err := tx.Where("id IN ?", stale).Delete(&models.Mention{}).Error; err != nil {
+					return err
+				}
+			}
+			if len(missing) > 0 {
+				return tx.Create(&missing).Error
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		result.MentionsAdded += len(missing)
+		result.MentionsRemoved += len(stale)
+	}
+}
@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"blog-api/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeedOptions - Volume konten demo yang dibuat
+type SeedOptions struct {
+	Users           int
+	PostsPerUser    int
+	CommentsPerPost int
+	Password        string // Password semua user demo
+}
+
+// SeedResult - Jumlah baris yang dibuat
+type SeedResult struct {
+	Users    int
+	Posts    int
+	Comments int
+}
+
+var (
+	seedTags     = []string{"go", "database", "devops", "tutorial", "opinion", "release", "security", "frontend"}
+	seedSubjects = []string{"Connection pooling", "Soft deletes", "Cursor pagination", "Graceful shutdown", "Feature flags", "Webhook retries", "Image variants", "Rate limiting"}
+	seedAngles   = []string{"in practice", "explained", "without the hype", "for small teams", "the hard way", "revisited"}
+	seedComments = []string{"Great write-up, thanks!", "We hit the same issue last month.", "Could you share the benchmark setup?", "This saved me a few hours.", "I disagree with the second point, but nice post.", "Bookmarked for later."}
+)
+
+// Seed - Buat user demo (demoN@example.com) beserta post, tag dan comment acak dalam satu transaksi.
+// Nomor user melanjutkan user demo yang sudah ada, sehingga seed bisa dijalankan berulang.
+func Seed(ctx context.Context, db *gorm.DB, opts SeedOptions) (SeedResult, error) {
+	var result SeedResult
+	if opts.Users < 1 || opts.PostsPerUser < 0 || opts.CommentsPerPost < 0 {
+		return result, fmt.Errorf("users must be at least 1 and counts must not be negative")
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(opts.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return result, err
+	}
+
+	rng := rand.New(rand.NewPCG(uint64(time.Now().UnixNano()), 0))
+	now := time.Now()
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []string
+		if err := tx.Unscoped().Model(&models.User{}).Where("username LIKE ?", "demo%").Pluck("username", &existing).Error; err != nil {
+			return err
+		}
+		taken := make(map[string]bool, len(existing))
+		for _, username := range existing {
+			taken[username] = true
+		}
+
+		users := make([]models.User, 0, opts.Users)
+		for n := 1; len(users) < opts.Users; n++ {
+			username := fmt.Sprintf("demo%d", n)
+			if taken[username] {
+				continue
+			}
+			users = append(users, models.User{
+				Email:    username + "@example.com",
+				Password: string(hashed),
+				Name:     fmt.Sprintf("Demo User %d", n),
+				Username: username,
+			})
+		}
+		if err := tx.CreateInBatches(&users, 100).Error; err != nil {
+			return err
+		}
+
+		tags, err := ensureTags(tx, seedTags)
+		if err != nil {
+			return err
+		}
+
+		posts := make([]models.Post, 0, len(users)*opts.PostsPerUser)
+		for _, user := range users {
+			for i := 0; i < opts.PostsPerUser; i++ {
+				createdAt := now.Add(-time.Duration(rng.Int64N(int64(90 * 24 * time.Hour))))
+				subject := seedSubjects[rng.IntN(len(seedSubjects))]
+				posts = append(posts, models.Post{
+					Title:        subject + " " + seedAngles[rng.IntN(len(seedAngles))],
+					Content:      seedContent(rng, subject),
+					UserID:       user.ID,
+					Tags:         pickTags(rng, tags),
+					CommentCount: int64(opts.CommentsPerPost),
+					CreatedAt:    createdAt,
+					UpdatedAt:    createdAt,
+				})
+			}
+		}
+		if len(posts) > 0 {
+			if err := tx.CreateInBatches(&posts, 100).Error; err != nil {
+				return err
+			}
+		}
+
+		comments := make([]models.Comment, 0, len(posts)*opts.CommentsPerPost)
+		for _, post := range posts {
+			for i := 0; i < opts.CommentsPerPost; i++ {
+				createdAt := post.CreatedAt.Add(time.Duration(rng.Int64N(int64(now.Sub(post.CreatedAt)) + 1)))
+				comments = append(comments, models.Comment{
+					Content:   seedComments[rng.IntN(len(seedComments))],
+					UserID:    users[rng.IntN(len(users))].ID,
+					PostID:    post.ID,
+					CreatedAt: createdAt,
+					UpdatedAt: createdAt,
+				})
+			}
+		}
+		if len(comments) > 0 {
+			if err := tx.CreateInBatches(&comments, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		result = SeedResult{Users: len(users), Posts: len(posts), Comments: len(comments)}
+		return nil
+	})
+	return result, err
+}
+
+// ensureTags - Buat tag yang belum ada lalu kembalikan semuanya dengan ID
+func ensureTags(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	tags := make([]models.Tag, len(names))
+	for i, name := range names {
+		tags[i] = models.Tag{Name: name}
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+		return nil, err
+	}
+
+	tags = tags[:0]
+	err := tx.Where("name IN ?", names).Find(&tags).Error
+	return tags, err
+}
+
+func pickTags(rng *rand.Rand, tags []models.Tag) []models.Tag {
+	picked := make([]models.Tag, 0, 3)
+	for _, i := range rng.Perm(len(tags))[:1+rng.IntN(3)] {
+		picked = append(picked, tags[i])
+	}
+	return picked
+}
+
+func seedContent(rng *rand.Rand, subject string) string {
+	sentences := []string{
+		subject + " looks simple until it meets production traffic.",
+		"This post walks through the trade-offs we ran into and what we would do differently.",
+		"The short version: measure first, then pick the boring option.",
+		"Most of the complexity comes from failure modes rather than the happy path.",
+		"We ended up with a small amount of code and a large amount of documentation.",
+	}
+	rng.Shuffle(len(sentences), func(i, j int) { sentences[i], sentences[j] = sentences[j], sentences[i] })
+	return strings.Join(sentences[:3+rng.IntN(3)], " ")
+}
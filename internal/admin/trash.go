@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// purgeBatchSize - Jumlah post/comment yang dihapus permanen per transaksi
+const purgeBatchSize = 500
+
+// PurgeResult - Jumlah post dan comment yang dihapus permanen
+type PurgeResult struct {
+	Posts    int64
+	Comments int64
+}
+
+// PurgeTrash - Hapus permanen post dan comment yang sudah di-soft delete sebelum cutoff,
+// beserta data turunannya (tag, bookmark, reaction, mention, notifikasi, feed)
+func PurgeTrash(ctx context.Context, db *gorm.DB, cutoff time.Time) (PurgeResult, error) {
+	var result PurgeResult
+	db = db.WithContext(ctx)
+
+	// Post dulu: comment milik post yang di-purge ikut terhapus walau tidak di trash
+	if err := purgePosts(db, cutoff, &result); err != nil {
+		return result, err
+	}
+	err := purgeComments(db, cutoff, &result)
+	return result, err
+}
+
+// purgePosts - Post di trash yang lebih lama dari cutoff beserta semua comment-nya
+func purgePosts(db *gorm.DB, cutoff time.Time, result *PurgeResult) error {
+	for {
+		var postIDs []uint
+		err := db.Unscoped().Model(&models.Post{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Limit(purgeBatchSize).Pluck("id", &postIDs).Error
+		if err != nil || len(postIDs) == 0 {
+			return err
+		}
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			var commentIDs []uint
+			if err := tx.Unscoped().Model(&models.Comment{}).Where("post_id IN ?", postIDs).Pluck("id", &commentIDs).Error; err != nil {
+				return err
+			}
+			if err := purgeCommentRows(tx, commentIDs); err != nil {
+				return err
+			}
+
+			for _, stmt := range []struct {
+				model interface{}
+				where string
+				args  []interface{}
+			}{
+				{&models.Reaction{}, "target_type = ? AND target_id IN ?", []interface{}{models.ReactionTargetPost, postIDs}},
+				{&models.Mention{}, "post_id IN ?", []interface{}{postIDs}},
+				{&models.Bookmark{}, "post_id IN ?", []interface{}{postIDs}},
+				{&models.ReadingListItem{}, "post_id IN ?", []interface{}{postIDs}},
+				{&models.FeedEntry{}, "post_id IN ?", []interface{}{postIDs}},
+				{&models.EditorSession{}, "post_id IN ?", []interface{}{postIDs}},
+				{&models.PostEditLock{}, "post_id IN ?", []interface{}{postIDs}},
+			} {
+				if err := tx.Where(stmt.where, stmt.args...).Delete(stmt.model).Error; err != nil {
+					return err
+				}
+			}
+			if err := deleteNotifications(tx, "post_id IN ?", postIDs); err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM post_tags WHERE post_id IN ?", postIDs).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("id IN ?", postIDs).Delete(&models.Post{}).Error; err != nil {
+				return err
+			}
+
+			result.Posts += int64(len(postIDs))
+			result.Comments += int64(len(commentIDs))
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// purgeComments - Comment di trash (post-nya masih ada) yang lebih lama dari cutoff
+func purgeComments(db *gorm.DB, cutoff time.Time, result *PurgeResult) error {
+	for {
+		var commentIDs []uint
+		err := db.Unscoped().Model(&models.Comment{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Limit(purgeBatchSize).Pluck("id", &commentIDs).Error
+		if err != nil || len(commentIDs) == 0 {
+			return err
+		}
+
+		if err := db.Transaction(func(tx *gorm.DB) error { return purgeCommentRows(tx, commentIDs) }); err != nil {
+			return err
+		}
+		result.Comments += int64(len(commentIDs))
+	}
+}
+
+// purgeCommentRows - Hapus permanen comment beserta reaction, mention dan notifikasinya
+func purgeCommentRows(tx *gorm.DB, commentIDs []uint) error {
+	if len(commentIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("target_type = ? AND target_id IN ?", models.ReactionTargetComment, commentIDs).Delete(&models.Reaction{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("target_type = ? AND target_id IN ?", models.ReactionTargetComment, commentIDs).Delete(&models.Mention{}).Error; err != nil {
+		return err
+	}
+	if err := deleteNotifications(tx, "comment_id IN ?", commentIDs); err != nil {
+		return err
+	}
+	return tx.Unscoped().Where("id IN ?", commentIDs).Delete(&models.Comment{}).Error
+}
+
+func deleteNotifications(tx *gorm.DB, where string, ids []uint) error {
+	var notificationIDs []uint
+	if err := tx.Model(&models.Notification{}).Where(where, ids).Pluck("id", &notificationIDs).Error; err != nil {
+		return err
+	}
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	if err := tx.Where("notification_id IN ?", notificationIDs).Delete(&models.NotificationActor{}).Error; err != nil {
+		return err
+	}
+	return tx.Where("id IN ?", notificationIDs).Delete(&models.Notification{}).Error
+}
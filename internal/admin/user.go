@@ -0,0 +1,102 @@
+// Package admin - Tugas operasional yang dijalankan lewat subcommand binary
+// (user, seed, purge-trash, reindex, export/import), bukan lewat HTTP API
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+	"blog-api/internal/service"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// CreateUser - Registrasi user dengan validasi yang sama seperti POST /api/register, lalu set role
+func CreateUser(ctx context.Context, db *gorm.DB, input service.RegisterInput, role string) (models.User, error) {
+	if err := validateRole(role); err != nil {
+		return models.User{}, err
+	}
+
+	user, err := service.NewUserService(repository.NewGormStore(db)).Register(ctx, input)
+	if err != nil {
+		return user, err
+	}
+
+	if role != models.RoleUser {
+		if err := db.WithContext(ctx).Model(&user).Update("role", role).Error; err != nil {
+			return user, err
+		}
+	}
+	user.Role = role
+	return user, nil
+}
+
+// SetRole - Ganti role user yang dicari lewat email atau username
+func SetRole(ctx context.Context, db *gorm.DB, login, role string) (models.User, error) {
+	if err := validateRole(role); err != nil {
+		return models.User{}, err
+	}
+
+	user, err := findUser(ctx, db, login)
+	if err != nil {
+		return user, err
+	}
+
+	if err := db.WithContext(ctx).Model(&user).Update("role", role).Error; err != nil {
+		return user, err
+	}
+	return user, nil
+}
+
+// ResetPassword - Set password baru, password kosong berarti dibuat acak (dikembalikan untuk ditampilkan)
+func ResetPassword(ctx context.Context, db *gorm.DB, login, password string) (string, error) {
+	if password == "" {
+		raw := make([]byte, 12)
+		if _, err := rand.Read(raw); err != nil {
+			return "", err
+		}
+		password = base64.RawURLEncoding.EncodeToString(raw)
+	}
+	if !service.ValidatePassword(password) {
+		return "", errors.New("password must be at least 6 characters")
+	}
+
+	user, err := findUser(ctx, db, login)
+	if err != nil {
+		return "", err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	if err := db.WithContext(ctx).Model(&user).Update("password", string(hashed)).Error; err != nil {
+		return "", err
+	}
+	return password, nil
+}
+
+// findUser - User berdasarkan email atau username
+func findUser(ctx context.Context, db *gorm.DB, login string) (models.User, error) {
+	var user models.User
+	err := db.WithContext(ctx).
+		Where("email = ? OR username = ?", login, service.NormalizeUsername(login)).
+		First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return user, fmt.Errorf("user %q not found", login)
+	}
+	return user, err
+}
+
+func validateRole(role string) error {
+	if role != models.RoleUser && role != models.RoleAdmin {
+		return fmt.Errorf("invalid role %q, must be: %s, %s", role, models.RoleUser, models.RoleAdmin)
+	}
+	return nil
+}
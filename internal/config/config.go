@@ -1,8 +1,13 @@
 package config
 
 import (
-	"log"
+	"fmt"
+	"log/slog"
+	"net/netip"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -15,12 +20,126 @@ type Config struct {
 	DBName     string
 	JWTSecret  string
 	ServerPort string
+	// GRPCPort - Port server gRPC (GRPC_PORT), terpisah dari port HTTP
+	GRPCPort string
+
+	// HTTP server: batas waktu baca (body dan header), tulis response, koneksi keep-alive idle,
+	// ukuran header maksimal, dan batas waktu menunggu request berjalan selesai saat shutdown.
+	// ShutdownDelay memberi waktu load balancer melihat health check 503 sebelum listener ditutup.
+	HTTPReadTimeout       time.Duration
+	HTTPReadHeaderTimeout time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+	HTTPMaxHeaderBytes    int
+	ShutdownTimeout       time.Duration
+	ShutdownDelay         time.Duration
+
+	// Readiness probe (/readyz): batas waktu tiap pengecekan dependency, dan lama hasil di-cache
+	// agar probe yang sering tidak membebani database
+	ReadinessTimeout  time.Duration
+	ReadinessCacheTTL time.Duration
+
+	// Logging: LOG_FORMAT json atau text, LOG_LEVEL debug, info, warn atau error
+	LogFormat string
+	LogLevel  string
+
+	// MetricsPort - Port admin terpisah untuk /metrics (METRICS_PORT), kosong berarti /metrics
+	// dilayani di port HTTP utama
+	MetricsPort string
+
+	// Tracing OpenTelemetry: TRACING_EXPORTER none, otlp (endpoint dari OTEL_EXPORTER_OTLP_*)
+	// atau stdout (TRACING_FILE untuk menulis ke file), TRACING_SAMPLE_RATIO 0..1 untuk trace baru
+	TracingExporter    string
+	TracingFile        string
+	TracingSampleRatio float64
+
+	// AutoMigrate - Terapkan migrasi SQL yang pending saat server start (DB_AUTO_MIGRATE),
+	// matikan jika migrasi dijalankan terpisah lewat "blog-api migrate up"
+	AutoMigrate bool
+
+	// ReactionKinds - Daftar jenis reaction yang diizinkan (REACTION_KINDS, dipisah koma)
+	ReactionKinds []string
+
+	// FeedFanout - Simpan feed per pembaca di tabel feed_entries saat post dibuat
+	// (FEED_FANOUT=true), default feed dihitung langsung dari tabel follows
+	FeedFanout bool
+
+	// PublicBaseURL - URL publik frontend (PUBLIC_BASE_URL, tanpa slash di akhir) untuk link
+	// di sitemap, robots.txt dan feed; kosong berarti memakai scheme dan host dari request
+	PublicBaseURL string
+
+	// Media upload
+	StorageDriver   string // STORAGE_DRIVER: local (default) atau s3
+	StorageLocalDir string // Direktori file untuk driver local
+	S3Endpoint      string // Endpoint S3-compatible, misal https://s3.amazonaws.com atau http://minio:9000
+	S3Region        string
+	S3Bucket        string
+	S3AccessKey     string
+	S3SecretKey     string
+	S3PublicURL     string // URL publik bucket (CDN), kosong berarti endpoint/bucket
+	MediaMaxBytes   int64  // Ukuran maksimal per file
+	MediaQuotaBytes int64  // Total ukuran media per user
+
+	// MediaMaxPixels - Batas lebar x tinggi gambar (MEDIA_MAX_PIXELS). File kecil bisa mendeklarasikan
+	// dimensi sangat besar (decompression bomb) yang menghabiskan memory saat di-decode worker.
+	MediaMaxPixels int64
+
+	// MediaVariantWidths - Lebar variant gambar yang dibuat worker (MEDIA_VARIANT_WIDTHS, dipisah koma)
+	MediaVariantWidths []int
+	// MediaVariantFormats - Format encode ulang variant: jpeg, webp (MEDIA_VARIANT_FORMATS)
+	MediaVariantFormats []string
+	// MediaWorkers - Jumlah goroutine worker pembuat variant
+	MediaWorkers int
+
+	// Webhook keluar
+	WebhookWorkers      int  // Jumlah goroutine pengirim (WEBHOOK_WORKERS)
+	WebhookMaxAttempts  int  // Percobaan maksimal sebelum delivery masuk dead letter
+	WebhookAllowPrivate bool // Izinkan URL ke loopback/jaringan privat (WEBHOOK_ALLOW_PRIVATE_NETWORKS, untuk development)
+
+	// RealtimeBroker - Pub/sub event realtime (REALTIME_BROKER): memory untuk satu instance,
+	// postgres (LISTEN/NOTIFY) jika server dijalankan lebih dari satu replica
+	RealtimeBroker string
+	// SSEMaxStreamsPerClient - Koneksi SSE bersamaan per IP client
+	SSEMaxStreamsPerClient int
+	// SSEMaxDuration - Umur maksimal satu koneksi SSE, client reconnect otomatis dengan Last-Event-ID
+	SSEMaxDuration time.Duration
+
+	// GraphQL: batas kedalaman selection set dan estimasi complexity (jumlah field
+	// dikalikan limit list) per operation, query di atas batas ditolak sebelum dieksekusi
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// Rate limiting token bucket: RATE_LIMIT_STORE memory untuk satu instance, postgres
+	// (tabel rate_limit_buckets) jika server dijalankan lebih dari satu replica
+	RateLimitEnabled bool
+	RateLimitStore   string
+	// RateLimitPolicies - Kebijakan per route (register, login, comment), dari RATE_LIMIT_<ROUTE>
+	// dengan format "jumlah/periode", misal "10/1m"; "off" mematikan limit route tersebut
+	RateLimitPolicies map[string]RateLimitPolicy
+
+	// TrustedProxies - IP/CIDR reverse proxy (TRUSTED_PROXIES, dipisah koma) yang X-Forwarded-For-nya
+	// dipercaya untuk menentukan IP client; kosong berarti selalu memakai IP koneksi
+	TrustedProxies []netip.Prefix
+}
+
+// RateLimitPolicy - Token bucket berkapasitas Requests yang terisi penuh kembali dalam Period.
+// KeyBy "ip" menghitung per IP client, "user" per user login (fallback ke IP jika anonim).
+type RateLimitPolicy struct {
+	Requests int
+	Period   time.Duration
+	KeyBy    string
+}
+
+// String - Format yang sama dengan env RATE_LIMIT_<ROUTE>
+func (p RateLimitPolicy) String() string {
+	return fmt.Sprintf("%d/%s", p.Requests, p.Period)
 }
 
 func LoadConfig() *Config {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found, using environment variables")
+		// Tanpa .env cukup environment variables (umum di container), bukan kondisi error
+		slog.Debug("no .env file found, using environment variables")
 	}
 
 	return &Config{
@@ -31,6 +150,71 @@ func LoadConfig() *Config {
 		DBName:     getEnv("DB_NAME", "blogdb"),
 		JWTSecret:  getEnv("JWT_SECRET", "abcd1234"),
 		ServerPort: getEnv("SERVER_PORT", "8080"),
+		GRPCPort:   getEnv("GRPC_PORT", "9090"),
+
+		HTTPReadTimeout:       getEnvDuration("HTTP_READ_TIMEOUT", 15*time.Second),
+		HTTPReadHeaderTimeout: getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		HTTPWriteTimeout:      getEnvDuration("HTTP_WRITE_TIMEOUT", 30*time.Second),
+		HTTPIdleTimeout:       getEnvDuration("HTTP_IDLE_TIMEOUT", 120*time.Second),
+		HTTPMaxHeaderBytes:    int(getEnvInt64("HTTP_MAX_HEADER_BYTES", 1<<20)),
+		ShutdownTimeout:       getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		ShutdownDelay:         getEnvDuration("SHUTDOWN_DELAY", 0),
+
+		ReadinessTimeout:  getEnvDuration("READINESS_TIMEOUT", 2*time.Second),
+		ReadinessCacheTTL: getEnvDuration("READINESS_CACHE_TTL", 2*time.Second),
+
+		LogFormat: getEnv("LOG_FORMAT", "json"),
+		LogLevel:  getEnv("LOG_LEVEL", "info"),
+
+		MetricsPort: getEnv("METRICS_PORT", ""),
+
+		TracingExporter:    getEnv("TRACING_EXPORTER", "none"),
+		TracingFile:        getEnv("TRACING_FILE", ""),
+		TracingSampleRatio: getEnvFloat("TRACING_SAMPLE_RATIO", 1),
+
+		AutoMigrate: getEnvBool("DB_AUTO_MIGRATE", true),
+
+		ReactionKinds: getEnvList("REACTION_KINDS", "like,love,haha,wow,sad"),
+		FeedFanout:    getEnvBool("FEED_FANOUT", false),
+		PublicBaseURL: strings.TrimRight(getEnv("PUBLIC_BASE_URL", ""), "/"),
+
+		StorageDriver:   getEnv("STORAGE_DRIVER", "local"),
+		StorageLocalDir: getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+		S3Endpoint:      strings.TrimRight(getEnv("S3_ENDPOINT", ""), "/"),
+		S3Region:        getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:        getEnv("S3_BUCKET", ""),
+		S3AccessKey:     getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:     getEnv("S3_SECRET_KEY", ""),
+		S3PublicURL:     strings.TrimRight(getEnv("S3_PUBLIC_URL", ""), "/"),
+		MediaMaxBytes:   getEnvInt64("MEDIA_MAX_BYTES", 10<<20),
+		MediaQuotaBytes: getEnvInt64("MEDIA_QUOTA_BYTES", 200<<20),
+
+		MediaMaxPixels: getEnvInt64("MEDIA_MAX_PIXELS", 40_000_000),
+
+		MediaVariantWidths:  getEnvIntList("MEDIA_VARIANT_WIDTHS", "320,640,1280"),
+		MediaVariantFormats: getEnvList("MEDIA_VARIANT_FORMATS", "jpeg,webp"),
+		MediaWorkers:        int(getEnvInt64("MEDIA_WORKERS", 2)),
+
+		WebhookWorkers:      int(getEnvInt64("WEBHOOK_WORKERS", 2)),
+		WebhookMaxAttempts:  int(getEnvInt64("WEBHOOK_MAX_ATTEMPTS", 8)),
+		WebhookAllowPrivate: getEnvBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+
+		RealtimeBroker:         getEnv("REALTIME_BROKER", "memory"),
+		SSEMaxStreamsPerClient: int(getEnvInt64("SSE_MAX_STREAMS_PER_CLIENT", 5)),
+		SSEMaxDuration:         getEnvDuration("SSE_MAX_DURATION", 30*time.Minute),
+
+		GraphQLMaxDepth:      int(getEnvInt64("GRAPHQL_MAX_DEPTH", 8)),
+		GraphQLMaxComplexity: int(getEnvInt64("GRAPHQL_MAX_COMPLEXITY", 1000)),
+
+		RateLimitEnabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+		RateLimitStore:   getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitPolicies: getEnvRateLimits(map[string]RateLimitPolicy{
+			"register": {Requests: 5, Period: time.Hour, KeyBy: "ip"},
+			"login":    {Requests: 10, Period: time.Minute, KeyBy: "ip"},
+			"comment":  {Requests: 20, Period: time.Minute, KeyBy: "user"},
+		}),
+
+		TrustedProxies: getEnvPrefixList("TRUSTED_PROXIES", ""),
 	}
 }
 
@@ -40,3 +224,91 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvInt64(key string, defaultValue int64) int64 {
+	value, err := strconv.ParseInt(os.Getenv(key), 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvIntList(key, defaultValue string) []int {
+	var values []int
+	for _, value := range getEnvList(key, defaultValue) {
+		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
+			values = append(values, parsed)
+		}
+	}
+	return values
+}
+
+// getEnvRateLimits - Timpa jumlah/periode default dari RATE_LIMIT_<ROUTE>; nilai yang tidak
+// valid diabaikan, "off" atau "0" menghapus policy route tersebut
+func getEnvRateLimits(defaults map[string]RateLimitPolicy) map[string]RateLimitPolicy {
+	policies := make(map[string]RateLimitPolicy, len(defaults))
+	for name, policy := range defaults {
+		value := strings.TrimSpace(os.Getenv("RATE_LIMIT_" + strings.ToUpper(name)))
+		if value == "off" || value == "0" {
+			continue
+		}
+		if requests, period, ok := strings.Cut(value, "/"); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(requests))
+			d, derr := time.ParseDuration(strings.TrimSpace(period))
+			if err == nil && derr == nil && n > 0 && d > 0 {
+				policy.Requests, policy.Period = n, d
+			}
+		}
+		policies[name] = policy
+	}
+	return policies
+}
+
+// getEnvPrefixList - Daftar IP atau CIDR; IP tunggal menjadi prefix /32 (/128 untuk IPv6)
+func getEnvPrefixList(key, defaultValue string) []netip.Prefix {
+	var prefixes []netip.Prefix
+	for _, value := range getEnvList(key, defaultValue) {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		} else {
+			slog.Warn("ignoring invalid trusted proxy", "env", key, "value", value)
+		}
+	}
+	return prefixes
+}
@@ -0,0 +1,30 @@
+package database
+
+import "gorm.io/gorm"
+
+// RegisterCallbacks - Pasang hook before/after di sekitar setiap operasi GORM (create, query,
+// update, delete, row, raw), dipakai plugin instrumentasi. Nama callback "<plugin>:before_<operasi>".
+func RegisterCallbacks(db *gorm.DB, plugin string, before, after func(operation string) func(*gorm.DB)) error {
+	callbacks := db.Callback()
+	operations := []struct {
+		name   string
+		before func(string, func(*gorm.DB)) error
+		after  func(string, func(*gorm.DB)) error
+	}{
+		{"create", callbacks.Create().Before("gorm:create").Register, callbacks.Create().After("gorm:create").Register},
+		{"query", callbacks.Query().Before("gorm:query").Register, callbacks.Query().After("gorm:query").Register},
+		{"update", callbacks.Update().Before("gorm:update").Register, callbacks.Update().After("gorm:update").Register},
+		{"delete", callbacks.Delete().Before("gorm:delete").Register, callbacks.Delete().After("gorm:delete").Register},
+		{"row", callbacks.Row().Before("gorm:row").Register, callbacks.Row().After("gorm:row").Register},
+		{"raw", callbacks.Raw().Before("gorm:raw").Register, callbacks.Raw().After("gorm:raw").Register},
+	}
+	for _, op := range operations {
+		if err := op.before(plugin+":before_"+op.name, before(op.name)); err != nil {
+			return err
+		}
+		if err := op.after(plugin+":after_"+op.name, after(op.name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
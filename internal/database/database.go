@@ -2,7 +2,7 @@ package database
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 
 	"blog-api/internal/config"
 
@@ -10,10 +10,9 @@ import (
 	"gorm.io/gorm"
 )
 
-var DB *gorm.DB
-
-func Connect(cfg *config.Config) error {
-	dsn := fmt.Sprintf(
+// DSN - Connection string Postgres dari konfigurasi (juga dipakai koneksi LISTEN realtime)
+func DSN(cfg *config.Config) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		cfg.DBHost,
 		cfg.DBUser,
@@ -21,17 +20,24 @@ func Connect(cfg *config.Config) error {
 		cfg.DBName,
 		cfg.DBPort,
 	)
+}
 
-	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+// Connect - Buka connection pool Postgres
+func Connect(cfg *config.Config) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(DSN(cfg)), &gorm.Config{})
 	if err != nil {
-		return fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	log.Println("Database connected successfully")
-	return nil
+	slog.Info("database connected")
+	return db, nil
 }
 
-func GetDB() *gorm.DB {
-	return DB
+// Close - Tutup connection pool saat server shutdown
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
@@ -0,0 +1,322 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// migrationLockKey - Key pg_advisory_lock agar hanya satu replica yang menjalankan migrasi ("blog" dalam ASCII)
+const migrationLockKey = 0x626c6f67
+
+var ErrNoDownMigration = errors.New("migration has no down file")
+
+// Migration - Satu versi skema dari file migrations/<dialect>/<version>_<name>.{up,down}.sql
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string // Kosong berarti migrasi tidak bisa di-rollback
+}
+
+// MigrationStatus - Versi migrasi dan kapan diterapkan (nil jika masih pending)
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Migrator - Menjalankan migrasi SQL berversi yang di-embed di binary
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+// NewMigrator - Migrator untuk dialect koneksi db (postgres atau sqlite)
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	dialect := db.Dialector.Name()
+	migrations, err := loadMigrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// loadMigrations - Baca dan urutkan file migrasi untuk satu dialect
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported migration dialect %q", dialect)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionPart, label, ok := strings.Cut(base, "_")
+		version, err := strconv.ParseInt(versionPart, 10, 64)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, label)
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Migrations - Semua migrasi yang di-embed, urut dari versi terlama
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Status - Setiap migrasi beserta waktu diterapkan. Hanya membaca (tanpa DDL), aman untuk
+// readiness probe dan role database read-only.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.readApplied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i].Migration = migration
+		if at, ok := applied[migration.Version]; ok {
+			statuses[i].AppliedAt = &at
+		}
+	}
+	return statuses, nil
+}
+
+// Pending - Jumlah migrasi yang belum diterapkan
+func (m *Migrator) Pending(ctx context.Context) (int, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pending := 0
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending++
+		}
+	}
+	return pending, nil
+}
+
+// Up - Terapkan semua migrasi yang belum diterapkan
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	return m.Goto(ctx, m.latest())
+}
+
+// Down - Rollback sejumlah steps migrasi terakhir yang sudah diterapkan
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if err := m.revert(ctx, db, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Goto - Naik atau turun sampai skema tepat di version (0 berarti rollback semua)
+func (m *Migrator) Goto(ctx context.Context, version int64) ([]Migration, error) {
+	if version != 0 && m.find(version) < 0 {
+		return nil, fmt.Errorf("unknown migration version %d", version)
+	}
+
+	var done []Migration
+	err := m.withLock(ctx, func(db *gorm.DB) error {
+		applied, err := m.applied(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		// Rollback dulu yang di atas target (dari yang terbaru), lalu terapkan sisanya
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok || migration.Version <= version {
+				continue
+			}
+			if err := m.revert(ctx, db, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > version {
+				continue
+			}
+			if err := m.apply(ctx, db, migration); err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+func (m *Migrator) latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+func (m *Migrator) find(version int64) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply - SQL migrasi dan pencatatan versinya dalam satu transaksi, gagal di tengah berarti tidak ada yang berubah
+func (m *Migrator) apply(ctx context.Context, db *gorm.DB, migration Migration) error {
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Up).Error; err != nil {
+			return err
+		}
+		return tx.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+			migration.Version, migration.Name, time.Now().UTC()).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s up: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+func (m *Migrator) revert(ctx context.Context, db *gorm.DB, migration Migration) error {
+	if migration.Down == "" {
+		return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, ErrNoDownMigration)
+	}
+
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(migration.Down).Error; err != nil {
+			return err
+		}
+		return tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migration.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d_%s down: %w", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// applied - Versi yang sudah tercatat di schema_migrations (tabel dibuat jika belum ada)
+func (m *Migrator) applied(ctx context.Context, db *gorm.DB) (map[int64]time.Time, error) {
+	err := db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`).Error
+	if err != nil {
+		return nil, err
+	}
+	return m.readApplied(ctx, db)
+}
+
+// readApplied - Seperti applied tanpa membuat tabel: schema_migrations yang belum ada
+// berarti belum ada migrasi yang diterapkan
+func (m *Migrator) readApplied(ctx context.Context, db *gorm.DB) (map[int64]time.Time, error) {
+	if !db.WithContext(ctx).Migrator().HasTable("schema_migrations") {
+		return map[int64]time.Time{}, nil
+	}
+
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+	if err := db.WithContext(ctx).Raw("SELECT version, applied_at FROM schema_migrations").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]time.Time, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+	return applied, nil
+}
+
+// withLock - Jalankan fn sambil memegang advisory lock Postgres pada satu koneksi,
+// replica lain yang start bersamaan menunggu lalu melihat migrasi sudah diterapkan.
+// SQLite hanya punya satu writer sehingga cukup mengandalkan transaksi per migrasi.
+func (m *Migrator) withLock(ctx context.Context, fn func(db *gorm.DB) error) error {
+	if m.dialect != "postgres" {
+		return fn(m.db)
+	}
+
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		// Lock melekat pada session, tetap dilepas walau ctx sudah dibatalkan
+		defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+
+		return fn(conn)
+	})
+}
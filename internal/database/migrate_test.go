@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"blog-api/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var allModels = []interface{}{
+	&models.User{}, &models.Post{}, &models.Comment{}, &models.Reaction{},
+	&models.Bookmark{}, &models.ReadingList{}, &models.ReadingListItem{},
+	&models.Follow{}, &models.FeedEntry{}, &models.Notification{},
+	&models.NotificationActor{}, &models.NotificationPreference{},
+	&models.Mention{}, &models.Tag{}, &models.Media{}, &models.MediaVariant{},
+	&models.Webhook{}, &models.WebhookEvent{}, &models.WebhookDelivery{},
+	&models.EditorSession{}, &models.PostEditLock{}, &models.RateLimitBucket{},
+}
+
+func setupMigrator(t *testing.T) (*gorm.DB, *Migrator) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	// Satu koneksi agar database :memory: tidak terpecah antar koneksi pool
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+	return db, migrator
+}
+
+// TestMigrationsMatchModels - Setiap kolom dan index yang dideklarasikan model ada setelah migrasi
+func TestMigrationsMatchModels(t *testing.T) {
+	db, migrator := setupMigrator(t)
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+
+	for _, model := range allModels {
+		s, err := schema.Parse(model, &sync.Map{}, db.NamingStrategy)
+		if err != nil {
+			t.Fatalf("Failed to parse %T: %v", model, err)
+		}
+		if !db.Migrator().HasTable(model) {
+			t.Errorf("Missing table %s", s.Table)
+			continue
+		}
+		for _, field := range s.Fields {
+			if field.DBName != "" && !db.Migrator().HasColumn(model, field.DBName) {
+				t.Errorf("Missing column %s.%s", s.Table, field.DBName)
+			}
+		}
+		for _, index := range s.ParseIndexes() {
+			if !db.Migrator().HasIndex(model, index.Name) {
+				t.Errorf("Missing index %s on %s", index.Name, s.Table)
+			}
+		}
+	}
+}
+
+// TestMigrationDialectsInSync - Postgres dan SQLite punya versi migrasi yang sama
+func TestMigrationDialectsInSync(t *testing.T) {
+	postgres, err := loadMigrations("postgres")
+	if err != nil {
+		t.Fatalf("Failed to load postgres migrations: %v", err)
+	}
+	sqliteMigrations, err := loadMigrations("sqlite")
+	if err != nil {
+		t.Fatalf("Failed to load sqlite migrations: %v", err)
+	}
+
+	if len(postgres) != len(sqliteMigrations) {
+		t.Fatalf("Expected same number of migrations, got postgres=%d sqlite=%d", len(postgres), len(sqliteMigrations))
+	}
+	for i := range postgres {
+		if postgres[i].Version != sqliteMigrations[i].Version || postgres[i].Name != sqliteMigrations[i].Name {
+			t.Errorf("Migration mismatch: postgres %d_%s, sqlite %d_%s",
+				postgres[i].Version, postgres[i].Name, sqliteMigrations[i].Version, sqliteMigrations[i].Name)
+		}
+		if (postgres[i].Down == "") != (sqliteMigrations[i].Down == "") {
+			t.Errorf("Migration %d has a down file in only one dialect", postgres[i].Version)
+		}
+	}
+}
+
+func TestMigratorUpDownGoto(t *testing.T) {
+	ctx := context.Background()
+	db, migrator := setupMigrator(t)
+	latest := migrator.latest()
+
+	if pending, err := migrator.Pending(ctx); err != nil || pending != len(migrator.Migrations()) {
+		t.Fatalf("Expected all migrations pending, got %d %v", pending, err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) != len(migrator.Migrations()) {
+		t.Fatalf("Expected all migrations applied, got %d %v", len(applied), err)
+	}
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != 0 {
+		t.Fatalf("Expected second Up to be a no-op, got %d %v", len(applied), err)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("Expected migration %d to be applied", status.Version)
+		}
+	}
+
+	reverted, err := migrator.Down(ctx, 1)
+	if err != nil || len(reverted) != 1 || reverted[0].Version != latest {
+		t.Fatalf("Expected latest migration reverted, got %+v %v", reverted, err)
+	}
+	if pending, _ := migrator.Pending(ctx); pending != 1 {
+		t.Errorf("Expected 1 pending migration after Down, got %d", pending)
+	}
+
+	if _, err := migrator.Goto(ctx, 0); err != nil {
+		t.Fatalf("Goto 0 failed: %v", err)
+	}
+	if db.Migrator().HasTable(&models.User{}) {
+		t.Error("Expected users table to be dropped after Goto 0")
+	}
+
+	if _, err := migrator.Goto(ctx, latest); err != nil {
+		t.Fatalf("Goto latest failed: %v", err)
+	}
+	if !db.Migrator().HasTable(&models.User{}) {
+		t.Error("Expected users table after Goto latest")
+	}
+
+	if _, err := migrator.Goto(ctx, latest+1000); err == nil {
+		t.Error("Expected error for unknown version")
+	}
+}
@@ -0,0 +1,7 @@
+-- Token bucket rate limiter bersama untuk deployment multi-replica
+CREATE TABLE rate_limit_buckets (
+    key varchar(255) PRIMARY KEY,
+    tokens double precision NOT NULL,
+    refilled_at bigint NOT NULL
+);
+CREATE INDEX idx_rate_limit_buckets_refilled_at ON rate_limit_buckets (refilled_at);
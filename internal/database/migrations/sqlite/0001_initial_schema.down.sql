@@ -0,0 +1,22 @@
+DROP TABLE IF EXISTS post_edit_locks;
+DROP TABLE IF EXISTS editor_sessions;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_events;
+DROP TABLE IF EXISTS webhooks;
+DROP TABLE IF EXISTS mentions;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notification_actors;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS feed_entries;
+DROP TABLE IF EXISTS follows;
+DROP TABLE IF EXISTS reading_list_items;
+DROP TABLE IF EXISTS reading_lists;
+DROP TABLE IF EXISTS bookmarks;
+DROP TABLE IF EXISTS reactions;
+DROP TABLE IF EXISTS comments;
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS posts;
+DROP TABLE IF EXISTS media_variants;
+DROP TABLE IF EXISTS media;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,275 @@
+-- Skema awal, sama dengan hasil AutoMigrate terakhir. Memakai IF NOT EXISTS agar
+-- database yang dulu dibuat oleh AutoMigrate bisa di-adopsi tanpa perubahan.
+
+CREATE TABLE IF NOT EXISTS users (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    email text NOT NULL,
+    password text NOT NULL,
+    name text NOT NULL,
+    username text,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users (username);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+CREATE TABLE IF NOT EXISTS media (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    key text NOT NULL,
+    filename text,
+    content_type text NOT NULL,
+    size integer NOT NULL,
+    width integer,
+    height integer,
+    variant_status text NOT NULL DEFAULT 'pending',
+    created_at datetime,
+    updated_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_key ON media (key);
+CREATE INDEX IF NOT EXISTS idx_media_user_id ON media (user_id);
+CREATE INDEX IF NOT EXISTS idx_media_variant_status ON media (variant_status);
+
+CREATE TABLE IF NOT EXISTS media_variants (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    media_id integer NOT NULL,
+    width integer NOT NULL,
+    height integer NOT NULL,
+    format text NOT NULL,
+    key text NOT NULL,
+    size integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_media_variants FOREIGN KEY (media_id) REFERENCES media (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_media_variants_unique ON media_variants (media_id, width, format);
+
+CREATE TABLE IF NOT EXISTS posts (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    title text NOT NULL,
+    content text NOT NULL,
+    user_id integer NOT NULL,
+    featured_image_id integer,
+    comment_count integer NOT NULL DEFAULT 0,
+    score integer NOT NULL DEFAULT 0,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_users_posts FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_posts_featured_image FOREIGN KEY (featured_image_id) REFERENCES media (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_posts_user_id ON posts (user_id);
+CREATE INDEX IF NOT EXISTS idx_posts_user_feed ON posts (user_id, id);
+CREATE INDEX IF NOT EXISTS idx_posts_featured_image_id ON posts (featured_image_id);
+CREATE INDEX IF NOT EXISTS idx_posts_score ON posts (score);
+CREATE INDEX IF NOT EXISTS idx_posts_deleted_at ON posts (deleted_at);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    name text NOT NULL,
+    created_at datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id integer,
+    tag_id integer,
+    PRIMARY KEY (post_id, tag_id),
+    CONSTRAINT fk_post_tags_post FOREIGN KEY (post_id) REFERENCES posts (id),
+    CONSTRAINT fk_post_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+);
+
+CREATE TABLE IF NOT EXISTS comments (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    content text NOT NULL,
+    user_id integer NOT NULL,
+    post_id integer NOT NULL,
+    score integer NOT NULL DEFAULT 0,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_users_comments FOREIGN KEY (user_id) REFERENCES users (id),
+    CONSTRAINT fk_posts_comments FOREIGN KEY (post_id) REFERENCES posts (id)
+);
+CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments (user_id);
+CREATE INDEX IF NOT EXISTS idx_comments_post_id ON comments (post_id);
+CREATE INDEX IF NOT EXISTS idx_comments_post_score ON comments (post_id, score);
+CREATE INDEX IF NOT EXISTS idx_comments_deleted_at ON comments (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reactions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    target_type text NOT NULL,
+    target_id integer NOT NULL,
+    kind text NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_reactions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reactions_unique ON reactions (user_id, target_type, target_id, kind);
+CREATE INDEX IF NOT EXISTS idx_reactions_target ON reactions (target_type, target_id);
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    post_id integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_bookmarks_post FOREIGN KEY (post_id) REFERENCES posts (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmarks_user_post ON bookmarks (user_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_bookmarks_post_id ON bookmarks (post_id);
+
+CREATE TABLE IF NOT EXISTS reading_lists (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    name text NOT NULL,
+    description text,
+    is_public numeric NOT NULL DEFAULT false,
+    share_token text NOT NULL,
+    created_at datetime,
+    updated_at datetime,
+    deleted_at datetime,
+    CONSTRAINT fk_reading_lists_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_reading_lists_user_id ON reading_lists (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reading_lists_share_token ON reading_lists (share_token);
+CREATE INDEX IF NOT EXISTS idx_reading_lists_deleted_at ON reading_lists (deleted_at);
+
+CREATE TABLE IF NOT EXISTS reading_list_items (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    reading_list_id integer NOT NULL,
+    post_id integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_reading_lists_items FOREIGN KEY (reading_list_id) REFERENCES reading_lists (id),
+    CONSTRAINT fk_reading_list_items_post FOREIGN KEY (post_id) REFERENCES posts (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reading_list_items_list_post ON reading_list_items (reading_list_id, post_id);
+CREATE INDEX IF NOT EXISTS idx_reading_list_items_post_id ON reading_list_items (post_id);
+
+CREATE TABLE IF NOT EXISTS follows (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    follower_id integer NOT NULL,
+    followee_id integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_follows_follower FOREIGN KEY (follower_id) REFERENCES users (id),
+    CONSTRAINT fk_follows_followee FOREIGN KEY (followee_id) REFERENCES users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_follows_pair ON follows (follower_id, followee_id);
+CREATE INDEX IF NOT EXISTS idx_follows_followee_id ON follows (followee_id);
+
+CREATE TABLE IF NOT EXISTS feed_entries (
+    user_id integer,
+    post_id integer,
+    author_id integer NOT NULL,
+    created_at datetime,
+    PRIMARY KEY (user_id, post_id)
+);
+CREATE INDEX IF NOT EXISTS idx_feed_entries_author_id ON feed_entries (author_id);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    type text NOT NULL,
+    actor_id integer NOT NULL,
+    actor_count integer NOT NULL DEFAULT 1,
+    post_id integer,
+    comment_id integer,
+    message text NOT NULL,
+    read_at datetime,
+    latest_at datetime NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_notifications_actor FOREIGN KEY (actor_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_latest ON notifications (user_id, latest_at);
+CREATE INDEX IF NOT EXISTS idx_notifications_read_at ON notifications (read_at);
+
+CREATE TABLE IF NOT EXISTS notification_actors (
+    notification_id integer,
+    user_id integer,
+    PRIMARY KEY (notification_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id integer PRIMARY KEY,
+    comment numeric NOT NULL,
+    reaction numeric NOT NULL,
+    mention numeric NOT NULL DEFAULT true,
+    updated_at datetime
+);
+
+CREATE TABLE IF NOT EXISTS mentions (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    target_type text NOT NULL,
+    target_id integer NOT NULL,
+    post_id integer NOT NULL,
+    author_id integer NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_mentions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mentions_unique ON mentions (target_type, target_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_user_id ON mentions (user_id);
+CREATE INDEX IF NOT EXISTS idx_mentions_post_id ON mentions (post_id);
+
+CREATE TABLE IF NOT EXISTS webhooks (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    url text NOT NULL,
+    secret text NOT NULL,
+    events text NOT NULL,
+    active numeric NOT NULL,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_user_id ON webhooks (user_id);
+
+CREATE TABLE IF NOT EXISTS webhook_events (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    user_id integer NOT NULL,
+    type text NOT NULL,
+    payload text NOT NULL,
+    dispatched_at datetime,
+    created_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_user_id ON webhook_events (user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_events_dispatched_at ON webhook_events (dispatched_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id integer PRIMARY KEY AUTOINCREMENT,
+    webhook_id integer NOT NULL,
+    event_id integer NOT NULL,
+    event_type text NOT NULL,
+    status text NOT NULL,
+    attempts integer NOT NULL DEFAULT 0,
+    next_attempt_at datetime NOT NULL,
+    last_status_code integer,
+    last_error text,
+    last_response text,
+    duration_ms integer,
+    delivered_at datetime,
+    created_at datetime,
+    updated_at datetime
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries (event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS editor_sessions (
+    id text PRIMARY KEY,
+    post_id integer NOT NULL,
+    user_id integer NOT NULL,
+    state text NOT NULL,
+    last_seen_at datetime NOT NULL,
+    created_at datetime,
+    CONSTRAINT fk_editor_sessions_user FOREIGN KEY (user_id) REFERENCES users (id)
+);
+CREATE INDEX IF NOT EXISTS idx_editor_sessions_post_id ON editor_sessions (post_id);
+CREATE INDEX IF NOT EXISTS idx_editor_sessions_last_seen_at ON editor_sessions (last_seen_at);
+
+CREATE TABLE IF NOT EXISTS post_edit_locks (
+    post_id integer PRIMARY KEY,
+    session_id text NOT NULL,
+    user_id integer NOT NULL,
+    acquired_at datetime NOT NULL,
+    expires_at datetime NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Role user untuk tugas admin (blog-api user promote)
+ALTER TABLE users ADD COLUMN role text NOT NULL DEFAULT 'user';
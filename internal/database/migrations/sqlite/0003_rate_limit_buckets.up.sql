@@ -0,0 +1,7 @@
+-- Token bucket rate limiter bersama untuk deployment multi-replica
+CREATE TABLE rate_limit_buckets (
+    key text PRIMARY KEY,
+    tokens real NOT NULL,
+    refilled_at integer NOT NULL
+);
+CREATE INDEX idx_rate_limit_buckets_refilled_at ON rate_limit_buckets (refilled_at);
@@ -0,0 +1,22 @@
+package grpcserver
+
+import (
+	"context"
+
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+)
+
+type authServer struct {
+	blogv1.UnimplementedAuthServiceServer
+	tokens *service.TokenService
+}
+
+// ValidateToken - Dipakai service internal untuk memeriksa token user tanpa mengetahui JWT secret
+func (s *authServer) ValidateToken(_ context.Context, req *blogv1.ValidateTokenRequest) (*blogv1.ValidateTokenResponse, error) {
+	userID, err := s.tokens.Validate(req.GetToken())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.ValidateTokenResponse{UserId: uint64(userID)}, nil
+}
@@ -0,0 +1,169 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/models"
+	"blog-api/internal/realtime"
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// watchEventReset - Sama dengan event reset SSE: last_event_id sudah tidak ada di buffer
+const watchEventReset = "reset"
+
+type commentServer struct {
+	blogv1.UnimplementedCommentServiceServer
+	cfg       *config.Config
+	comments  *service.CommentService
+	posts     *service.PostService
+	reactions *service.ReactionService
+	hub       *realtime.Hub
+}
+
+func (s *commentServer) ListComments(ctx context.Context, req *blogv1.ListCommentsRequest) (*blogv1.ListCommentsResponse, error) {
+	postID, err := parseID(req.GetPostId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	comments, nextCursor, err := s.comments.List(ctx, postID, toPageOptions(req.GetPage()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := s.reactions.AttachToComments(ctx, viewerID(ctx), comments); err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &blogv1.ListCommentsResponse{NextCursor: nextCursor}
+	for _, comment := range comments {
+		res.Comments = append(res.Comments, toComment(comment))
+	}
+	return res, nil
+}
+
+func (s *commentServer) CreateComment(ctx context.Context, req *blogv1.CreateCommentRequest) (*blogv1.CreateCommentResponse, error) {
+	userID, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postID, err := parseID(req.GetPostId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	comment, _, err := s.comments.Create(ctx, origin(ctx, s.cfg), userID, postID, req.GetContent())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.CreateCommentResponse{Comment: toComment(comment)}, nil
+}
+
+func (s *commentServer) DeleteComment(ctx context.Context, req *blogv1.DeleteCommentRequest) (*blogv1.DeleteCommentResponse, error) {
+	userID, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postID, err := parseID(req.GetPostId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+	commentID, err := parseID(req.GetId(), "Invalid comment ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.comments.Delete(ctx, origin(ctx, s.cfg), userID, postID, commentID); err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.DeleteCommentResponse{}, nil
+}
+
+// WatchComments - Event dari hub realtime yang sama dengan SSE, termasuk batas stream per client
+func (s *commentServer) WatchComments(req *blogv1.WatchCommentsRequest, stream grpc.ServerStreamingServer[blogv1.WatchCommentsResponse]) error {
+	ctx := stream.Context()
+	postID, err := parseID(req.GetPostId(), "Invalid post ID")
+	if err != nil {
+		return err
+	}
+
+	// Cek apakah post exists
+	if _, err := s.posts.Get(ctx, postID); err != nil {
+		return statusError(err)
+	}
+
+	if s.hub == nil {
+		return status.Error(codes.Unavailable, "Realtime is not available")
+	}
+
+	release, ok := s.hub.AcquireClient(peerHost(ctx), s.cfg.SSEMaxStreamsPerClient)
+	if !ok {
+		return status.Error(codes.ResourceExhausted, "Too many open streams")
+	}
+	defer release()
+
+	sub, backlog, resumed := s.hub.Subscribe(service.CommentTopic(postID), req.GetLastEventId())
+	defer sub.Close()
+
+	if !resumed {
+		if err := stream.Send(&blogv1.WatchCommentsResponse{Event: watchEventReset}); err != nil {
+			return err
+		}
+	}
+	for _, msg := range backlog {
+		if err := sendCommentEvent(stream, msg); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-sub.C:
+			// Channel ditutup jika client terlalu lambat mengikuti event, client reconnect dengan last_event_id
+			if !ok {
+				return status.Error(codes.Aborted, "Stream is too slow, reconnect with last_event_id")
+			}
+			if err := sendCommentEvent(stream, msg); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// sendCommentEvent - Data event berupa JSON comment (comment.created) atau id dan post_id (comment.deleted)
+func sendCommentEvent(stream grpc.ServerStreamingServer[blogv1.WatchCommentsResponse], msg realtime.Message) error {
+	var comment models.Comment
+	if err := json.Unmarshal(msg.Data, &comment); err != nil {
+		logging.FromContext(stream.Context()).Warn("invalid realtime event", "event", msg.Event, "id", msg.ID, "error", err)
+		return nil
+	}
+	return stream.Send(&blogv1.WatchCommentsResponse{
+		Id:      msg.ID,
+		Event:   msg.Event,
+		Comment: toComment(comment),
+	})
+}
+
+// peerHost - IP client gRPC, dipakai untuk batas stream per client
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,90 @@
+package grpcserver
+
+import (
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toUser - Author tanpa email; nil jika relasi tidak di-preload
+func toUser(user models.User) *blogv1.User {
+	if user.ID == 0 {
+		return nil
+	}
+	return &blogv1.User{
+		Id:       uint64(user.ID),
+		Name:     user.Name,
+		Username: user.Username,
+	}
+}
+
+func toPost(post models.Post) *blogv1.Post {
+	p := &blogv1.Post{
+		Id:              uint64(post.ID),
+		Title:           post.Title,
+		Content:         post.Content,
+		ContentHtml:     post.ContentHTML,
+		UserId:          uint64(post.UserID),
+		Author:          toUser(post.User),
+		Tags:            []string{},
+		CommentCount:    post.CommentCount,
+		Score:           post.Score,
+		CreatedAt:       timestamppb.New(post.CreatedAt),
+		UpdatedAt:       timestamppb.New(post.UpdatedAt),
+		Reactions:       post.Reactions,
+		ViewerReactions: post.ViewerReactions,
+	}
+	for _, tag := range post.Tags {
+		p.Tags = append(p.Tags, tag.Name)
+	}
+	if post.FeaturedImageID != nil {
+		featuredImageID := uint64(*post.FeaturedImageID)
+		p.FeaturedImageId = &featuredImageID
+	}
+	return p
+}
+
+func toComment(comment models.Comment) *blogv1.Comment {
+	c := &blogv1.Comment{
+		Id:              uint64(comment.ID),
+		PostId:          uint64(comment.PostID),
+		Content:         comment.Content,
+		ContentHtml:     comment.ContentHTML,
+		UserId:          uint64(comment.UserID),
+		Author:          toUser(comment.User),
+		Score:           comment.Score,
+		Reactions:       comment.Reactions,
+		ViewerReactions: comment.ViewerReactions,
+	}
+	if !comment.CreatedAt.IsZero() {
+		c.CreatedAt = timestamppb.New(comment.CreatedAt)
+		c.UpdatedAt = timestamppb.New(comment.UpdatedAt)
+	}
+	return c
+}
+
+// toPostInput - featured_image_id dan tags yang tidak diisi berarti tidak diubah saat update
+func toPostInput(input *blogv1.PostInput) service.PostInput {
+	in := service.PostInput{
+		Title:   input.GetTitle(),
+		Content: input.GetContent(),
+	}
+	if input.GetTags() != nil {
+		in.Tags = append([]string{}, input.GetTags().GetTags()...)
+	}
+	if input != nil && input.FeaturedImageId != nil {
+		featuredImageID := uint(input.GetFeaturedImageId())
+		in.FeaturedImageID = &featuredImageID
+	}
+	return in
+}
+
+func toPageOptions(page *blogv1.PageRequest) service.PageOptions {
+	return service.PageOptions{
+		Limit:  int(page.GetLimit()),
+		Cursor: page.GetCursor(),
+		Sort:   page.GetSort(),
+	}
+}
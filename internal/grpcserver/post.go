@@ -0,0 +1,109 @@
+package grpcserver
+
+import (
+	"context"
+	"math"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type postServer struct {
+	blogv1.UnimplementedPostServiceServer
+	cfg       *config.Config
+	posts     *service.PostService
+	reactions *service.ReactionService
+}
+
+// parseID - ID dari request, batasnya sama dengan parameter path REST (32 bit, bukan 0)
+func parseID(id uint64, errMsg string) (uint, error) {
+	if id == 0 || id > math.MaxUint32 {
+		return 0, status.Error(codes.InvalidArgument, errMsg)
+	}
+	return uint(id), nil
+}
+
+func (s *postServer) GetPost(ctx context.Context, req *blogv1.GetPostRequest) (*blogv1.GetPostResponse, error) {
+	postID, err := parseID(req.GetId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.posts.Get(ctx, postID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	posts := []models.Post{post}
+	if err := s.reactions.AttachToPosts(ctx, viewerID(ctx), posts); err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.GetPostResponse{Post: toPost(posts[0])}, nil
+}
+
+func (s *postServer) ListPosts(ctx context.Context, req *blogv1.ListPostsRequest) (*blogv1.ListPostsResponse, error) {
+	posts, nextCursor, err := s.posts.List(ctx, toPageOptions(req.GetPage()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	if err := s.reactions.AttachToPosts(ctx, viewerID(ctx), posts); err != nil {
+		return nil, statusError(err)
+	}
+
+	res := &blogv1.ListPostsResponse{NextCursor: nextCursor}
+	for _, post := range posts {
+		res.Posts = append(res.Posts, toPost(post))
+	}
+	return res, nil
+}
+
+func (s *postServer) CreatePost(ctx context.Context, req *blogv1.CreatePostRequest) (*blogv1.CreatePostResponse, error) {
+	userID, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.posts.Create(ctx, origin(ctx, s.cfg), userID, toPostInput(req.GetInput()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.CreatePostResponse{Post: toPost(post)}, nil
+}
+
+func (s *postServer) UpdatePost(ctx context.Context, req *blogv1.UpdatePostRequest) (*blogv1.UpdatePostResponse, error) {
+	userID, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postID, err := parseID(req.GetId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.posts.Update(ctx, origin(ctx, s.cfg), userID, postID, toPostInput(req.GetInput()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.UpdatePostResponse{Post: toPost(post)}, nil
+}
+
+func (s *postServer) DeletePost(ctx context.Context, req *blogv1.DeletePostRequest) (*blogv1.DeletePostResponse, error) {
+	userID, err := userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	postID, err := parseID(req.GetId(), "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.posts.Delete(ctx, origin(ctx, s.cfg), userID, postID); err != nil {
+		return nil, statusError(err)
+	}
+	return &blogv1.DeletePostResponse{}, nil
+}
@@ -0,0 +1,37 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// unaryRecover - Ubah panic di handler menjadi codes.Internal. Berbeda dengan net/http, grpc-go
+// tidak me-recover panic handler sehingga satu nil dereference menghentikan seluruh proses.
+func unaryRecover(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(info.FullMethod, p)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func streamRecover(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = recovered(info.FullMethod, p)
+		}
+	}()
+	return handler(srv, stream)
+}
+
+// recovered - Catat panic beserta stack trace, detail tidak dikirim ke client
+func recovered(method string, p interface{}) error {
+	slog.Error("panic in gRPC handler", "method", method, "panic", p, "stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "Internal server error")
+}
@@ -0,0 +1,128 @@
+// Package grpcserver berisi implementasi API gRPC (proto/blog/v1) di atas package service,
+// sehingga validasi, ownership dan efek samping sama persis dengan REST dan GraphQL.
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	"blog-api/internal/config"
+	"blog-api/internal/realtime"
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey struct{}
+
+// statusCodes - Kode gRPC untuk kategori error service
+var statusCodes = map[service.Code]codes.Code{
+	service.CodeInvalid:         codes.InvalidArgument,
+	service.CodeUnauthenticated: codes.Unauthenticated,
+	service.CodeForbidden:       codes.PermissionDenied,
+	service.CodeNotFound:        codes.NotFound,
+	service.CodeConflict:        codes.AlreadyExists,
+	service.CodeInternal:        codes.Internal,
+	service.CodeRateLimited:     codes.ResourceExhausted,
+}
+
+// New - Server gRPC dengan semua service blog dan reflection (untuk grpcurl).
+// Interceptor recovery dipasang paling luar agar panic di auth juga tertangkap.
+func New(cfg *config.Config, tokens *service.TokenService, posts *service.PostService, comments *service.CommentService, reactions *service.ReactionService, hub *realtime.Hub) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryRecover, unaryAuth(tokens)),
+		grpc.ChainStreamInterceptor(streamRecover, streamAuth(tokens)),
+	)
+	blogv1.RegisterPostServiceServer(server, &postServer{cfg: cfg, posts: posts, reactions: reactions})
+	blogv1.RegisterCommentServiceServer(server, &commentServer{cfg: cfg, comments: comments, posts: posts, reactions: reactions, hub: hub})
+	blogv1.RegisterAuthServiceServer(server, &authServer{tokens: tokens})
+	reflection.Register(server)
+	return server
+}
+
+// authenticate - Sama dengan middleware Auth.Optional: tanpa metadata authorization request
+// diteruskan sebagai anonymous, token yang tidak valid ditolak dengan Unauthenticated
+func authenticate(ctx context.Context, tokens *service.TokenService) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return ctx, nil
+	}
+
+	// Format: "Bearer <token>"
+	parts := strings.Split(values[0], " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return nil, status.Error(codes.Unauthenticated, "Invalid authorization format")
+	}
+
+	userID, err := tokens.Validate(parts[1])
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return context.WithValue(ctx, contextKey{}, userID), nil
+}
+
+func unaryAuth(tokens *service.TokenService) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, tokens)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuth(tokens *service.TokenService) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), tokens)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream - ServerStream dengan context yang sudah berisi user_id
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// userID - User yang login, error Unauthenticated jika request tanpa token
+func userID(ctx context.Context) (uint, error) {
+	id, ok := ctx.Value(contextKey{}).(uint)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "Unauthorized")
+	}
+	return id, nil
+}
+
+// viewerID - User yang login untuk personalisasi (viewer_reactions), 0 untuk request tanpa token
+func viewerID(ctx context.Context) uint {
+	id, _ := ctx.Value(contextKey{}).(uint)
+	return id
+}
+
+// origin - Base URL publik dari config dan session editor dari metadata x-editor-session
+func origin(ctx context.Context, cfg *config.Config) service.Origin {
+	o := service.Origin{BaseURL: cfg.PublicBaseURL}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-editor-session"); len(values) > 0 {
+		o.EditorSession = values[0]
+	}
+	return o
+}
+
+// statusError - Ubah error service menjadi status gRPC dengan pesan yang sama seperti REST
+func statusError(err error) error {
+	return status.Error(statusCodes[service.ErrorCode(err)], err.Error())
+}
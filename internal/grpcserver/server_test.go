@@ -0,0 +1,242 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/realtime"
+	"blog-api/internal/repository"
+	"blog-api/internal/service"
+	blogv1 "blog-api/proto/blog/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// testTokens - JWT untuk user test, di-inject ke server
+var testTokens = service.NewTokenService("test-secret")
+
+// setupTestServer - Store in-memory, hub realtime dan server gRPC lewat bufconn
+func setupTestServer(t *testing.T) (*grpc.ClientConn, *repository.MemoryStore) {
+	store := repository.NewMemoryStore()
+
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+
+	listener := bufconn.Listen(1 << 20)
+	cfg := &config.Config{SSEMaxStreamsPerClient: 5, ReactionKinds: []string{"like", "love"}}
+	server := New(cfg, testTokens, service.NewPostService(cfg, store, hub, nil), service.NewCommentService(cfg, store, nil, hub, nil),
+		service.NewReactionService(cfg, store), hub)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, store
+}
+
+func createTestUser(t *testing.T, store repository.Store, username string) (models.User, context.Context) {
+	user := models.User{Email: username + "@example.com", Password: "x", Name: username, Username: username}
+	if err := store.Users().Create(context.Background(), &user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	token, err := testTokens.Generate(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return user, metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func assertCode(t *testing.T, err error, code codes.Code, message string) {
+	t.Helper()
+	st, _ := status.FromError(err)
+	if st.Code() != code || (message != "" && st.Message() != message) {
+		t.Fatalf("Expected %s %q, got %v", code, message, err)
+	}
+}
+
+func TestPostServiceSharesRESTRules(t *testing.T) {
+	conn, store := setupTestServer(t)
+	posts := blogv1.NewPostServiceClient(conn)
+	auth := blogv1.NewAuthServiceClient(conn)
+
+	owner, ownerCtx := createTestUser(t, store, "owner")
+	_, otherCtx := createTestUser(t, store, "other")
+
+	input := &blogv1.PostInput{
+		Title:   "gRPC post",
+		Content: "Content from gRPC",
+		Tags:    &blogv1.TagList{Tags: []string{"Go"}},
+	}
+
+	_, err := posts.CreatePost(context.Background(), &blogv1.CreatePostRequest{Input: input})
+	assertCode(t, err, codes.Unauthenticated, "Unauthorized")
+
+	badCtx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer not-a-jwt")
+	_, err = posts.ListPosts(badCtx, &blogv1.ListPostsRequest{})
+	assertCode(t, err, codes.Unauthenticated, "Invalid or expired token")
+
+	_, err = posts.CreatePost(ownerCtx, &blogv1.CreatePostRequest{Input: &blogv1.PostInput{Title: "Go", Content: input.Content}})
+	assertCode(t, err, codes.InvalidArgument, "Title must be between 3 and 200 characters")
+
+	created, err := posts.CreatePost(ownerCtx, &blogv1.CreatePostRequest{Input: input})
+	if err != nil {
+		t.Fatalf("Expected post to be created, got %v", err)
+	}
+	post := created.GetPost()
+	if post.GetAuthor().GetUsername() != "owner" || len(post.GetTags()) != 1 || post.GetTags()[0] != "go" {
+		t.Fatalf("Expected author and normalized tag, got %+v", post)
+	}
+
+	// Update tanpa tags tidak mengubah tag yang sudah ada
+	updated, err := posts.UpdatePost(ownerCtx, &blogv1.UpdatePostRequest{
+		Id:    post.GetId(),
+		Input: &blogv1.PostInput{Title: "gRPC post updated", Content: input.Content},
+	})
+	if err != nil || len(updated.GetPost().GetTags()) != 1 {
+		t.Fatalf("Expected tags to be kept, got %+v %v", updated.GetPost(), err)
+	}
+
+	list, err := posts.ListPosts(context.Background(), &blogv1.ListPostsRequest{Page: &blogv1.PageRequest{Limit: 10}})
+	if err != nil || len(list.GetPosts()) != 1 || list.GetNextCursor() != "" {
+		t.Fatalf("Expected one post on one page, got %+v %v", list, err)
+	}
+
+	_, err = posts.DeletePost(otherCtx, &blogv1.DeletePostRequest{Id: post.GetId()})
+	assertCode(t, err, codes.PermissionDenied, "You can only delete your own posts")
+
+	if _, err := posts.DeletePost(ownerCtx, &blogv1.DeletePostRequest{Id: post.GetId()}); err != nil {
+		t.Fatalf("Expected owner to delete post, got %v", err)
+	}
+	_, err = posts.GetPost(context.Background(), &blogv1.GetPostRequest{Id: post.GetId()})
+	assertCode(t, err, codes.NotFound, "Post not found")
+
+	token, _ := testTokens.Generate(context.Background(), owner.ID)
+	validated, err := auth.ValidateToken(context.Background(), &blogv1.ValidateTokenRequest{Token: token})
+	if err != nil || validated.GetUserId() != uint64(owner.ID) {
+		t.Fatalf("Expected user_id %d, got %+v %v", owner.ID, validated, err)
+	}
+}
+
+func TestWatchComments(t *testing.T) {
+	conn, store := setupTestServer(t)
+	comments := blogv1.NewCommentServiceClient(conn)
+
+	author, authorCtx := createTestUser(t, store, "author")
+	post := models.Post{Title: "Watched post", Content: "Content for streaming", UserID: author.ID}
+	store.Posts().Create(context.Background(), &post)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// last_event_id yang tidak dikenal dibalas event reset, sekaligus tanda subscription sudah aktif
+	stream, err := comments.WatchComments(ctx, &blogv1.WatchCommentsRequest{PostId: uint64(post.ID), LastEventId: "unknown"})
+	if err != nil {
+		t.Fatalf("Failed to watch comments: %v", err)
+	}
+	if event, err := stream.Recv(); err != nil || event.GetEvent() != "reset" {
+		t.Fatalf("Expected reset event, got %+v %v", event, err)
+	}
+
+	created, err := comments.CreateComment(authorCtx, &blogv1.CreateCommentRequest{PostId: uint64(post.ID), Content: "Live comment"})
+	if err != nil {
+		t.Fatalf("Failed to create comment: %v", err)
+	}
+	event, err := stream.Recv()
+	if err != nil || event.GetEvent() != service.CommentEventCreated || event.GetId() == "" ||
+		event.GetComment().GetContent() != "Live comment" || event.GetComment().GetAuthor().GetUsername() != "author" {
+		t.Fatalf("Expected comment.created, got %+v %v", event, err)
+	}
+
+	commentID := created.GetComment().GetId()
+	if _, err := comments.DeleteComment(authorCtx, &blogv1.DeleteCommentRequest{PostId: uint64(post.ID), Id: commentID}); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+	event, err = stream.Recv()
+	if err != nil || event.GetEvent() != service.CommentEventDeleted || event.GetComment().GetId() != commentID {
+		t.Fatalf("Expected comment.deleted, got %+v %v", event, err)
+	}
+
+	list, err := comments.ListComments(context.Background(), &blogv1.ListCommentsRequest{PostId: uint64(post.ID)})
+	if err != nil || len(list.GetComments()) != 0 {
+		t.Fatalf("Expected deleted comment to be hidden, got %+v %v", list, err)
+	}
+}
+
+func TestReactionsOnPostsAndComments(t *testing.T) {
+	conn, store := setupTestServer(t)
+	posts := blogv1.NewPostServiceClient(conn)
+	comments := blogv1.NewCommentServiceClient(conn)
+
+	author, _ := createTestUser(t, store, "author")
+	reader, readerCtx := createTestUser(t, store, "reader")
+	post := models.Post{Title: "Reacted post", Content: "Content with reactions", UserID: author.ID}
+	store.Posts().Create(context.Background(), &post)
+	comment := models.Comment{Content: "Reacted comment", UserID: author.ID, PostID: post.ID}
+	store.Comments().Create(context.Background(), &comment)
+
+	reactions := service.NewReactionService(&config.Config{ReactionKinds: []string{"like", "love"}}, store)
+	if _, err := reactions.SetPostReaction(context.Background(), reader.ID, post.ID, "like", true); err != nil {
+		t.Fatalf("Failed to react to post: %v", err)
+	}
+	if _, err := reactions.SetCommentReaction(context.Background(), author.ID, post.ID, comment.ID, "love", true); err != nil {
+		t.Fatalf("Failed to react to comment: %v", err)
+	}
+
+	got, err := posts.GetPost(readerCtx, &blogv1.GetPostRequest{Id: uint64(post.ID)})
+	if err != nil || got.GetPost().GetReactions()["like"] != 1 || len(got.GetPost().GetViewerReactions()) != 1 {
+		t.Fatalf("Expected post reactions with viewer state, got %+v %v", got.GetPost(), err)
+	}
+
+	// Tanpa token jumlah tetap ada, viewer_reactions kosong
+	list, err := posts.ListPosts(context.Background(), &blogv1.ListPostsRequest{})
+	if err != nil || list.GetPosts()[0].GetReactions()["like"] != 1 || len(list.GetPosts()[0].GetViewerReactions()) != 0 {
+		t.Fatalf("Expected anonymous post reactions, got %+v %v", list, err)
+	}
+
+	listed, err := comments.ListComments(readerCtx, &blogv1.ListCommentsRequest{PostId: uint64(post.ID)})
+	if err != nil || listed.GetComments()[0].GetReactions()["love"] != 1 || len(listed.GetComments()[0].GetViewerReactions()) != 0 {
+		t.Fatalf("Expected comment reactions without viewer state, got %+v %v", listed, err)
+	}
+}
+
+func TestRecoverPanics(t *testing.T) {
+	// Service nil membuat setiap handler panic (nil dereference)
+	listener := bufconn.Listen(1 << 20)
+	server := New(nil, nil, nil, nil, nil, nil)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial gRPC server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	posts := blogv1.NewPostServiceClient(conn)
+	for i := 0; i < 2; i++ {
+		_, err := posts.GetPost(context.Background(), &blogv1.GetPostRequest{Id: 1})
+		assertCode(t, err, codes.Internal, "Internal server error")
+	}
+
+	stream, err := blogv1.NewCommentServiceClient(conn).WatchComments(context.Background(), &blogv1.WatchCommentsRequest{PostId: 1})
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	_, err = stream.Recv()
+	assertCode(t, err, codes.Internal, "Internal server error")
+}
@@ -3,20 +3,18 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
-	"time"
 
-	"blog-api/internal/config"
-	"blog-api/internal/database"
+	"blog-api/internal/logging"
+	"blog-api/internal/middleware"
 	"blog-api/internal/models"
-
-	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"blog-api/internal/service"
 )
 
 type RegisterRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 	Name     string `json:"name"`
+	Username string `json:"username,omitempty"` // Opsional, dibuat dari email jika kosong
 }
 
 type LoginRequest struct {
@@ -29,60 +27,31 @@ type AuthResponse struct {
 	User  models.User `json:"user"`
 }
 
-func Register(w http.ResponseWriter, r *http.Request) {
+// AuthHandler - Register dan login
+type AuthHandler struct {
+	users  *service.UserService
+	tokens *service.TokenService
+}
+
+func NewAuthHandler(users *service.UserService, tokens *service.TokenService) *AuthHandler {
+	return &AuthHandler{users: users, tokens: tokens}
+}
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Validasi input menggunakan validator
-	valid, errMsg := ValidateRequired(map[string]string{
-		"email":    req.Email,
-		"password": req.Password,
-		"name":     req.Name,
-	})
-	if !valid {
-		HandleValidationError(w, errMsg)
-		return
-	}
-
-	if !ValidateEmail(req.Email) {
-		HandleValidationError(w, "Invalid email format")
-		return
-	}
-
-	if !ValidatePassword(req.Password) {
-		HandleValidationError(w, "Password must be at least 6 characters")
-		return
-	}
-
-	if !ValidateStringLength(req.Name, 2, 100) {
-		HandleValidationError(w, "Name must be between 2 and 100 characters")
-		return
-	}
-
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	user, err := h.users.Register(r.Context(), service.RegisterInput(req))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to hash password")
-		return
-	}
-
-	// Buat user baru
-	user := models.User{
-		Email:    req.Email,
-		Password: string(hashedPassword),
-		Name:     req.Name,
-	}
-
-	if err := database.GetDB().Create(&user).Error; err != nil {
-		respondError(w, http.StatusBadRequest, "Email already exists")
+		respondServiceError(w, r, err)
 		return
 	}
 
 	// Generate JWT token
-	token, err := generateJWT(user.ID)
+	token, err := h.tokens.Generate(r.Context(), user.ID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -94,38 +63,21 @@ func Register(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func Login(w http.ResponseWriter, r *http.Request) {
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Validasi input
-	valid, errMsg := ValidateRequired(map[string]string{
-		"email":    req.Email,
-		"password": req.Password,
-	})
-	if !valid {
-		HandleValidationError(w, errMsg)
-		return
-	}
-
-	// Cari user berdasarkan email
-	var user models.User
-	if err := database.GetDB().Where("email = ?", req.Email).First(&user).Error; err != nil {
-		respondError(w, http.StatusUnauthorized, "Invalid credentials")
-		return
-	}
-
-	// Verifikasi password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		respondError(w, http.StatusUnauthorized, "Invalid credentials")
+	user, err := h.users.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
 	// Generate JWT token
-	token, err := generateJWT(user.ID)
+	token, err := h.tokens.Generate(r.Context(), user.ID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate token")
 		return
@@ -137,18 +89,6 @@ func Login(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-func generateJWT(userID uint) (string, error) {
-	cfg := config.LoadConfig()
-
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Token berlaku 24 jam
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(cfg.JWTSecret))
-}
-
 // Helper functions
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -159,3 +99,33 @@ func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
+
+// serviceErrorStatus - Status HTTP untuk kategori error service
+var serviceErrorStatus = map[service.Code]int{
+	service.CodeInvalid:         http.StatusBadRequest,
+	service.CodeUnauthenticated: http.StatusUnauthorized,
+	service.CodeForbidden:       http.StatusForbidden,
+	service.CodeNotFound:        http.StatusNotFound,
+	service.CodeConflict:        http.StatusConflict,
+	service.CodeInternal:        http.StatusInternalServerError,
+	service.CodeRateLimited:     http.StatusTooManyRequests,
+}
+
+// respondServiceError - Kirim error dari package service dengan status HTTP yang sesuai,
+// error internal dicatat ke log request
+func respondServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	code := service.ErrorCode(err)
+	if code == service.CodeInternal {
+		logging.FromContext(r.Context()).Error("service error", "error", err)
+	}
+	setRateLimitHeaders(w, service.RateLimitOf(err))
+	respondError(w, serviceErrorStatus[code], err.Error())
+}
+
+// setRateLimitHeaders - Header RateLimit-* (dan Retry-After jika ditolak) untuk limit yang dicek
+// di service, sama seperti middleware.RateLimiter; nil berarti tanpa header
+func setRateLimitHeaders(w http.ResponseWriter, limit *service.RateLimit) {
+	if limit != nil {
+		middleware.SetRateLimitHeaders(w.Header(), limit.Policy, limit.Result)
+	}
+}
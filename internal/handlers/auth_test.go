@@ -7,26 +7,110 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"blog-api/internal/config"
 	"blog-api/internal/database"
-	"blog-api/internal/models"
+	"blog-api/internal/ratelimit"
+	"blog-api/internal/realtime"
+	"blog-api/internal/repository"
+	"blog-api/internal/service"
+	"blog-api/internal/storage"
+	"blog-api/internal/webhook"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
-func setupTestDB(t *testing.T) {
-	var err error
-	database.DB, err = gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+// testHandlers - Handler yang di-wire ke database :memory:, hub realtime, dispatcher webhook
+// dan storage local milik satu test, cfg bisa diubah per test
+type testHandlers struct {
+	cfg           *config.Config
+	db            *gorm.DB
+	hub           *realtime.Hub
+	dispatcher    *webhook.Dispatcher
+	files         *storage.LocalStorage
+	auth          *AuthHandler
+	users         *UserHandler
+	posts         *PostHandler
+	comments      *CommentHandler
+	graphql       *GraphQLHandler
+	bookmarks     *BookmarkHandler
+	readingLists  *ReadingListHandler
+	follows       *FollowHandler
+	feed          *FeedHandler
+	notifications *NotificationHandler
+	media         *MediaHandler
+	webhooks      *WebhookHandler
+	reactions     *ReactionHandler
+	syndication   *SyndicationHandler
+	sitemap       *SitemapHandler
+	presence      *PresenceHandler
+}
+
+// setupTestDB - configure mengubah cfg sebelum service dibuat (pengganti t.Setenv agar test bisa paralel)
+func setupTestDB(t *testing.T, configure ...func(cfg *config.Config)) *testHandlers {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	if err != nil {
 		t.Fatalf("Failed to connect to test database: %v", err)
 	}
+	// Satu koneksi: setiap koneksi baru ke :memory: adalah database kosong yang terpisah,
+	// termasuk koneksi dari goroutine server httptest
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("Failed to get test connection pool: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
 
 	// Migrate tables
-	database.DB.AutoMigrate(&models.User{}, &models.Post{}, &models.Comment{})
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	files, err := storage.NewLocal(t.TempDir(), "/media")
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	cfg := config.LoadConfig()
+	for _, fn := range configure {
+		fn(cfg)
+	}
+	hub := realtime.NewHub(realtime.NewMemoryBroker())
+	dispatcher := webhook.NewDispatcher(cfg, db)
+	store := repository.NewGormStore(db)
+	users := service.NewUserService(store)
+	posts := service.NewPostService(cfg, store, hub, dispatcher)
+	comments := service.NewCommentService(cfg, store, ratelimit.NewMemoryStore(), hub, dispatcher)
+	reactions := service.NewReactionService(cfg, store)
+	bookmarks := service.NewBookmarkService(cfg, store)
+	return &testHandlers{
+		cfg:           cfg,
+		db:            db,
+		hub:           hub,
+		dispatcher:    dispatcher,
+		files:         files,
+		auth:          NewAuthHandler(users, service.NewTokenService(cfg.JWTSecret)),
+		users:         NewUserHandler(users),
+		posts:         NewPostHandler(cfg, posts, comments, reactions, bookmarks, files),
+		comments:      NewCommentHandler(cfg, comments, posts, reactions, hub),
+		graphql:       NewGraphQLHandler(cfg, users, posts, comments, reactions),
+		bookmarks:     NewBookmarkHandler(bookmarks),
+		readingLists:  NewReadingListHandler(service.NewReadingListService(cfg, store)),
+		follows:       NewFollowHandler(service.NewFollowService(cfg, store)),
+		feed:          NewFeedHandler(posts, reactions, bookmarks, files),
+		notifications: NewNotificationHandler(service.NewNotificationService(cfg, store)),
+		media:         NewMediaHandler(cfg, service.NewMediaService(cfg, store, files, nil), files),
+		webhooks:      NewWebhookHandler(service.NewWebhookService(cfg, store, dispatcher)),
+		reactions:     NewReactionHandler(reactions),
+		syndication:   NewSyndicationHandler(cfg, users, posts),
+		sitemap:       NewSitemapHandler(cfg, service.NewSitemapService(cfg, store)),
+		presence:      NewPresenceHandler(cfg, service.NewPresenceService(cfg, store, hub), hub),
+	}
 }
 
 func TestRegister(t *testing.T) {
-	setupTestDB(t)
+	t.Parallel()
+	h := setupTestDB(t)
 
 	tests := []struct {
 		name           string
@@ -83,7 +167,7 @@ func TestRegister(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
-			Register(w, req)
+			h.auth.Register(w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
@@ -106,7 +190,8 @@ func TestRegister(t *testing.T) {
 }
 
 func TestLogin(t *testing.T) {
-	setupTestDB(t)
+	t.Parallel()
+	h := setupTestDB(t)
 
 	// Create test user first
 	registerBody := RegisterRequest{
@@ -117,7 +202,7 @@ func TestLogin(t *testing.T) {
 	body, _ := json.Marshal(registerBody)
 	req := httptest.NewRequest("POST", "/api/register", bytes.NewBuffer(body))
 	w := httptest.NewRecorder()
-	Register(w, req)
+	h.auth.Register(w, req)
 
 	tests := []struct {
 		name           string
@@ -161,7 +246,7 @@ func TestLogin(t *testing.T) {
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 
-			Login(w, req)
+			h.auth.Login(w, req)
 
 			if w.Code != tt.expectedStatus {
 				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// BookmarkHandler - Bookmark post milik user
+type BookmarkHandler struct {
+	bookmarks *service.BookmarkService
+}
+
+func NewBookmarkHandler(bookmarks *service.BookmarkService) *BookmarkHandler {
+	return &BookmarkHandler{bookmarks: bookmarks}
+}
+
+// AddBookmark - Simpan post ke bookmark user (idempotent)
+func (h *BookmarkHandler) AddBookmark(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	if err := h.bookmarks.Add(r.Context(), userID, uint(postID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"bookmarked": true, "post_id": postID})
+}
+
+// RemoveBookmark - Hapus post dari bookmark user (idempotent)
+func (h *BookmarkHandler) RemoveBookmark(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	if err := h.bookmarks.Remove(r.Context(), userID, uint(postID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"bookmarked": false, "post_id": postID})
+}
+
+// GetBookmarks - Ambil bookmark milik user yang login (terbaru dulu, cursor pagination)
+func (h *BookmarkHandler) GetBookmarks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	bookmarks, nextCursor, err := h.bookmarks.List(r.Context(), userID, service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PaginatedResponse{Data: bookmarks, NextCursor: nextCursor})
+}
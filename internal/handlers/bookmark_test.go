@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blog-api/internal/models"
+)
+
+func TestBookmarks(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	reader := createTestUser(t, h.db, "bookmarker@example.com")
+	first := createTestPost(t, h.db, reader.ID)
+	second := createTestPost(t, h.db, reader.ID)
+
+	for _, post := range []models.Post{first, second} {
+		w := httptest.NewRecorder()
+		h.bookmarks.AddBookmark(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(post.ID)}, reader.ID))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+	}
+
+	t.Run("List bookmarks newest first", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.bookmarks.GetBookmarks(w, newTestRequest("GET", "/?limit=1", nil, nil, reader.ID))
+
+		var response struct {
+			Data       []models.Bookmark `json:"data"`
+			NextCursor string            `json:"next_cursor"`
+		}
+		json.NewDecoder(w.Body).Decode(&response)
+
+		if len(response.Data) != 1 || response.Data[0].PostID != second.ID {
+			t.Fatalf("Expected latest bookmark first, got %+v", response.Data)
+		}
+		if response.NextCursor == "" {
+			t.Error("Expected next cursor")
+		}
+	})
+
+	t.Run("Bookmarked flag on posts", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.bookmarks.RemoveBookmark(w, newTestRequest("DELETE", "/", nil, map[string]string{"id": fmt.Sprint(first.ID)}, reader.ID))
+
+		w = httptest.NewRecorder()
+		h.posts.GetPosts(w, newTestRequest("GET", "/", nil, nil, reader.ID))
+
+		var posts []models.Post
+		json.NewDecoder(w.Body).Decode(&posts)
+
+		flags := map[uint]bool{}
+		for _, post := range posts {
+			if post.Bookmarked == nil {
+				t.Fatalf("Expected bookmarked flag for authenticated request on post %d", post.ID)
+			}
+			flags[post.ID] = *post.Bookmarked
+		}
+		if flags[first.ID] || !flags[second.ID] {
+			t.Errorf("Unexpected bookmarked flags: %v", flags)
+		}
+	})
+
+	t.Run("No flag for anonymous request", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.posts.GetPosts(w, newTestRequest("GET", "/", nil, nil, 0))
+
+		var posts []map[string]interface{}
+		json.NewDecoder(w.Body).Decode(&posts)
+
+		if _, exists := posts[0]["bookmarked"]; exists {
+			t.Error("Expected no bookmarked field for anonymous request")
+		}
+	})
+}
+
+func TestSharedReadingList(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	owner := createTestUser(t, h.db, "lists@example.com")
+	other := createTestUser(t, h.db, "other@example.com")
+	post := createTestPost(t, h.db, owner.ID)
+
+	w := httptest.NewRecorder()
+	h.readingLists.CreateReadingList(w, newTestRequest("POST", "/", ReadingListRequest{Name: "Weekend reads"}, nil, owner.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var list models.ReadingList
+	json.NewDecoder(w.Body).Decode(&list)
+	listVars := map[string]string{"list_id": fmt.Sprint(list.ID), "post_id": fmt.Sprint(post.ID)}
+
+	w = httptest.NewRecorder()
+	h.readingLists.AddReadingListItem(w, newTestRequest("PUT", "/", nil, listVars, other.ID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for non-owner, got %d", http.StatusForbidden, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.readingLists.AddReadingListItem(w, newTestRequest("PUT", "/", nil, listVars, owner.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	shareVars := map[string]string{"token": list.ShareToken}
+
+	w = httptest.NewRecorder()
+	h.readingLists.GetSharedReadingList(w, newTestRequest("GET", "/", nil, shareVars, 0))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected private list to be hidden, got status %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.readingLists.UpdateReadingList(w, newTestRequest("PUT", "/", ReadingListRequest{Name: "Weekend reads", IsPublic: true}, listVars, owner.ID))
+
+	w = httptest.NewRecorder()
+	h.readingLists.GetSharedReadingList(w, newTestRequest("GET", "/", nil, shareVars, 0))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var shared models.ReadingList
+	json.NewDecoder(w.Body).Decode(&shared)
+	if len(shared.Items) != 1 || shared.Items[0].Post.ID != post.ID {
+		t.Errorf("Expected shared list to contain the post, got %+v", shared.Items)
+	}
+}
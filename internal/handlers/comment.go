@@ -5,9 +5,10 @@ import (
 	"net/http"
 	"strconv"
 
-	"blog-api/internal/database"
+	"blog-api/internal/config"
 	"blog-api/internal/middleware"
-	"blog-api/internal/models"
+	"blog-api/internal/realtime"
+	"blog-api/internal/service"
 
 	"github.com/gorilla/mux"
 )
@@ -16,8 +17,21 @@ type CommentRequest struct {
 	Content string `json:"content"`
 }
 
+// CommentHandler - Comment pada post beserta stream realtime-nya
+type CommentHandler struct {
+	cfg       *config.Config
+	comments  *service.CommentService
+	posts     *service.PostService
+	reactions *service.ReactionService // Personalisasi: viewer_reactions
+	hub       *realtime.Hub            // Stream SSE
+}
+
+func NewCommentHandler(cfg *config.Config, comments *service.CommentService, posts *service.PostService, reactions *service.ReactionService, hub *realtime.Hub) *CommentHandler {
+	return &CommentHandler{cfg: cfg, comments: comments, posts: posts, reactions: reactions, hub: hub}
+}
+
 // CreateComment - Buat comment baru pada post (dengan transaksi)
-func CreateComment(w http.ResponseWriter, r *http.Request) {
+func (h *CommentHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
 		respondError(w, http.StatusUnauthorized, "Unauthorized")
@@ -37,61 +51,59 @@ func CreateComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validasi input
-	valid, errMsg := ValidateRequired(map[string]string{
-		"content": req.Content,
-	})
-	if !valid {
-		HandleValidationError(w, errMsg)
+	comment, limit, err := h.comments.Create(r.Context(), requestOrigin(h.cfg, r), userID, uint(postID), req.Content)
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
+	setRateLimitHeaders(w, limit)
+	respondJSON(w, http.StatusCreated, comment)
+}
 
-	if !ValidateStringLength(req.Content, 1, 1000) {
-		HandleValidationError(w, "Content must be between 1 and 1000 characters")
+// GetComments - Ambil comments untuk post tertentu (cursor pagination)
+// Query params: limit, cursor, sort=oldest|newest|top
+func (h *CommentHandler) GetComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["post_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
 		return
 	}
 
-	// Mulai transaksi
-	tx := database.GetDB().Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	// Cek apakah post exists
-	var post models.Post
-	if err := tx.First(&post, postID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusNotFound, "Post not found")
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
 		return
 	}
 
-	comment := models.Comment{
-		Content: req.Content,
-		UserID:  userID,
-		PostID:  uint(postID),
-	}
-
-	if err := tx.Create(&comment).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to create comment")
+	comments, nextCursor, err := h.comments.List(r.Context(), uint(postID), service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+		Sort:   r.URL.Query().Get("sort"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	// Preload user data
-	if err := tx.Preload("User").First(&comment, comment.ID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to load comment data")
+	if err := h.reactions.AttachToComments(r.Context(), viewerOf(r), comments); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	tx.Commit()
-	respondJSON(w, http.StatusCreated, comment)
+	response := PaginatedResponse{Data: comments, NextCursor: nextCursor}
+
+	respondJSON(w, http.StatusOK, response)
 }
 
-// GetComments - Ambil semua comments untuk post tertentu
-func GetComments(w http.ResponseWriter, r *http.Request) {
+// DeleteComment - Hapus comment (dengan transaksi, soft delete)
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
 	vars := mux.Vars(r)
 	postID, err := strconv.ParseUint(vars["post_id"], 10, 32)
 	if err != nil {
@@ -99,24 +111,21 @@ func GetComments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Cek apakah post exists
-	var post models.Post
-	if err := database.GetDB().First(&post, postID).Error; err != nil {
-		respondError(w, http.StatusNotFound, "Post not found")
+	commentID, err := strconv.ParseUint(vars["comment_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid comment ID")
 		return
 	}
 
-	var comments []models.Comment
-	if err := database.GetDB().Preload("User").Where("post_id = ?", postID).Find(&comments).Error; err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch comments")
+	if err := h.comments.Delete(r.Context(), requestOrigin(h.cfg, r), userID, uint(postID), uint(commentID)); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
-
-	respondJSON(w, http.StatusOK, comments)
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Comment deleted successfully"})
 }
 
-// DeleteComment - Hapus comment (dengan transaksi, soft delete)
-func DeleteComment(w http.ResponseWriter, r *http.Request) {
+// RestoreComment - Kembalikan comment yang sudah di-soft delete (dengan transaksi)
+func (h *CommentHandler) RestoreComment(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
 		respondError(w, http.StatusUnauthorized, "Unauthorized")
@@ -136,35 +145,10 @@ func DeleteComment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Mulai transaksi
-	tx := database.GetDB().Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	var comment models.Comment
-	if err := tx.Where("id = ? AND post_id = ?", commentID, postID).First(&comment).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusNotFound, "Comment not found")
-		return
-	}
-
-	// Cek ownership
-	if comment.UserID != userID {
-		tx.Rollback()
-		respondError(w, http.StatusForbidden, "You can only delete your own comments")
-		return
-	}
-
-	// Soft delete comment
-	if err := tx.Delete(&comment).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to delete comment")
+	comment, err := h.comments.Restore(r.Context(), userID, uint(postID), uint(commentID))
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
-
-	tx.Commit()
-	respondJSON(w, http.StatusOK, map[string]string{"message": "Comment deleted successfully"})
+	respondJSON(w, http.StatusOK, comment)
 }
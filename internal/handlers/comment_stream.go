@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/realtime"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// Event tambahan pada stream comment, selain service.CommentEventCreated/Deleted
+const (
+	// streamEventReset - Last-Event-ID sudah tidak ada di buffer, client perlu memuat ulang GetComments
+	streamEventReset = "reset"
+)
+
+const (
+	// sseHeartbeatInterval - Comment kosong agar proxy tidak menutup koneksi idle
+	sseHeartbeatInterval = 15 * time.Second
+	// sseRetry - Jeda reconnect yang disarankan ke EventSource
+	sseRetry = 3 * time.Second
+)
+
+// StreamComments - Server-Sent Events untuk comment baru/terhapus pada post.
+// Mendukung resume lewat header Last-Event-ID (atau query last_event_id).
+func (h *CommentHandler) StreamComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["post_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	// Cek apakah post exists
+	post, err := h.posts.Get(r.Context(), uint(postID))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "Streaming not supported")
+		return
+	}
+
+	if h.hub == nil {
+		respondError(w, http.StatusServiceUnavailable, "Realtime is not available")
+		return
+	}
+
+	release, ok := h.hub.AcquireClient(middleware.ClientIP(r, h.cfg.TrustedProxies), h.cfg.SSEMaxStreamsPerClient)
+	if !ok {
+		respondError(w, http.StatusTooManyRequests, "Too many open streams")
+		return
+	}
+	defer release()
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("last_event_id")
+	}
+	sub, backlog, resumed := h.hub.Subscribe(service.CommentTopic(post.ID), lastEventID)
+	defer sub.Close()
+
+	// Stream hidup lebih lama dari HTTP_READ_TIMEOUT/HTTP_WRITE_TIMEOUT server, lepas deadline koneksi
+	rc := http.NewResponseController(w)
+	rc.SetReadDeadline(time.Time{})
+	rc.SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Matikan buffering nginx
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "retry: %d\n\n", sseRetry.Milliseconds())
+	if !resumed {
+		writeSSE(w, realtime.Message{Event: streamEventReset, Data: []byte("{}")})
+	}
+	for _, msg := range backlog {
+		writeSSE(w, msg)
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+	// Koneksi dibatasi umurnya, EventSource reconnect otomatis dengan Last-Event-ID
+	lifetime := time.NewTimer(h.cfg.SSEMaxDuration)
+	defer lifetime.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-lifetime.C:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case msg, ok := <-sub.C:
+			// Channel ditutup jika koneksi terlalu lambat mengikuti event
+			if !ok {
+				return
+			}
+			if err := writeSSE(w, msg); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE - Tulis satu event SSE (data JSON selalu satu baris)
+func writeSSE(w http.ResponseWriter, msg realtime.Message) error {
+	var b strings.Builder
+	if msg.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", msg.ID)
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", msg.Event, msg.Data)
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"bufio"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// sseEvent - Satu event hasil parsing stream
+type sseEvent struct {
+	ID, Event, Data string
+}
+
+// readSSEEvent - Baca event berikutnya (melewati baris retry dan heartbeat)
+func readSSEEvent(t *testing.T, reader *bufio.Reader) sseEvent {
+	var event sseEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			event.ID = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			event.Data = strings.TrimPrefix(line, "data: ")
+		case line == "" && event.Event != "":
+			return event
+		}
+	}
+}
+
+func openCommentStream(t *testing.T, serverURL, lastEventID string) *http.Response {
+	req, _ := http.NewRequest("GET", serverURL, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	return resp
+}
+
+func TestStreamComments(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	h.cfg.SSEMaxStreamsPerClient = 1
+
+	author := createTestUser(t, h.db, "author@example.com")
+	post := createTestPost(t, h.db, author.ID)
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.comments.StreamComments(w, mux.SetURLVars(r, vars))
+	}))
+	defer server.Close()
+
+	resp := openCommentStream(t, server.URL, "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// Koneksi kedua dari IP yang sama melebihi batas
+	second := openCommentStream(t, server.URL, "")
+	second.Body.Close()
+	if second.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, second.StatusCode)
+	}
+
+	w := httptest.NewRecorder()
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Live comment"}, vars, author.ID))
+	created := readSSEEvent(t, reader)
+	if created.Event != service.CommentEventCreated || created.ID == "" || !strings.Contains(created.Data, `"content":"Live comment"`) {
+		t.Fatalf("Unexpected event %+v", created)
+	}
+
+	var commentID uint
+	fmt.Sscanf(created.Data, `{"id":%d`, &commentID)
+	w = httptest.NewRecorder()
+	h.comments.DeleteComment(w, newTestRequest("DELETE", "/", nil, map[string]string{
+		"post_id":    fmt.Sprint(post.ID),
+		"comment_id": fmt.Sprint(commentID),
+	}, author.ID))
+	deleted := readSSEEvent(t, reader)
+	if deleted.Event != service.CommentEventDeleted || deleted.Data != fmt.Sprintf(`{"id":%d,"post_id":%d}`, commentID, post.ID) {
+		t.Fatalf("Unexpected event %+v", deleted)
+	}
+	resp.Body.Close()
+
+	// Reconnect dengan Last-Event-ID mengirim ulang event setelahnya dari buffer
+	h.cfg.SSEMaxStreamsPerClient = 5
+	resumed := openCommentStream(t, server.URL, created.ID)
+	defer resumed.Body.Close()
+	if event := readSSEEvent(t, bufio.NewReader(resumed.Body)); event.ID != deleted.ID {
+		t.Errorf("Expected replay of %s, got %+v", deleted.ID, event)
+	}
+
+	expired := openCommentStream(t, server.URL, "no-longer-buffered")
+	defer expired.Body.Close()
+	if event := readSSEEvent(t, bufio.NewReader(expired.Body)); event.Event != streamEventReset {
+		t.Errorf("Expected reset event, got %+v", event)
+	}
+}
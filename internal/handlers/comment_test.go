@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// createTestUser - Helper untuk membuat user langsung di database
+func createTestUser(t *testing.T, db *gorm.DB, email string) models.User {
+	user := models.User{Email: email, Password: "hashed", Name: "Test User", Username: strings.Split(email, "@")[0]}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user
+}
+
+// createTestPost - Helper untuk membuat post langsung di database
+func createTestPost(t *testing.T, db *gorm.DB, userID uint) models.Post {
+	post := models.Post{Title: "Test Post", Content: "Test post content", UserID: userID}
+	if err := db.Create(&post).Error; err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+	return post
+}
+
+// newTestRequest - Helper untuk membuat request dengan mux vars dan user_id (0 = anonymous)
+func newTestRequest(method, target string, body interface{}, vars map[string]string, userID uint) *http.Request {
+	var buf bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+
+	req := httptest.NewRequest(method, target, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	req = mux.SetURLVars(req, vars)
+	if userID != 0 {
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserIDKey, userID))
+	}
+	return req
+}
+
+func TestGetCommentsPagination(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "commenter@example.com")
+	post := createTestPost(t, h.db, user.ID)
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+
+	for i := 1; i <= 5; i++ {
+		h.db.Create(&models.Comment{
+			Content: fmt.Sprintf("Comment %d", i),
+			UserID:  user.ID,
+			PostID:  post.ID,
+			Score:   int64(i % 3),
+		})
+	}
+
+	tests := []struct {
+		name        string
+		query       string
+		expectedIDs []uint
+		expectNext  bool
+	}{
+		{"Oldest first page", "?limit=2", []uint{1, 2}, true},
+		{"Newest first page", "?limit=2&sort=newest", []uint{5, 4}, true},
+		{"Top first page", "?limit=3&sort=top", []uint{5, 2, 4}, true},
+		{"All in one page", "?limit=10", []uint{1, 2, 3, 4, 5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.comments.GetComments(w, newTestRequest("GET", "/api/posts/1/comments"+tt.query, nil, vars, 0))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			var response struct {
+				Data       []models.Comment `json:"data"`
+				NextCursor string           `json:"next_cursor"`
+			}
+			json.NewDecoder(w.Body).Decode(&response)
+
+			if len(response.Data) != len(tt.expectedIDs) {
+				t.Fatalf("Expected %d comments, got %d", len(tt.expectedIDs), len(response.Data))
+			}
+			for i, id := range tt.expectedIDs {
+				if response.Data[i].ID != id {
+					t.Errorf("Expected comment %d at position %d, got %d", id, i, response.Data[i].ID)
+				}
+			}
+			if (response.NextCursor != "") != tt.expectNext {
+				t.Errorf("Expected next cursor presence %v, got %q", tt.expectNext, response.NextCursor)
+			}
+		})
+	}
+
+	t.Run("Follow cursor on top sort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.comments.GetComments(w, newTestRequest("GET", "/api/posts/1/comments?limit=3&sort=top", nil, vars, 0))
+
+		var first PaginatedResponse
+		json.NewDecoder(w.Body).Decode(&first)
+
+		w = httptest.NewRecorder()
+		h.comments.GetComments(w, newTestRequest("GET", "/api/posts/1/comments?limit=3&sort=top&cursor="+first.NextCursor, nil, vars, 0))
+
+		var second struct {
+			Data []models.Comment `json:"data"`
+		}
+		json.NewDecoder(w.Body).Decode(&second)
+
+		if len(second.Data) != 2 || second.Data[0].ID != 1 || second.Data[1].ID != 3 {
+			t.Errorf("Unexpected second page: %+v", second.Data)
+		}
+	})
+
+	t.Run("Invalid sort", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.comments.GetComments(w, newTestRequest("GET", "/api/posts/1/comments?sort=random", nil, vars, 0))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestCommentCountMaintained(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "counter@example.com")
+	post := createTestPost(t, h.db, user.ID)
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+
+	commentCount := func() int64 {
+		var p models.Post
+		h.db.First(&p, post.ID)
+		return p.CommentCount
+	}
+
+	w := httptest.NewRecorder()
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Nice post"}, vars, user.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	if got := commentCount(); got != 1 {
+		t.Fatalf("Expected comment_count 1 after create, got %d", got)
+	}
+
+	var comment models.Comment
+	json.NewDecoder(w.Body).Decode(&comment)
+	vars["comment_id"] = fmt.Sprint(comment.ID)
+
+	w = httptest.NewRecorder()
+	h.comments.DeleteComment(w, newTestRequest("DELETE", "/", nil, vars, user.ID))
+	if got := commentCount(); got != 0 {
+		t.Fatalf("Expected comment_count 0 after delete, got %d", got)
+	}
+
+	w = httptest.NewRecorder()
+	h.comments.RestoreComment(w, newTestRequest("POST", "/", nil, vars, user.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if got := commentCount(); got != 1 {
+		t.Fatalf("Expected comment_count 1 after restore, got %d", got)
+	}
+}
+
+func TestGetPostCapsEmbeddedComments(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "embed@example.com")
+	post := createTestPost(t, h.db, user.ID)
+
+	for i := 0; i < maxEmbeddedComments+5; i++ {
+		h.db.Create(&models.Comment{Content: "Comment", UserID: user.ID, PostID: post.ID})
+	}
+
+	w := httptest.NewRecorder()
+	h.posts.GetPost(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(post.ID)}, 0))
+
+	var response models.Post
+	json.NewDecoder(w.Body).Decode(&response)
+
+	if len(response.Comments) != maxEmbeddedComments {
+		t.Errorf("Expected %d embedded comments, got %d", maxEmbeddedComments, len(response.Comments))
+	}
+	if response.Comments[0].User.ID != user.ID {
+		t.Error("Expected embedded comments to include user")
+	}
+	if response.CommentsURL == "" {
+		t.Error("Expected comments_url in response")
+	}
+}
+
+func TestGetPostsCapsEmbeddedComments(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "list-embed@example.com")
+	post := createTestPost(t, h.db, user.ID)
+
+	for i := 0; i < maxEmbeddedComments+5; i++ {
+		h.db.Create(&models.Comment{Content: "Comment", UserID: user.ID, PostID: post.ID})
+	}
+
+	w := httptest.NewRecorder()
+	h.posts.GetPosts(w, newTestRequest("GET", "/", nil, nil, 0))
+
+	var response []models.Post
+	json.NewDecoder(w.Body).Decode(&response)
+
+	if len(response) != 1 || len(response[0].Comments) != maxEmbeddedComments {
+		t.Fatalf("Expected one post with %d embedded comments, got %+v", maxEmbeddedComments, response)
+	}
+	if response[0].Comments[0].User.ID != user.ID {
+		t.Error("Expected embedded comments to include user")
+	}
+	if response[0].CommentsURL != fmt.Sprintf("/api/posts/%d/comments", post.ID) {
+		t.Errorf("Expected comments_url, got %q", response[0].CommentsURL)
+	}
+}
+
+func TestCreateCommentRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t, func(cfg *config.Config) {
+		cfg.RateLimitPolicies["comment"] = config.RateLimitPolicy{Requests: 2, Period: time.Minute, KeyBy: "user"}
+	})
+
+	user := createTestUser(t, h.db, "limited@example.com")
+	post := createTestPost(t, h.db, user.ID)
+	create := func(postID uint) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		vars := map[string]string{"post_id": fmt.Sprint(postID)}
+		h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Nice post"}, vars, user.ID))
+		return w
+	}
+
+	// Post yang tidak ada tidak menghabiskan kuota
+	if w := create(post.ID + 100); w.Code != http.StatusNotFound || w.Header().Get("RateLimit-Remaining") != "" {
+		t.Fatalf("Expected 404 without rate limit headers, got %d %v", w.Code, w.Header())
+	}
+
+	for _, remaining := range []string{"1", "0"} {
+		w := create(post.ID)
+		if w.Code != http.StatusCreated || w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != remaining {
+			t.Fatalf("Expected 201 with %s remaining, got %d %v", remaining, w.Code, w.Header())
+		}
+		if w.Header().Get("RateLimit-Policy") != "2;w=60" {
+			t.Errorf("Expected RateLimit-Policy 2;w=60, got %q", w.Header().Get("RateLimit-Policy"))
+		}
+	}
+
+	w := create(post.ID)
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Errorf("Expected 429 with Retry-After and RateLimit headers, got %d %v", w.Code, w.Header())
+	}
+}
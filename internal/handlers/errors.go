@@ -2,8 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
+
+	"blog-api/internal/logging"
 )
 
 type ErrorResponse struct {
@@ -16,9 +17,9 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// HandleError - Error handler terpusat dengan logging
-func HandleError(w http.ResponseWriter, statusCode int, err error, message string) {
-	log.Printf("Error [%d]: %s - %v", statusCode, message, err)
+// HandleError - Error handler terpusat, dicatat lewat logger request (membawa request_id)
+func HandleError(w http.ResponseWriter, r *http.Request, statusCode int, err error, message string) {
+	logging.FromContext(r.Context()).Error(message, "status", statusCode, "error", err)
 
 	response := ErrorResponse{
 		Error:   message,
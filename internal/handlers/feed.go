@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+	"blog-api/internal/storage"
+)
+
+// FeedHandler - Timeline post dari author yang di-follow
+type FeedHandler struct {
+	posts     *service.PostService
+	reactions *service.ReactionService
+	bookmarks *service.BookmarkService
+	files     storage.Storage // URL featured image
+}
+
+func NewFeedHandler(posts *service.PostService, reactions *service.ReactionService, bookmarks *service.BookmarkService, files storage.Storage) *FeedHandler {
+	return &FeedHandler{posts: posts, reactions: reactions, bookmarks: bookmarks, files: files}
+}
+
+// GetFeed - Timeline post dari author yang di-follow (terbaru dulu, cursor pagination)
+func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	posts, nextCursor, err := h.posts.Feed(r.Context(), userID, service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	if err := h.reactions.AttachToPosts(r.Context(), userID, posts); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	if err := h.bookmarks.AttachToPosts(r.Context(), userID, posts); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	prepareFeaturedImages(h.files, posts)
+	respondJSON(w, http.StatusOK, PaginatedResponse{Data: posts, NextCursor: nextCursor})
+}
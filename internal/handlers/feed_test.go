@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+)
+
+func TestFeed(t *testing.T) {
+	t.Parallel()
+	for _, fanout := range []bool{false, true} {
+		t.Run(fmt.Sprintf("FEED_FANOUT=%t", fanout), func(t *testing.T) {
+			t.Parallel()
+			h := setupTestDB(t, func(cfg *config.Config) { cfg.FeedFanout = fanout })
+
+			reader := createTestUser(t, h.db, "reader@example.com")
+			followed := createTestUser(t, h.db, "followed@example.com")
+			stranger := createTestUser(t, h.db, "stranger@example.com")
+
+			// Post lama sebelum follow harus tetap muncul (backfill pada mode fan-out)
+			oldPost := createTestPost(t, h.db, followed.ID)
+			createTestPost(t, h.db, stranger.ID)
+
+			w := httptest.NewRecorder()
+			h.follows.FollowUser(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(followed.ID)}, reader.ID))
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+			}
+
+			w = httptest.NewRecorder()
+			h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "New post", Content: "Fresh content for followers"}, nil, followed.ID))
+			var newPost models.Post
+			json.NewDecoder(w.Body).Decode(&newPost)
+
+			w = httptest.NewRecorder()
+			h.feed.GetFeed(w, newTestRequest("GET", "/?limit=1", nil, nil, reader.ID))
+
+			var page struct {
+				Data       []models.Post `json:"data"`
+				NextCursor string        `json:"next_cursor"`
+			}
+			json.NewDecoder(w.Body).Decode(&page)
+			if len(page.Data) != 1 || page.Data[0].ID != newPost.ID {
+				t.Fatalf("Expected newest followed post first, got %+v", page.Data)
+			}
+
+			w = httptest.NewRecorder()
+			h.feed.GetFeed(w, newTestRequest("GET", "/?limit=1&cursor="+page.NextCursor, nil, nil, reader.ID))
+			page.NextCursor = ""
+			json.NewDecoder(w.Body).Decode(&page)
+			if len(page.Data) != 1 || page.Data[0].ID != oldPost.ID || page.NextCursor != "" {
+				t.Fatalf("Expected older followed post on last page, got %+v", page.Data)
+			}
+
+			w = httptest.NewRecorder()
+			h.follows.UnfollowUser(w, newTestRequest("DELETE", "/", nil, map[string]string{"id": fmt.Sprint(followed.ID)}, reader.ID))
+
+			w = httptest.NewRecorder()
+			h.feed.GetFeed(w, newTestRequest("GET", "/", nil, nil, reader.ID))
+			json.NewDecoder(w.Body).Decode(&page)
+			if len(page.Data) != 0 {
+				t.Errorf("Expected empty feed after unfollow, got %d posts", len(page.Data))
+			}
+		})
+	}
+}
+
+func TestFollowLists(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	fan := createTestUser(t, h.db, "fan@example.com")
+
+	w := httptest.NewRecorder()
+	h.follows.FollowUser(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(author.ID)}, author.ID))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d when following yourself, got %d", http.StatusBadRequest, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.follows.FollowUser(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(author.ID)}, fan.ID))
+
+	w = httptest.NewRecorder()
+	h.follows.GetFollowers(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(author.ID)}, 0))
+
+	var followers struct {
+		Data []models.User `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&followers)
+	if len(followers.Data) != 1 || followers.Data[0].ID != fan.ID {
+		t.Errorf("Expected fan in followers, got %+v", followers.Data)
+	}
+
+	w = httptest.NewRecorder()
+	h.follows.GetFollowing(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(fan.ID)}, 0))
+
+	var following struct {
+		Data []models.User `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&following)
+	if len(following.Data) != 1 || following.Data[0].ID != author.ID {
+		t.Errorf("Expected author in following, got %+v", following.Data)
+	}
+}
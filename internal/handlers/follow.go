@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// FollowHandler - Follow/unfollow author dan list followers/following
+type FollowHandler struct {
+	follows *service.FollowService
+}
+
+func NewFollowHandler(follows *service.FollowService) *FollowHandler {
+	return &FollowHandler{follows: follows}
+}
+
+// FollowUser - Follow author (idempotent, dengan transaksi)
+func (h *FollowHandler) FollowUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	followeeID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.follows.Follow(r.Context(), userID, uint(followeeID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"following": true, "user_id": followeeID})
+}
+
+// UnfollowUser - Berhenti follow author (idempotent, dengan transaksi)
+func (h *FollowHandler) UnfollowUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	followeeID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	if err := h.follows.Unfollow(r.Context(), userID, uint(followeeID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"following": false, "user_id": followeeID})
+}
+
+// GetFollowers - List user yang mem-follow user {id} (cursor pagination)
+func (h *FollowHandler) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.follows.Followers)
+}
+
+// GetFollowing - List user yang di-follow oleh user {id} (cursor pagination)
+func (h *FollowHandler) GetFollowing(w http.ResponseWriter, r *http.Request) {
+	h.listFollows(w, r, h.follows.Following)
+}
+
+// listFollows - Logic bersama untuk list followers/following, terbaru dulu
+func (h *FollowHandler) listFollows(w http.ResponseWriter, r *http.Request,
+	list func(ctx context.Context, userID uint, opts service.PageOptions) ([]models.User, string, error)) {
+	vars := mux.Vars(r)
+	userID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	users, nextCursor, err := list(r.Context(), uint(userID), service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PaginatedResponse{Data: users, NextCursor: nextCursor})
+}
@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// graphqlMaxBodyBytes - Ukuran maksimal body request GraphQL
+const graphqlMaxBodyBytes = 1 << 20
+
+// GraphQLRequest - Body request GraphQL standar
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlErrorCodes - Kode extensions.code untuk status HTTP yang dipakai REST handler
+var graphqlErrorCodes = map[int]string{
+	http.StatusBadRequest:          "BAD_USER_INPUT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "FORBIDDEN",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusInternalServerError: "INTERNAL_SERVER_ERROR",
+}
+
+// graphqlError - Error resolver dengan pesan yang sama seperti REST dan kode di extensions
+type graphqlError struct {
+	status    int
+	message   string
+	rateLimit *service.RateLimit // Untuk 429: status bucket yang menolak request
+}
+
+func newGraphQLError(status int, message string) error {
+	return &graphqlError{status: status, message: message}
+}
+
+// graphqlServiceError - Error dari package service dengan kode yang sama seperti status REST-nya
+func graphqlServiceError(err error) error {
+	return &graphqlError{
+		status:    serviceErrorStatus[service.ErrorCode(err)],
+		message:   err.Error(),
+		rateLimit: service.RateLimitOf(err),
+	}
+}
+
+// graphqlRateLimited - Error resolver yang ditolak rate limiter beserta status bucket-nya
+func graphqlRateLimited(errs []gqlerrors.FormattedError) (*service.RateLimit, bool) {
+	for _, formatted := range errs {
+		located, ok := formatted.OriginalError().(*gqlerrors.Error)
+		if !ok {
+			continue
+		}
+		var gqlErr *graphqlError
+		if errors.As(located.OriginalError, &gqlErr) && gqlErr.status == http.StatusTooManyRequests {
+			return gqlErr.rateLimit, true
+		}
+	}
+	return nil, false
+}
+
+func (e *graphqlError) Error() string {
+	return e.message
+}
+
+// Extensions - Dibaca graphql-go saat memformat error
+func (e *graphqlError) Extensions() map[string]interface{} {
+	code, ok := graphqlErrorCodes[e.status]
+	if !ok {
+		code = strings.ToUpper(strings.ReplaceAll(http.StatusText(e.status), " ", "_"))
+	}
+	return map[string]interface{}{"code": code}
+}
+
+type graphqlContextKey struct{}
+
+// graphqlRequestContext - Data per request yang dibutuhkan resolver
+type graphqlRequestContext struct {
+	r       *http.Request
+	handler *GraphQLHandler
+	loaders *graphqlLoaders
+}
+
+func graphqlLoadersFrom(ctx context.Context) *graphqlLoaders {
+	return ctx.Value(graphqlContextKey{}).(*graphqlRequestContext).loaders
+}
+
+// graphqlHandlerFrom - Handler (dan service-nya) yang menjalankan request
+func graphqlHandlerFrom(ctx context.Context) *GraphQLHandler {
+	return ctx.Value(graphqlContextKey{}).(*graphqlRequestContext).handler
+}
+
+// graphqlHTTPRequest - Request HTTP asli dari resolver
+func graphqlHTTPRequest(ctx context.Context) *http.Request {
+	return ctx.Value(graphqlContextKey{}).(*graphqlRequestContext).r
+}
+
+// graphqlOrigin - Info request untuk service (URL publik, header X-Editor-Session)
+func graphqlOrigin(ctx context.Context) service.Origin {
+	return requestOrigin(graphqlHandlerFrom(ctx).cfg, graphqlHTTPRequest(ctx))
+}
+
+func graphqlUserID(ctx context.Context) (uint, bool) {
+	return middleware.GetUserID(graphqlHTTPRequest(ctx))
+}
+
+// GraphQLHandler - Endpoint GraphQL di atas service yang sama dengan REST
+type GraphQLHandler struct {
+	cfg       *config.Config
+	users     *service.UserService
+	posts     *service.PostService
+	comments  *service.CommentService
+	reactions *service.ReactionService
+}
+
+func NewGraphQLHandler(cfg *config.Config, users *service.UserService, posts *service.PostService, comments *service.CommentService, reactions *service.ReactionService) *GraphQLHandler {
+	return &GraphQLHandler{cfg: cfg, users: users, posts: posts, comments: comments, reactions: reactions}
+}
+
+// GraphQL - Endpoint GraphQL (POST JSON: query, operationName, variables). Token opsional seperti
+// route publik lain; mutation membutuhkan token dan memakai validasi serta ownership yang sama dengan REST.
+func (h *GraphQLHandler) GraphQL(w http.ResponseWriter, r *http.Request) {
+	schema, err := graphqlSchema()
+	if err != nil {
+		logging.FromContext(r.Context()).Error("invalid GraphQL schema", "error", err)
+		respondError(w, http.StatusInternalServerError, "GraphQL is not available")
+		return
+	}
+
+	var req GraphQLRequest
+	r.Body = http.MaxBytesReader(w, r.Body, graphqlMaxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		respondError(w, http.StatusBadRequest, "query is required")
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+
+	validation := graphql.ValidateDocument(&schema, doc, nil)
+	if !validation.IsValid {
+		respondJSON(w, http.StatusBadRequest, graphql.Result{Errors: validation.Errors})
+		return
+	}
+
+	// Batas dicek sebelum eksekusi agar query berat tidak sempat menyentuh database
+	if err := checkGraphQLLimits(&schema, doc, req.Variables, h.cfg.GraphQLMaxDepth, h.cfg.GraphQLMaxComplexity); err != nil {
+		respondJSON(w, http.StatusBadRequest, graphql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    err.Error(),
+			Extensions: map[string]interface{}{"code": "QUERY_TOO_COMPLEX"},
+		}}})
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), graphqlContextKey{}, &graphqlRequestContext{
+		r:       r,
+		handler: h,
+		loaders: newGraphQLLoaders(r.Context(), h, viewerOf(r)),
+	})
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+
+	// Mutation yang kena rate limit dibalas 429 + Retry-After seperti REST
+	status := http.StatusOK
+	if limit, limited := graphqlRateLimited(result.Errors); limited {
+		setRateLimitHeaders(w, limit)
+		status = http.StatusTooManyRequests
+	}
+	respondJSON(w, status, result)
+}
+
+// graphqlLimits - Pengukur kedalaman dan complexity dokumen yang sudah lolos validasi
+// (fragment sudah dipastikan ada dan tidak siklis)
+type graphqlLimits struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// listFields - Nama field yang menerima argumen limit; complexity selection di bawahnya dikalikan limit
+	listFields map[string]bool
+}
+
+// checkGraphQLLimits - Tolak operation yang lebih dalam dari maxDepth atau complexity-nya melebihi
+// maxComplexity. Setiap field bernilai 1, selection di bawah field list dikalikan limit-nya.
+// Field introspection (__schema, __type) dihitung sama agar tidak bisa diulang lewat alias tanpa batas.
+func checkGraphQLLimits(schema *graphql.Schema, doc *ast.Document, variables map[string]interface{}, maxDepth, maxComplexity int) error {
+	limits := graphqlLimits{
+		fragments:  make(map[string]*ast.FragmentDefinition),
+		variables:  variables,
+		listFields: make(map[string]bool),
+	}
+	for _, t := range schema.TypeMap() {
+		object, ok := t.(*graphql.Object)
+		if !ok {
+			continue
+		}
+		for name, field := range object.Fields() {
+			for _, arg := range field.Args {
+				if arg.Name() == "limit" {
+					limits.listFields[name] = true
+				}
+			}
+		}
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			limits.fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			operations = append(operations, definition)
+		}
+	}
+
+	for _, operation := range operations {
+		depth, complexity := limits.measure(operation.SelectionSet, 1)
+		if depth > maxDepth {
+			return fmt.Errorf("Query depth %d exceeds the maximum of %d", depth, maxDepth)
+		}
+		if complexity > maxComplexity {
+			return fmt.Errorf("Query complexity %d exceeds the maximum of %d", complexity, maxComplexity)
+		}
+	}
+	return nil
+}
+
+// measure - Kedalaman maksimal dan complexity selection set pada level depth
+func (l *graphqlLimits) measure(set *ast.SelectionSet, depth int) (int, int) {
+	if set == nil {
+		return depth - 1, 0
+	}
+
+	maxDepth, complexity := depth-1, 0
+	for _, selection := range set.Selections {
+		var childDepth, childComplexity int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			childDepth, childComplexity = l.measure(selection.SelectionSet, depth+1)
+			if childDepth < depth {
+				childDepth = depth
+			}
+			if l.listFields[selection.Name.Value] {
+				childComplexity *= l.listLimit(selection)
+			}
+			childComplexity++
+		case *ast.InlineFragment:
+			childDepth, childComplexity = l.measure(selection.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			if fragment, ok := l.fragments[selection.Name.Value]; ok {
+				childDepth, childComplexity = l.measure(fragment.SelectionSet, depth)
+			}
+		}
+		if childDepth > maxDepth {
+			maxDepth = childDepth
+		}
+		complexity += childComplexity
+	}
+	return maxDepth, complexity
+}
+
+// listLimit - Nilai argumen limit (literal atau variable) dengan default dan batas yang sama seperti resolver
+func (l *graphqlLimits) listLimit(field *ast.Field) int {
+	limit := defaultPageLimit
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			limit, _ = strconv.Atoi(value.Value)
+		case *ast.Variable:
+			if v, ok := l.variables[value.Name.Value].(float64); ok {
+				limit = int(v)
+			}
+		}
+	}
+	if limit < 1 {
+		return 1
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+)
+
+// batchLoader - Dataloader per request: key yang didaftarkan selama satu level eksekusi GraphQL
+// dikumpulkan, lalu diambil dengan satu kali fetch saat thunk pertama dievaluasi
+type batchLoader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	results map[K]V
+	errs    map[K]error
+}
+
+func newBatchLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{
+		fetch:   fetch,
+		queued:  make(map[K]bool),
+		results: make(map[K]V),
+		errs:    make(map[K]error),
+	}
+}
+
+// Load - Daftarkan key dan kembalikan thunk untuk executor graphql-go.
+// Key yang tidak ditemukan menghasilkan nil.
+func (l *batchLoader[K, V]) Load(key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			results, err := l.fetch(keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+				} else if v, ok := results[k]; ok {
+					l.results[k] = v
+				}
+			}
+		}
+
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		if v, ok := l.results[key]; ok {
+			return v, nil
+		}
+		return nil, nil
+	}
+}
+
+// Prime - Isi hasil untuk key yang sudah dimuat di tempat lain (mis. author dari list post)
+// agar thunk berikutnya tidak memicu fetch
+func (l *batchLoader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.results[key] = value
+	}
+}
+
+// pageKey - List milik satu parent (comment per post, post per user) dengan argumen paginasinya
+type pageKey struct {
+	ParentID uint
+	Limit    int
+	After    string
+	Sort     string
+}
+
+// graphqlConnection - Satu halaman list GraphQL
+type graphqlConnection struct {
+	Nodes      interface{}
+	NextCursor *string
+}
+
+// graphqlLoaders - Semua loader untuk satu request GraphQL
+type graphqlLoaders struct {
+	users        *batchLoader[uint, *models.User]
+	posts        *batchLoader[uint, *models.Post]
+	postTags     *batchLoader[uint, []string]
+	postComments *batchLoader[pageKey, *graphqlConnection]
+	userPosts    *batchLoader[pageKey, *graphqlConnection]
+	// postReactions, commentReactions - Jumlah reaction dan reaction milik viewer per target
+	postReactions    *batchLoader[uint, service.ReactionSummary]
+	commentReactions *batchLoader[uint, service.ReactionSummary]
+}
+
+// newGraphQLLoaders - Loader yang mengambil data lewat service milik handler,
+// viewerID (0 untuk anonymous) dipakai untuk viewerReactions
+func newGraphQLLoaders(ctx context.Context, h *GraphQLHandler, viewerID uint) *graphqlLoaders {
+	reactionLoader := func(targetType string) *batchLoader[uint, service.ReactionSummary] {
+		return newBatchLoader(func(ids []uint) (map[uint]service.ReactionSummary, error) {
+			return h.reactions.Summaries(ctx, targetType, ids, viewerID)
+		})
+	}
+
+	return &graphqlLoaders{
+		postReactions:    reactionLoader(models.ReactionTargetPost),
+		commentReactions: reactionLoader(models.ReactionTargetComment),
+		users: newBatchLoader(func(ids []uint) (map[uint]*models.User, error) {
+			users, err := h.users.FindByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*models.User, len(users))
+			for i := range users {
+				result[users[i].ID] = &users[i]
+			}
+			return result, nil
+		}),
+		posts: newBatchLoader(func(ids []uint) (map[uint]*models.Post, error) {
+			posts, err := h.posts.FindByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			result := make(map[uint]*models.Post, len(posts))
+			for i := range posts {
+				result[posts[i].ID] = &posts[i]
+			}
+			return result, nil
+		}),
+		postTags: newBatchLoader(func(postIDs []uint) (map[uint][]string, error) {
+			tags, err := h.posts.Tags(ctx, postIDs)
+			if err != nil {
+				return nil, err
+			}
+			// Post tanpa tag tetap mendapat list kosong (field non-null)
+			result := make(map[uint][]string, len(tags))
+			for postID, postTags := range tags {
+				result[postID] = tagNames(models.Post{Tags: postTags})
+			}
+			return result, nil
+		}),
+		// Satu fetch per kombinasi argumen paginasi
+		postComments: newBatchLoader(func(keys []pageKey) (map[pageKey]*graphqlConnection, error) {
+			result := make(map[pageKey]*graphqlConnection, len(keys))
+			for args, postIDs := range groupPageKeys(keys) {
+				pages, err := h.comments.ListByPosts(ctx, postIDs, args.options())
+				if err != nil {
+					return nil, err
+				}
+				for _, postID := range postIDs {
+					args.ParentID = postID
+					result[args] = newConnection(pages[postID].Comments, pages[postID].NextCursor)
+				}
+			}
+			return result, nil
+		}),
+		userPosts: newBatchLoader(func(keys []pageKey) (map[pageKey]*graphqlConnection, error) {
+			result := make(map[pageKey]*graphqlConnection, len(keys))
+			for args, userIDs := range groupPageKeys(keys) {
+				pages, err := h.posts.ListByUsers(ctx, userIDs, args.options())
+				if err != nil {
+					return nil, err
+				}
+				for _, userID := range userIDs {
+					args.ParentID = userID
+					result[args] = newConnection(pages[userID].Posts, pages[userID].NextCursor)
+				}
+			}
+			return result, nil
+		}),
+	}
+}
+
+// options - Argumen paginasi untuk service
+func (k pageKey) options() service.PageOptions {
+	return service.PageOptions{Limit: k.Limit, Cursor: k.After, Sort: k.Sort}
+}
+
+// newConnection - Halaman dari service; nodes tidak pernah null, cursor kosong menjadi null
+func newConnection[T any](nodes []T, nextCursor string) *graphqlConnection {
+	if nodes == nil {
+		nodes = []T{}
+	}
+	connection := &graphqlConnection{Nodes: nodes}
+	if nextCursor != "" {
+		connection.NextCursor = &nextCursor
+	}
+	return connection
+}
+
+// groupPageKeys - Kelompokkan key berdasarkan argumen paginasi (ParentID dikosongkan)
+func groupPageKeys(keys []pageKey) map[pageKey][]uint {
+	groups := make(map[pageKey][]uint)
+	for _, key := range keys {
+		parentID := key.ParentID
+		key.ParentID = 0
+		groups[key] = append(groups[key], parentID)
+	}
+	return groups
+}
@@ -0,0 +1,603 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+)
+
+// graphqlSchema - Schema dibangun sekali saat request GraphQL pertama
+var graphqlSchema = sync.OnceValues(newGraphQLSchema)
+
+var postSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "PostSort",
+	Values: graphql.EnumValueConfigMap{
+		"NEWEST": &graphql.EnumValueConfig{Value: "newest"},
+		"TOP":    &graphql.EnumValueConfig{Value: "top", Description: "Berdasarkan jumlah reaction"},
+	},
+})
+
+var commentSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "CommentSort",
+	Values: graphql.EnumValueConfigMap{
+		"OLDEST": &graphql.EnumValueConfig{Value: "oldest"},
+		"NEWEST": &graphql.EnumValueConfig{Value: "newest"},
+		"TOP":    &graphql.EnumValueConfig{Value: "top", Description: "Berdasarkan jumlah reaction"},
+	},
+})
+
+// newGraphQLSchema - Schema users, posts, comments beserta relasinya. Relasi di-resolve
+// lewat graphqlLoaders agar list tidak memicu N+1 query.
+func newGraphQLSchema() (graphql.Schema, error) {
+	var userType, postType, commentType *graphql.Object
+
+	reactionCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "ReactionCount",
+		Fields: graphql.Fields{
+			"kind":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	reactionsType := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(reactionCountType)))
+	viewerReactionsType := graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String)))
+
+	userType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"name":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"username": &graphql.Field{Type: graphql.String},
+				"email": &graphql.Field{
+					Type:        graphql.String,
+					Description: "Hanya terlihat oleh user itu sendiri",
+					Resolve:     resolveUserEmail,
+				},
+				"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"posts": &graphql.Field{
+					Type:    graphql.NewNonNull(connectionType("PostConnection", postType)),
+					Args:    pageArgs(postSortEnum, "newest"),
+					Resolve: resolveUserPosts,
+				},
+			}
+		}),
+	})
+
+	postType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"title":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"content":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"contentHtml":  &graphql.Field{Type: graphql.String, Description: "Content yang sudah di-escape dengan link mention"},
+				"commentCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"score":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Total reactions"},
+				"createdAt":    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"author": &graphql.Field{
+					Type:    userType,
+					Resolve: resolvePostAuthor,
+				},
+				"tags": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+					Resolve: resolvePostTags,
+				},
+				"comments": &graphql.Field{
+					Type:    graphql.NewNonNull(connectionType("CommentConnection", commentType)),
+					Args:    pageArgs(commentSortEnum, "oldest"),
+					Resolve: resolvePostComments,
+				},
+				"reactions": &graphql.Field{
+					Type:        reactionsType,
+					Description: "Jumlah reaction per kind, urut kind",
+					Resolve:     resolvePostReactions,
+				},
+				"viewerReactions": &graphql.Field{
+					Type:        viewerReactionsType,
+					Description: "Reaction milik user yang login, kosong untuk anonymous",
+					Resolve:     resolvePostViewerReactions,
+				},
+			}
+		}),
+	})
+
+	commentType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Comment",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+				"content":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"contentHtml": &graphql.Field{Type: graphql.String, Description: "Content yang sudah di-escape dengan link mention"},
+				"score":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Total reactions"},
+				"createdAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"updatedAt":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+				"author": &graphql.Field{
+					Type:    userType,
+					Resolve: resolveCommentAuthor,
+				},
+				"post": &graphql.Field{
+					Type:    postType,
+					Resolve: resolveCommentPost,
+				},
+				"reactions": &graphql.Field{
+					Type:        reactionsType,
+					Description: "Jumlah reaction per kind, urut kind",
+					Resolve:     resolveCommentReactions,
+				},
+				"viewerReactions": &graphql.Field{
+					Type:        viewerReactionsType,
+					Description: "Reaction milik user yang login, kosong untuk anonymous",
+					Resolve:     resolveCommentViewerReactions,
+				},
+			}
+		}),
+	})
+
+	postInputType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "PostInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"tags": &graphql.InputObjectFieldConfig{
+				Type:        graphql.NewList(graphql.NewNonNull(graphql.String)),
+				Description: "Saat update, tidak diisi berarti tag tidak diubah",
+			},
+			"featuredImageId": &graphql.InputObjectFieldConfig{
+				Type:        graphql.ID,
+				Description: "ID media milik sendiri; saat update tidak diisi berarti tidak diubah, 0 menghapus",
+			},
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"me": &graphql.Field{
+				Type:    userType,
+				Resolve: resolveMe,
+			},
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"id":       &graphql.ArgumentConfig{Type: graphql.ID},
+					"username": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: resolveUser,
+			},
+			"post": &graphql.Field{
+				Type: postType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolvePost,
+			},
+			"posts": &graphql.Field{
+				Type:    graphql.NewNonNull(connectionType("PostConnection", postType)),
+				Args:    pageArgs(postSortEnum, "newest"),
+				Resolve: resolvePosts,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createPost": &graphql.Field{
+				Type: graphql.NewNonNull(postType),
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(postInputType)},
+				},
+				Resolve: resolveCreatePost,
+			},
+			"updatePost": &graphql.Field{
+				Type: graphql.NewNonNull(postType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(postInputType)},
+				},
+				Resolve: resolveUpdatePost,
+			},
+			"deletePost": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolveDeletePost,
+			},
+			"createComment": &graphql.Field{
+				Type: graphql.NewNonNull(commentType),
+				Args: graphql.FieldConfigArgument{
+					"postId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"content": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: resolveCreateComment,
+			},
+			"deleteComment": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: graphql.FieldConfigArgument{
+					"postId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: resolveDeleteComment,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// connectionTypes - Type connection dipakai di beberapa field, harus satu instance per nama
+var connectionTypes = map[string]*graphql.Object{}
+
+// connectionType - Halaman list dengan cursor pagination (nodes + nextCursor)
+func connectionType(name string, node *graphql.Object) *graphql.Object {
+	if connection, ok := connectionTypes[name]; ok {
+		return connection
+	}
+	connection := graphql.NewObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"nodes":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(node)))},
+			"nextCursor": &graphql.Field{Type: graphql.String, Description: "Kosong jika tidak ada halaman berikutnya"},
+		},
+	})
+	connectionTypes[name] = connection
+	return connection
+}
+
+// pageArgs - Argumen paginasi yang sama dengan REST (limit, cursor) ditambah sort
+func pageArgs(sort *graphql.Enum, defaultSort string) graphql.FieldConfigArgument {
+	return graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageLimit},
+		"after": &graphql.ArgumentConfig{Type: graphql.String, Description: "nextCursor dari halaman sebelumnya"},
+		"sort":  &graphql.ArgumentConfig{Type: sort, DefaultValue: defaultSort},
+	}
+}
+
+// graphqlPageKey - Validasi argumen paginasi dengan aturan yang sama seperti parseLimit dan service.DecodeCursor
+func graphqlPageKey(p graphql.ResolveParams, parentID uint) (pageKey, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 {
+		return pageKey{}, newGraphQLError(http.StatusBadRequest, "Invalid limit")
+	}
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+
+	after, _ := p.Args["after"].(string)
+	if _, err := service.DecodeCursor(after); err != nil {
+		return pageKey{}, newGraphQLError(http.StatusBadRequest, "Invalid cursor")
+	}
+
+	sort, _ := p.Args["sort"].(string)
+	return pageKey{ParentID: parentID, Limit: limit, After: after, Sort: sort}, nil
+}
+
+// graphqlID - Parse argumen ID, kosong berarti argumen tidak diisi
+func graphqlID(p graphql.ResolveParams, name, errMsg string) (uint, bool, error) {
+	raw, ok := p.Args[name].(string)
+	if !ok || raw == "" {
+		return 0, false, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil {
+		return 0, false, newGraphQLError(http.StatusBadRequest, errMsg)
+	}
+	return uint(id), true, nil
+}
+
+// viewerID - User yang login, error UNAUTHENTICATED jika request tanpa token
+func viewerID(p graphql.ResolveParams) (uint, error) {
+	userID, ok := graphqlUserID(p.Context)
+	if !ok {
+		return 0, newGraphQLError(http.StatusUnauthorized, "Unauthorized")
+	}
+	return userID, nil
+}
+
+func sourceUser(p graphql.ResolveParams) *models.User {
+	switch source := p.Source.(type) {
+	case *models.User:
+		return source
+	case models.User:
+		return &source
+	}
+	return nil
+}
+
+func sourcePost(p graphql.ResolveParams) *models.Post {
+	switch source := p.Source.(type) {
+	case *models.Post:
+		return source
+	case models.Post:
+		return &source
+	}
+	return nil
+}
+
+func sourceComment(p graphql.ResolveParams) *models.Comment {
+	switch source := p.Source.(type) {
+	case *models.Comment:
+		return source
+	case models.Comment:
+		return &source
+	}
+	return nil
+}
+
+func resolveUserEmail(p graphql.ResolveParams) (interface{}, error) {
+	user := sourceUser(p)
+	if userID, ok := graphqlUserID(p.Context); ok && user != nil && user.ID == userID {
+		return user.Email, nil
+	}
+	return nil, nil
+}
+
+func resolveUserPosts(p graphql.ResolveParams) (interface{}, error) {
+	key, err := graphqlPageKey(p, sourceUser(p).ID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlLoadersFrom(p.Context).userPosts.Load(key), nil
+}
+
+func resolvePostAuthor(p graphql.ResolveParams) (interface{}, error) {
+	return graphqlLoadersFrom(p.Context).users.Load(sourcePost(p).UserID), nil
+}
+
+func resolvePostTags(p graphql.ResolveParams) (interface{}, error) {
+	// Tags nil berarti belum dimuat bersama post
+	if post := sourcePost(p); post.Tags != nil {
+		return tagNames(*post), nil
+	}
+	return graphqlLoadersFrom(p.Context).postTags.Load(sourcePost(p).ID), nil
+}
+
+func resolvePostComments(p graphql.ResolveParams) (interface{}, error) {
+	key, err := graphqlPageKey(p, sourcePost(p).ID)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlLoadersFrom(p.Context).postComments.Load(key), nil
+}
+
+// reactionCount - Satu item field reactions
+type reactionCount struct {
+	Kind  string `json:"kind"`
+	Count int64  `json:"count"`
+}
+
+// reactionField - Thunk yang mengambil ReactionSummary dari loader lalu memilih bagian yang diminta
+func reactionField(load func() (interface{}, error), pick func(service.ReactionSummary) interface{}) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		summary, err := load()
+		if err != nil {
+			return nil, err
+		}
+		s, _ := summary.(service.ReactionSummary)
+		return pick(s), nil
+	}
+}
+
+func reactionCounts(summary service.ReactionSummary) interface{} {
+	counts := []reactionCount{}
+	for kind, count := range summary.Reactions {
+		counts = append(counts, reactionCount{Kind: kind, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].Kind < counts[j].Kind })
+	return counts
+}
+
+func viewerReactions(summary service.ReactionSummary) interface{} {
+	if summary.ViewerReactions == nil {
+		return []string{}
+	}
+	return summary.ViewerReactions
+}
+
+func resolvePostReactions(p graphql.ResolveParams) (interface{}, error) {
+	return reactionField(graphqlLoadersFrom(p.Context).postReactions.Load(sourcePost(p).ID), reactionCounts), nil
+}
+
+func resolvePostViewerReactions(p graphql.ResolveParams) (interface{}, error) {
+	return reactionField(graphqlLoadersFrom(p.Context).postReactions.Load(sourcePost(p).ID), viewerReactions), nil
+}
+
+func resolveCommentReactions(p graphql.ResolveParams) (interface{}, error) {
+	return reactionField(graphqlLoadersFrom(p.Context).commentReactions.Load(sourceComment(p).ID), reactionCounts), nil
+}
+
+func resolveCommentViewerReactions(p graphql.ResolveParams) (interface{}, error) {
+	return reactionField(graphqlLoadersFrom(p.Context).commentReactions.Load(sourceComment(p).ID), viewerReactions), nil
+}
+
+func resolveCommentAuthor(p graphql.ResolveParams) (interface{}, error) {
+	return graphqlLoadersFrom(p.Context).users.Load(sourceComment(p).UserID), nil
+}
+
+func resolveCommentPost(p graphql.ResolveParams) (interface{}, error) {
+	return graphqlLoadersFrom(p.Context).posts.Load(sourceComment(p).PostID), nil
+}
+
+func resolveMe(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlLoadersFrom(p.Context).users.Load(userID), nil
+}
+
+func resolveUser(p graphql.ResolveParams) (interface{}, error) {
+	userID, ok, err := graphqlID(p, "id", "Invalid user ID")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return graphqlLoadersFrom(p.Context).users.Load(userID), nil
+	}
+
+	username, _ := p.Args["username"].(string)
+	if username == "" {
+		return nil, newGraphQLError(http.StatusBadRequest, "id or username is required")
+	}
+	user, err := graphqlHandlerFrom(p.Context).users.FindByUsername(p.Context, username)
+	if service.ErrorCode(err) == service.CodeNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return &user, nil
+}
+
+func resolvePost(p graphql.ResolveParams) (interface{}, error) {
+	postID, _, err := graphqlID(p, "id", "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+	return graphqlLoadersFrom(p.Context).posts.Load(postID), nil
+}
+
+func resolvePosts(p graphql.ResolveParams) (interface{}, error) {
+	key, err := graphqlPageKey(p, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, nextCursor, err := graphqlHandlerFrom(p.Context).posts.List(p.Context, key.options())
+	if err != nil {
+		return nil, graphqlServiceError(err)
+	}
+
+	// Author sudah dimuat bersama post, relasi author berikutnya memakai hasil yang sama
+	loaders := graphqlLoadersFrom(p.Context)
+	for i := range posts {
+		loaders.users.Prime(posts[i].UserID, &posts[i].User)
+	}
+	return newConnection(posts, nextCursor), nil
+}
+
+// postInput - Ubah argumen PostInput menjadi service.PostInput yang sama dengan REST
+func postInput(p graphql.ResolveParams) (service.PostInput, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	req := service.PostInput{}
+	req.Title, _ = input["title"].(string)
+	req.Content, _ = input["content"].(string)
+
+	if tags, ok := input["tags"].([]interface{}); ok {
+		req.Tags = []string{}
+		for _, tag := range tags {
+			name, _ := tag.(string)
+			req.Tags = append(req.Tags, name)
+		}
+	}
+
+	if raw, ok := input["featuredImageId"].(string); ok {
+		mediaID, err := strconv.ParseUint(raw, 10, 32)
+		if err != nil {
+			return req, newGraphQLError(http.StatusBadRequest, "Invalid featured image ID")
+		}
+		featuredImageID := uint(mediaID)
+		req.FeaturedImageID = &featuredImageID
+	}
+	return req, nil
+}
+
+func resolveCreatePost(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	req, err := postInput(p)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := graphqlHandlerFrom(p.Context).posts.Create(p.Context, graphqlOrigin(p.Context), userID, req)
+	if err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return &post, nil
+}
+
+func resolveUpdatePost(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	postID, _, err := graphqlID(p, "id", "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+	req, err := postInput(p)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := graphqlHandlerFrom(p.Context).posts.Update(p.Context, graphqlOrigin(p.Context), userID, postID, req)
+	if err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return &post, nil
+}
+
+func resolveDeletePost(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	postID, _, err := graphqlID(p, "id", "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := graphqlHandlerFrom(p.Context).posts.Delete(p.Context, graphqlOrigin(p.Context), userID, postID); err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return true, nil
+}
+
+func resolveCreateComment(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	postID, _, err := graphqlID(p, "postId", "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+	content, _ := p.Args["content"].(string)
+
+	comment, _, err := graphqlHandlerFrom(p.Context).comments.Create(p.Context, graphqlOrigin(p.Context), userID, postID, content)
+	if err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return &comment, nil
+}
+
+func resolveDeleteComment(p graphql.ResolveParams) (interface{}, error) {
+	userID, err := viewerID(p)
+	if err != nil {
+		return nil, err
+	}
+	postID, _, err := graphqlID(p, "postId", "Invalid post ID")
+	if err != nil {
+		return nil, err
+	}
+	commentID, _, err := graphqlID(p, "id", "Invalid comment ID")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := graphqlHandlerFrom(p.Context).comments.Delete(p.Context, graphqlOrigin(p.Context), userID, postID, commentID); err != nil {
+		return nil, graphqlServiceError(err)
+	}
+	return true, nil
+}
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type graphqlTestResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+// doGraphQL - Helper untuk menjalankan request GraphQL sebagai user (0 = anonymous)
+func doGraphQL(t *testing.T, h *testHandlers, userID uint, query string, variables map[string]interface{}) (int, graphqlTestResponse) {
+	req := newTestRequest("POST", "/api/graphql", GraphQLRequest{Query: query, Variables: variables}, nil, userID)
+	rr := httptest.NewRecorder()
+	h.graphql.GraphQL(rr, req)
+
+	var response graphqlTestResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Invalid response %q: %v", rr.Body.String(), err)
+	}
+	return rr.Code, response
+}
+
+func TestGraphQLPostsBatchesRelations(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	for i := 0; i < 3; i++ {
+		author := createTestUser(t, h.db, fmt.Sprintf("author%d@example.com", i))
+		for j := 0; j < 2; j++ {
+			post := createTestPost(t, h.db, author.ID)
+			for k := 0; k < 3; k++ {
+				h.db.Create(&models.Comment{Content: "Nice", UserID: author.ID, PostID: post.ID})
+			}
+		}
+	}
+
+	queries := 0
+	h.db.Callback().Query().After("gorm:query").Register("test:count", func(*gorm.DB) { queries++ })
+
+	status, response := doGraphQL(t, h, 0, `{
+		posts(limit: 10) {
+			nodes {
+				title
+				author { name }
+				tags
+				comments(limit: 2) { nodes { content author { username } } nextCursor }
+			}
+			nextCursor
+		}
+	}`, nil)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Expected success, got %d %+v", status, response.Errors)
+	}
+
+	var posts struct {
+		Nodes []struct {
+			Author   struct{ Name string }
+			Comments struct {
+				Nodes      []struct{ Author struct{ Username string } }
+				NextCursor *string
+			}
+		}
+		NextCursor *string
+	}
+	json.Unmarshal(response.Data["posts"], &posts)
+	if len(posts.Nodes) != 6 || posts.NextCursor != nil {
+		t.Fatalf("Expected 6 posts on one page, got %d (cursor %v)", len(posts.Nodes), posts.NextCursor)
+	}
+	for _, post := range posts.Nodes {
+		if post.Author.Name == "" || len(post.Comments.Nodes) != 2 || post.Comments.NextCursor == nil {
+			t.Fatalf("Expected author and 2 of 3 comments, got %+v", post)
+		}
+		if post.Comments.Nodes[0].Author.Username == "" {
+			t.Errorf("Expected comment author to be resolved")
+		}
+	}
+
+	// Satu query per relasi (ditambah lookup mention), tidak bertambah per post
+	if queries > 6 {
+		t.Errorf("Expected relations to be batched, got %d queries", queries)
+	}
+}
+
+func TestGraphQLMutationsReuseRESTRules(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	owner := createTestUser(t, h.db, "owner@example.com")
+	other := createTestUser(t, h.db, "other@example.com")
+
+	createPostMutation := `mutation($title: String!) {
+		createPost(input: {title: $title, content: "Content from GraphQL", tags: ["Go"]}) { id title tags author { email } }
+	}`
+
+	tests := []struct {
+		name    string
+		userID  uint
+		title   string
+		code    string
+		message string
+	}{
+		{name: "Anonymous", userID: 0, title: "GraphQL post", code: "UNAUTHENTICATED", message: "Unauthorized"},
+		{name: "Short title", userID: owner.ID, title: "Go", code: "BAD_USER_INPUT", message: "Title must be between 3 and 200 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, response := doGraphQL(t, h, tt.userID, createPostMutation, map[string]interface{}{"title": tt.title})
+			if len(response.Errors) != 1 || response.Errors[0].Message != tt.message || response.Errors[0].Extensions["code"] != tt.code {
+				t.Fatalf("Expected %s %q, got %+v", tt.code, tt.message, response.Errors)
+			}
+		})
+	}
+
+	_, response := doGraphQL(t, h, owner.ID, createPostMutation, map[string]interface{}{"title": "GraphQL post"})
+	if len(response.Errors) > 0 {
+		t.Fatalf("Expected post to be created, got %+v", response.Errors)
+	}
+	var created struct {
+		ID     string
+		Tags   []string
+		Author struct{ Email *string }
+	}
+	json.Unmarshal(response.Data["createPost"], &created)
+	if len(created.Tags) != 1 || created.Tags[0] != "go" || created.Author.Email == nil {
+		t.Fatalf("Expected normalized tag and own email, got %+v", created)
+	}
+
+	_, response = doGraphQL(t, h, other.ID, `mutation($id: ID!) { deletePost(id: $id) }`, map[string]interface{}{"id": created.ID})
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "FORBIDDEN" {
+		t.Fatalf("Expected FORBIDDEN for another user's post, got %+v", response.Errors)
+	}
+
+	_, response = doGraphQL(t, h, owner.ID, `mutation($id: ID!) { deletePost(id: $id) }`, map[string]interface{}{"id": created.ID})
+	if len(response.Errors) > 0 || string(response.Data["deletePost"]) != "true" {
+		t.Fatalf("Expected owner to delete post, got %+v", response.Errors)
+	}
+}
+
+func TestGraphQLReactions(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	reader := createTestUser(t, h.db, "reader@example.com")
+	post := createTestPost(t, h.db, author.ID)
+	comment := models.Comment{Content: "Nice", UserID: author.ID, PostID: post.ID}
+	h.db.Create(&comment)
+
+	w := httptest.NewRecorder()
+	h.reactions.AddPostReaction(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(post.ID), "kind": "like"}, reader.ID))
+	w = httptest.NewRecorder()
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID), "comment_id": fmt.Sprint(comment.ID), "kind": "love"}
+	h.reactions.AddCommentReaction(w, newTestRequest("PUT", "/", nil, vars, author.ID))
+
+	query := `query($id: ID!) {
+		post(id: $id) {
+			reactions { kind count }
+			viewerReactions
+			comments { nodes { reactions { kind count } viewerReactions } }
+		}
+	}`
+	type reactions struct {
+		Reactions []struct {
+			Kind  string
+			Count int
+		}
+		ViewerReactions []string
+	}
+	type postReactions struct {
+		reactions
+		Comments struct{ Nodes []reactions }
+	}
+
+	var got postReactions
+	status, response := doGraphQL(t, h, reader.ID, query, map[string]interface{}{"id": fmt.Sprint(post.ID)})
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Expected success, got %d %+v", status, response.Errors)
+	}
+	json.Unmarshal(response.Data["post"], &got)
+	if len(got.Reactions) != 1 || got.Reactions[0].Kind != "like" || got.Reactions[0].Count != 1 ||
+		len(got.ViewerReactions) != 1 || got.ViewerReactions[0] != "like" {
+		t.Fatalf("Expected post reactions with viewer state, got %+v", got.reactions)
+	}
+	if len(got.Comments.Nodes) != 1 || len(got.Comments.Nodes[0].Reactions) != 1 || len(got.Comments.Nodes[0].ViewerReactions) != 0 {
+		t.Fatalf("Expected comment reactions without viewer state, got %+v", got.Comments.Nodes)
+	}
+
+	// Anonymous tetap melihat jumlah, viewerReactions kosong
+	_, response = doGraphQL(t, h, 0, query, map[string]interface{}{"id": fmt.Sprint(post.ID)})
+	got = postReactions{}
+	json.Unmarshal(response.Data["post"], &got)
+	if len(got.Reactions) != 1 || got.ViewerReactions == nil || len(got.ViewerReactions) != 0 {
+		t.Fatalf("Expected anonymous reactions without viewer state, got %+v", got.reactions)
+	}
+}
+
+func TestGraphQLCreateCommentRateLimited(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t, func(cfg *config.Config) {
+		cfg.RateLimitPolicies["comment"] = config.RateLimitPolicy{Requests: 1, Period: time.Minute, KeyBy: "user"}
+	})
+
+	user := createTestUser(t, h.db, "commenter@example.com")
+	post := createTestPost(t, h.db, user.ID)
+	createCommentMutation := `mutation($postId: ID!) { createComment(postId: $postId, content: "Nice post") { id } }`
+	variables := map[string]interface{}{"postId": fmt.Sprint(post.ID)}
+
+	if status, response := doGraphQL(t, h, user.ID, createCommentMutation, variables); status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Expected first comment to be created, got %d %+v", status, response.Errors)
+	}
+
+	req := newTestRequest("POST", "/api/graphql", GraphQLRequest{Query: createCommentMutation, Variables: variables}, nil, user.ID)
+	rr := httptest.NewRecorder()
+	h.graphql.GraphQL(rr, req)
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") == "" {
+		t.Fatalf("Expected 429 with Retry-After, got %d %q", rr.Code, rr.Header().Get("Retry-After"))
+	}
+	var response graphqlTestResponse
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	if len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "TOO_MANY_REQUESTS" {
+		t.Fatalf("Expected TOO_MANY_REQUESTS, got %+v", response.Errors)
+	}
+
+	// Bucket yang sama dipakai REST
+	w := httptest.NewRecorder()
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Nice post"}, vars, user.ID))
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected REST comment to share the limit, got %d", w.Code)
+	}
+}
+
+func TestGraphQLLimits(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  int
+	}{
+		{name: "Within limits", query: `{ posts { nodes { author { posts(limit: 5) { nodes { title } } } } } }`, want: http.StatusOK},
+		{name: "Too deep", query: `{ posts { nodes { comments { nodes { post { author { posts { nodes { comments { nodes { id } } } } } } } } } } }`, want: http.StatusBadRequest},
+		{name: "Too complex", query: `{ posts(limit: 100) { nodes { comments(limit: 100) { nodes { id } } } } }`, want: http.StatusBadRequest},
+		{name: "Introspection within limits", query: `{ __schema { types { name fields { name type { name ofType { name ofType { name ofType { name } } } } } } } }`, want: http.StatusOK},
+		{name: "Introspection too deep", query: `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }`, want: http.StatusBadRequest},
+		{name: "Aliased introspection too complex", query: "{ " + aliasedIntrospection(400) + "}", want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := doGraphQL(t, h, 0, tt.query, nil)
+			if status != tt.want {
+				t.Fatalf("Expected status %d, got %d %+v", tt.want, status, response.Errors)
+			}
+			if tt.want == http.StatusBadRequest && (len(response.Errors) != 1 || response.Errors[0].Extensions["code"] != "QUERY_TOO_COMPLEX") {
+				t.Errorf("Expected QUERY_TOO_COMPLEX, got %+v", response.Errors)
+			}
+		})
+	}
+}
+
+// aliasedIntrospection - Banyak alias __type dalam satu query
+func aliasedIntrospection(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "t%d: __type(name: \"Post\") { fields { name } } ", i)
+	}
+	return b.String()
+}
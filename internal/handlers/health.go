@@ -1,14 +1,209 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"blog-api/internal/database"
+	"blog-api/internal/logging"
+	"blog-api/internal/storage"
+
+	"gorm.io/gorm"
 )
 
+// shuttingDown - Di-set saat shutdown dimulai agar load balancer berhenti mengirim request baru
+var shuttingDown atomic.Bool
+
+// SetShuttingDown - Tandai server sedang shutdown, health check menjawab 503 sejak saat ini
+func SetShuttingDown() {
+	shuttingDown.Store(true)
+}
+
 func HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+	if shuttingDown.Load() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{
+			"status":  "shutting_down",
+			"message": "Blog API is shutting down",
+		})
+		return
+	}
+
 	json.NewEncoder(w).Encode(map[string]string{
 		"status":  "ok",
 		"message": "Blog API is running",
 	})
 }
+
+// Livez - Liveness probe: proses masih berjalan, tidak memeriksa dependency
+// (dependency yang mati tidak boleh membuat orchestrator me-restart server)
+func Livez(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ReadinessCheck - Satu dependency yang diperiksa oleh /readyz
+type ReadinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// CheckResult - Hasil satu pengecekan readiness: status ok, timeout atau error. Detail error
+// (hostname, pesan driver) hanya ditulis ke log karena /readyz bisa diakses tanpa login.
+type CheckResult struct {
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// ReadinessReport - Response /readyz
+type ReadinessReport struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+// ReadinessHandler - Readiness probe: jalankan semua check secara paralel dengan timeout,
+// hasilnya di-cache selama ttl (request yang datang bersamaan menunggu satu putaran check)
+type ReadinessHandler struct {
+	checks  []ReadinessCheck
+	timeout time.Duration
+	ttl     time.Duration
+
+	mu       sync.Mutex
+	report   ReadinessReport
+	cachedAt time.Time
+}
+
+// NewReadinessHandler - Buat ReadinessHandler
+func NewReadinessHandler(timeout, ttl time.Duration, checks ...ReadinessCheck) *ReadinessHandler {
+	return &ReadinessHandler{checks: checks, timeout: timeout, ttl: ttl}
+}
+
+// DefaultReadinessChecks - Database, migrasi pending dan storage media
+func DefaultReadinessChecks(db *gorm.DB, files storage.Storage) []ReadinessCheck {
+	return []ReadinessCheck{
+		{Name: "database", Check: DatabaseCheck(db)},
+		{Name: "migrations", Check: MigrationsCheck(db)},
+		{Name: "storage", Check: StorageCheck(files)},
+	}
+}
+
+// StorageCheck - Periksa storage media (no-op untuk storage yang tidak mendukung Checker)
+func StorageCheck(files storage.Storage) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return storage.Check(ctx, files)
+	}
+}
+
+// DatabaseCheck - Ping connection pool database
+func DatabaseCheck(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// MigrationsCheck - Gagal jika masih ada migrasi yang belum diterapkan
+// (misal DB_AUTO_MIGRATE=false dan "blog-api migrate up" belum dijalankan)
+func MigrationsCheck(db *gorm.DB) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			return err
+		}
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if pending > 0 {
+			return fmt.Errorf("%d pending migrations", pending)
+		}
+		return nil
+	}
+}
+
+// Readyz - 200 jika semua dependency siap, 503 jika ada yang gagal atau server sedang shutdown
+func (h *ReadinessHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	if shuttingDown.Load() {
+		respondJSON(w, http.StatusServiceUnavailable, ReadinessReport{
+			Status:    "shutting_down",
+			Checks:    map[string]CheckResult{},
+			CheckedAt: time.Now().UTC(),
+		})
+		return
+	}
+
+	// Client probe yang memutus koneksi tidak boleh membuat hasil "context canceled" ikut di-cache
+	report := h.Report(context.WithoutCancel(r.Context()))
+	status := http.StatusOK
+	if report.Status != "ok" {
+		status = http.StatusServiceUnavailable
+	}
+	respondJSON(w, status, report)
+}
+
+// Report - Hasil check terakhir jika belum lebih tua dari ttl, selain itu jalankan ulang
+func (h *ReadinessHandler) Report(ctx context.Context) ReadinessReport {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.cachedAt.IsZero() && time.Since(h.cachedAt) < h.ttl {
+		return h.report
+	}
+
+	results := make([]CheckResult, len(h.checks))
+	var wg sync.WaitGroup
+	for i, check := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = h.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := ReadinessReport{
+		Status:    "ok",
+		Checks:    make(map[string]CheckResult, len(h.checks)),
+		CheckedAt: time.Now().UTC(),
+	}
+	for i, check := range h.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != "ok" {
+			report.Status = "unavailable"
+		}
+	}
+
+	h.report = report
+	h.cachedAt = time.Now()
+	return report
+}
+
+func (h *ReadinessHandler) run(ctx context.Context, check ReadinessCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Check(ctx)
+	result := CheckResult{
+		Status:    "ok",
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = "error"
+		if errors.Is(err, context.DeadlineExceeded) {
+			result.Status = "timeout"
+		}
+		logging.FromContext(ctx).Warn("readiness check failed", "check", check.Name, "error", err)
+	}
+	return result
+}
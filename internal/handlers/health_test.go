@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func readyz(t *testing.T, h *ReadinessHandler) (int, ReadinessReport) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	h.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report ReadinessReport
+	if err := json.NewDecoder(rr.Body).Decode(&report); err != nil {
+		t.Fatalf("Invalid readiness response: %v", err)
+	}
+	return rr.Code, report
+}
+
+func TestReadyzDefaultChecks(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	code, report := readyz(t, NewReadinessHandler(time.Second, 0, DefaultReadinessChecks(h.db, h.files)...))
+	if code != http.StatusOK || report.Status != "ok" || len(report.Checks) != 3 {
+		t.Fatalf("Expected all checks to pass, got %d %+v", code, report)
+	}
+
+	// Database tanpa migrasi: migrations gagal, database tetap ok
+	fresh, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, _ := fresh.DB()
+	sqlDB.SetMaxOpenConns(1)
+	code, report = readyz(t, NewReadinessHandler(time.Second, 0, DefaultReadinessChecks(fresh, h.files)...))
+	if code != http.StatusServiceUnavailable || report.Checks["database"].Status != "ok" {
+		t.Fatalf("Expected 503 with healthy database, got %d %+v", code, report)
+	}
+	if migrations := report.Checks["migrations"]; migrations.Status != "error" {
+		t.Errorf("Expected pending migrations error, got %+v", migrations)
+	}
+	// Probe hanya membaca, tidak membuat schema_migrations
+	if fresh.Migrator().HasTable("schema_migrations") {
+		t.Error("Expected readiness check not to create schema_migrations")
+	}
+}
+
+func TestReadyzTimeoutAndCache(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	h := NewReadinessHandler(50*time.Millisecond, time.Minute,
+		ReadinessCheck{Name: "slow", Check: func(ctx context.Context) error {
+			calls++
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		ReadinessCheck{Name: "fast", Check: func(ctx context.Context) error { return nil }},
+	)
+
+	code, report := readyz(t, h)
+	if code != http.StatusServiceUnavailable || report.Status != "unavailable" {
+		t.Fatalf("Expected 503, got %d %+v", code, report)
+	}
+	if slow := report.Checks["slow"]; slow.Status != "timeout" {
+		t.Errorf("Expected slow check to time out, got %+v", slow)
+	}
+	if report.Checks["fast"].Status != "ok" {
+		t.Errorf("Expected fast check to pass, got %+v", report.Checks["fast"])
+	}
+
+	readyz(t, h)
+	if calls != 1 {
+		t.Errorf("Expected cached result on second probe, got %d calls", calls)
+	}
+}
+
+func TestLivez(t *testing.T) {
+	t.Parallel()
+	rr := httptest.NewRecorder()
+	Livez(rr, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", rr.Code)
+	}
+
+	// Check yang gagal tidak mempengaruhi liveness
+	h := NewReadinessHandler(time.Second, 0, ReadinessCheck{Name: "db", Check: func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}})
+	rr = httptest.NewRecorder()
+	h.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rr.Code != http.StatusServiceUnavailable || !strings.Contains(rr.Body.String(), `"db":{"status":"error"`) {
+		t.Errorf("Expected failing readiness, got %d %s", rr.Code, rr.Body.String())
+	}
+	// Detail error hanya di log, tidak dikirim ke client anonim
+	if strings.Contains(rr.Body.String(), "connection refused") {
+		t.Errorf("Expected error detail to stay out of the response, got %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"blog-api/internal/config"
+	"blog-api/internal/imaging"
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+	"blog-api/internal/storage"
+
+	"github.com/gorilla/mux"
+
+	// Decoder format gambar untuk image.DecodeConfig
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// multipartMemory - Bagian form multipart yang disimpan di memory, sisanya ke file sementara
+	multipartMemory = 8 << 20
+	// multipartOverhead - Toleransi ukuran body di luar file (boundary dan field lain)
+	multipartOverhead = 64 << 10
+)
+
+// allowedMediaTypes - Content type hasil sniffing yang boleh di-upload beserta ekstensi file
+var allowedMediaTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// MediaHandler - Upload, list dan hapus media beserta file-nya di storage
+type MediaHandler struct {
+	cfg   *config.Config
+	media *service.MediaService
+	files storage.Storage
+}
+
+func NewMediaHandler(cfg *config.Config, media *service.MediaService, files storage.Storage) *MediaHandler {
+	return &MediaHandler{cfg: cfg, media: media, files: files}
+}
+
+// UploadMedia - Upload gambar (multipart field "file"), content type ditentukan dari isi file
+func (h *MediaHandler) UploadMedia(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	cfg := h.cfg
+	r.Body = http.MaxBytesReader(w, r.Body, cfg.MediaMaxBytes+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		HandleValidationError(w, "file is required")
+		return
+	}
+	defer file.Close()
+
+	if header.Size > cfg.MediaMaxBytes {
+		respondError(w, http.StatusRequestEntityTooLarge, "File too large")
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Failed to read file")
+		return
+	}
+
+	// Content-Type dari client tidak dipercaya, sniff dari isi file
+	contentType := http.DetectContentType(data)
+	ext, allowed := allowedMediaTypes[contentType]
+	if !allowed {
+		respondError(w, http.StatusUnsupportedMediaType, "Unsupported media type, allowed: jpeg, png, gif, webp")
+		return
+	}
+
+	// File asli disimpan tanpa EXIF (lokasi GPS, info kamera) dan metadata lain
+	data, err = imaging.StripMetadata(data, contentType)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid image file")
+		return
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid image file")
+		return
+	}
+	if imaging.ExceedsPixels(imageConfig.Width, imageConfig.Height, cfg.MediaMaxPixels) {
+		respondError(w, http.StatusBadRequest, "Image dimensions too large")
+		return
+	}
+
+	// Dimensi sesuai tampilan (orientation 5-8 berarti gambar diputar 90 derajat)
+	width, height := imageConfig.Width, imageConfig.Height
+	if contentType == "image/jpeg" && imaging.JPEGOrientation(data) >= 5 {
+		width, height = height, width
+	}
+
+	media, err := h.media.Upload(r.Context(), userID, service.MediaUpload{
+		Filename:    header.Filename,
+		ContentType: contentType,
+		Extension:   ext,
+		Data:        data,
+		Width:       width,
+		Height:      height,
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	prepareMedia(h.files, &media)
+	respondJSON(w, http.StatusCreated, media)
+}
+
+// GetMedia - Ambil metadata media by ID
+func (h *MediaHandler) GetMedia(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	mediaID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	media, err := h.media.Get(r.Context(), uint(mediaID))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	prepareMedia(h.files, &media)
+	respondJSON(w, http.StatusOK, media)
+}
+
+// GetMyMedia - Media milik user yang login (terbaru dulu, cursor pagination)
+func (h *MediaHandler) GetMyMedia(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	media, nextCursor, err := h.media.List(r.Context(), userID, service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	for i := range media {
+		prepareMedia(h.files, &media[i])
+	}
+
+	respondJSON(w, http.StatusOK, PaginatedResponse{Data: media, NextCursor: nextCursor})
+}
+
+// DeleteMedia - Hapus media milik sendiri (record dan file di storage)
+func (h *MediaHandler) DeleteMedia(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	mediaID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid media ID")
+		return
+	}
+
+	if err := h.media.Delete(r.Context(), userID, uint(mediaID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Media deleted successfully"})
+}
+
+// ServeMediaFile - Serve file dari storage local dengan dukungan Range request dan cache header
+// (untuk storage S3 file diakses langsung lewat URL bucket/CDN)
+func (h *MediaHandler) ServeMediaFile(w http.ResponseWriter, r *http.Request) {
+	local, ok := h.files.(*storage.LocalStorage)
+	if !ok {
+		respondError(w, http.StatusNotFound, "Media not found")
+		return
+	}
+
+	// Key bisa milik file asli atau salah satu variant
+	key := mux.Vars(r)["key"]
+	file, err := h.media.File(r.Context(), key)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	path, err := local.Path(key)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Media not found")
+		return
+	}
+
+	content, err := os.Open(path)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Media not found")
+		return
+	}
+	defer content.Close()
+
+	stat, err := content.Stat()
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to read media")
+		return
+	}
+
+	// Key berisi token acak dan tidak pernah ditimpa, jadi aman di-cache selamanya
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("ETag", file.ETag)
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", stat.ModTime(), content)
+}
+
+// prepareMedia - Isi URL media dan variant serta srcset per format (urut dari yang terkecil)
+func prepareMedia(files storage.Storage, media *models.Media) {
+	media.URL = files.URL(media.Key)
+
+	sort.Slice(media.Variants, func(i, j int) bool {
+		if media.Variants[i].Format != media.Variants[j].Format {
+			return media.Variants[i].Format < media.Variants[j].Format
+		}
+		return media.Variants[i].Width < media.Variants[j].Width
+	})
+
+	srcSet := make(map[string][]string)
+	for i := range media.Variants {
+		variant := &media.Variants[i]
+		variant.URL = files.URL(variant.Key)
+		srcSet[variant.Format] = append(srcSet[variant.Format], fmt.Sprintf("%s %dw", variant.URL, variant.Width))
+	}
+
+	if len(srcSet) > 0 {
+		media.SrcSet = make(map[string]string, len(srcSet))
+		for format, candidates := range srcSet {
+			media.SrcSet[format] = strings.Join(candidates, ", ")
+		}
+	}
+}
+
+// prepareFeaturedImages - Isi URL dan srcset featured image pada list post
+func prepareFeaturedImages(files storage.Storage, posts []models.Post) {
+	for i := range posts {
+		if posts[i].FeaturedImage != nil {
+			prepareMedia(files, posts[i].FeaturedImage)
+		}
+	}
+}
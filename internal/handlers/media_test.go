@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blog-api/internal/models"
+)
+
+// newUploadRequest - Request multipart dengan field "file"
+func newUploadRequest(t *testing.T, filename string, content []byte, userID uint) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := newTestRequest("POST", "/api/media", nil, nil, userID)
+	req.Body = io.NopCloser(&body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func testPNG(t *testing.T, width, height int) []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadMedia(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	user := createTestUser(t, h.db, "uploader@example.com")
+
+	w := httptest.NewRecorder()
+	// Nama dan ekstensi dari client diabaikan, content type ditentukan dari isi file
+	h.media.UploadMedia(w, newUploadRequest(t, "photo.txt", testPNG(t, 40, 30), user.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var media models.Media
+	json.NewDecoder(w.Body).Decode(&media)
+	if media.ContentType != "image/png" || media.Width != 40 || media.Height != 30 {
+		t.Errorf("Unexpected media: %+v", media)
+	}
+	if media.URL != "/media/"+media.Key {
+		t.Errorf("Unexpected URL %s", media.URL)
+	}
+
+	t.Run("Serve with range", func(t *testing.T) {
+		req := newTestRequest("GET", media.URL, nil, map[string]string{"key": media.Key}, 0)
+		req.Header.Set("Range", "bytes=0-7")
+		w := httptest.NewRecorder()
+		h.media.ServeMediaFile(w, req)
+
+		if w.Code != http.StatusPartialContent || w.Body.Len() != 8 {
+			t.Errorf("Expected 8 bytes partial content, got %d with %d bytes", w.Code, w.Body.Len())
+		}
+		if w.Header().Get("Cache-Control") == "" || w.Header().Get("Content-Type") != "image/png" {
+			t.Errorf("Unexpected headers: %v", w.Header())
+		}
+	})
+
+	t.Run("Unsupported type", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.media.UploadMedia(w, newUploadRequest(t, "fake.png", []byte("<html><script>alert(1)</script></html>"), user.ID))
+		if w.Code != http.StatusUnsupportedMediaType {
+			t.Errorf("Expected status %d, got %d", http.StatusUnsupportedMediaType, w.Code)
+		}
+	})
+
+	t.Run("File too large", func(t *testing.T) {
+		previous := h.cfg.MediaMaxBytes
+		h.cfg.MediaMaxBytes = 100
+		defer func() { h.cfg.MediaMaxBytes = previous }()
+		w := httptest.NewRecorder()
+		h.media.UploadMedia(w, newUploadRequest(t, "big.png", testPNG(t, 400, 400), user.ID))
+		if w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status %d, got %d", http.StatusRequestEntityTooLarge, w.Code)
+		}
+	})
+
+	t.Run("Decompression bomb", func(t *testing.T) {
+		// PNG kecil dengan IHDR yang mendeklarasikan 50000x50000 pixel
+		data := testPNG(t, 1, 1)
+		binary.BigEndian.PutUint32(data[16:], 50000)
+		binary.BigEndian.PutUint32(data[20:], 50000)
+		binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+		w := httptest.NewRecorder()
+		h.media.UploadMedia(w, newUploadRequest(t, "bomb.png", data, user.ID))
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "dimensions too large") {
+			t.Errorf("Expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+		}
+	})
+
+	t.Run("Quota exceeded", func(t *testing.T) {
+		previous := h.cfg.MediaQuotaBytes
+		h.cfg.MediaQuotaBytes = media.Size + 10
+		defer func() { h.cfg.MediaQuotaBytes = previous }()
+		w := httptest.NewRecorder()
+		h.media.UploadMedia(w, newUploadRequest(t, "second.png", testPNG(t, 40, 30), user.ID))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d, got %d", http.StatusForbidden, w.Code)
+		}
+
+		// File yang sudah disimpan dihapus lagi, record tidak dibuat
+		files, _ := filepath.Glob(filepath.Join(h.files.Dir, fmt.Sprint(user.ID), "*"))
+		if len(files) != 1 {
+			t.Errorf("Expected only the first upload in storage, got %v", files)
+		}
+	})
+
+	t.Run("Delete removes file", func(t *testing.T) {
+		vars := map[string]string{"id": fmt.Sprint(media.ID)}
+
+		w := httptest.NewRecorder()
+		h.media.DeleteMedia(w, newTestRequest("DELETE", "/", nil, vars, user.ID+1))
+		if w.Code != http.StatusForbidden {
+			t.Errorf("Expected status %d for other user, got %d", http.StatusForbidden, w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		h.media.DeleteMedia(w, newTestRequest("DELETE", "/", nil, vars, user.ID))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+
+		w = httptest.NewRecorder()
+		h.media.ServeMediaFile(w, newTestRequest("GET", "/", nil, map[string]string{"key": media.Key}, 0))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d after delete, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestPostFeaturedImage(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	author := createTestUser(t, h.db, "author@example.com")
+	other := createTestUser(t, h.db, "other@example.com")
+
+	own := models.Media{UserID: author.ID, Key: "1/own.png", ContentType: "image/png", Size: 10, VariantStatus: models.MediaVariantReady}
+	foreign := models.Media{UserID: other.ID, Key: "2/foreign.png", ContentType: "image/png", Size: 10}
+	h.db.Create(&own)
+	h.db.Create(&foreign)
+	h.db.Create(&[]models.MediaVariant{
+		{MediaID: own.ID, Width: 640, Height: 320, Format: "jpeg", Key: "1/own_w640.jpg"},
+		{MediaID: own.ID, Width: 320, Height: 160, Format: "jpeg", Key: "1/own_w320.jpg"},
+	})
+
+	w := httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "Foreign", Content: "Using someone else's image", FeaturedImageID: &foreign.ID}, nil, author.ID))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status %d for foreign media, got %d", http.StatusForbidden, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "Own", Content: "Using my own image", FeaturedImageID: &own.ID}, nil, author.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	var post models.Post
+	json.NewDecoder(w.Body).Decode(&post)
+	if post.FeaturedImage == nil {
+		t.Fatal("Expected featured_image in response")
+	}
+	expected := "/media/1/own_w320.jpg 320w, /media/1/own_w640.jpg 640w"
+	if post.FeaturedImage.SrcSet["jpeg"] != expected {
+		t.Errorf("Expected srcset %q, got %q", expected, post.FeaturedImage.SrcSet["jpeg"])
+	}
+
+	// featured_image_id 0 menghapus featured image
+	zero := uint(0)
+	w = httptest.NewRecorder()
+	h.posts.UpdatePost(w, newTestRequest("PUT", "/", PostRequest{Title: "Own", Content: "Using my own image", FeaturedImageID: &zero}, map[string]string{"id": fmt.Sprint(post.ID)}, author.ID))
+	json.NewDecoder(w.Body).Decode(&post)
+	if w.Code != http.StatusOK || post.FeaturedImageID != nil {
+		t.Errorf("Expected featured image to be cleared, got %d %+v", w.Code, post.FeaturedImageID)
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"blog-api/internal/models"
+)
+
+func TestCommentMentions(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	jane := createTestUser(t, h.db, "jane@example.com")
+	post := createTestPost(t, h.db, author.ID)
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+
+	w := httptest.NewRecorder()
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "cc @Jane and @nobody <3"}, vars, author.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	var mentions []models.Mention
+	h.db.Find(&mentions)
+	if len(mentions) != 1 || mentions[0].UserID != jane.ID {
+		t.Fatalf("Expected a single mention of jane, got %+v", mentions)
+	}
+
+	response := getTestNotifications(t, h, jane.ID)
+	if len(response.Data) != 1 || response.Data[0].Type != models.NotificationTypeMention {
+		t.Fatalf("Expected mention notification, got %+v", response.Data)
+	}
+
+	w = httptest.NewRecorder()
+	h.comments.GetComments(w, newTestRequest("GET", "/", nil, vars, 0))
+
+	var page struct {
+		Data []models.Comment `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&page)
+
+	expected := `cc <a href="/@jane" class="mention">@Jane</a> and @nobody &lt;3`
+	if len(page.Data) != 1 || page.Data[0].ContentHTML != expected {
+		t.Errorf("Expected content_html %q, got %+v", expected, page.Data)
+	}
+}
+
+func TestUpdatePostSyncsMentions(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	createTestUser(t, h.db, "jane@example.com")
+	createTestUser(t, h.db, "john@example.com")
+
+	w := httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "Hello", Content: "Thanks @jane for the review"}, nil, author.ID))
+
+	var post models.Post
+	json.NewDecoder(w.Body).Decode(&post)
+
+	w = httptest.NewRecorder()
+	h.posts.UpdatePost(w, newTestRequest("PUT", "/", PostRequest{Title: "Hello", Content: "Thanks @john for the review"}, map[string]string{"id": fmt.Sprint(post.ID)}, author.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var usernames []string
+	h.db.Model(&models.Mention{}).
+		Joins("JOIN users ON users.id = mentions.user_id").
+		Where("mentions.target_type = ? AND mentions.target_id = ?", models.ReactionTargetPost, post.ID).
+		Pluck("users.username", &usernames)
+	if len(usernames) != 1 || usernames[0] != "john" {
+		t.Errorf("Expected mentions [john] after update, got %v", usernames)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	createTestUser(t, h.db, "jo_hn@example.com")
+	createTestUser(t, h.db, "joanne@example.com")
+	createTestUser(t, h.db, "mike@example.com")
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{"jo", []string{"jo_hn", "joanne"}},
+		{"@JO_", []string{"jo_hn"}},
+		{"x", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.users.SearchUsers(w, newTestRequest("GET", "/?prefix="+tt.prefix, nil, nil, 0))
+
+			var users []UserSummary
+			json.NewDecoder(w.Body).Decode(&users)
+
+			var got []string
+			for _, user := range users {
+				got = append(got, user.Username)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRegisterUsername(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	createTestUser(t, h.db, "taken@example.com")
+
+	tests := []struct {
+		name             string
+		request          RegisterRequest
+		expectedStatus   int
+		expectedUsername string
+	}{
+		{"Generated from email", RegisterRequest{Email: "Taken@other.com", Password: "password123", Name: "Taken Two"}, http.StatusCreated, "taken1"},
+		{"Explicit username", RegisterRequest{Email: "x@example.com", Password: "password123", Name: "Explicit", Username: "@Cool_Name"}, http.StatusCreated, "cool_name"},
+		{"Reserved username", RegisterRequest{Email: "y@example.com", Password: "password123", Name: "Reserved", Username: "admin"}, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.auth.Register(w, newTestRequest("POST", "/api/register", tt.request, nil, 0))
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			var response AuthResponse
+			json.NewDecoder(w.Body).Decode(&response)
+			if response.User.Username != tt.expectedUsername {
+				t.Errorf("Expected username %q, got %q", tt.expectedUsername, response.User.Username)
+			}
+		})
+	}
+}
+
+func TestUpdateUsername(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	user := createTestUser(t, h.db, "renamer@example.com")
+	createTestUser(t, h.db, "taken@example.com")
+
+	tests := []struct {
+		name           string
+		username       string
+		expectedStatus int
+	}{
+		{"Available", "fresh_name", http.StatusOK},
+		{"Taken", "taken", http.StatusConflict},
+		{"Invalid", "a", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.users.UpdateUsername(w, newTestRequest("PUT", "/api/me/username", UsernameRequest{Username: tt.username}, nil, user.ID))
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	// Error database selain unique violation bukan 409
+	sqlDB, _ := h.db.DB()
+	sqlDB.Close()
+	w := httptest.NewRecorder()
+	h.users.UpdateUsername(w, newTestRequest("PUT", "/api/me/username", UsernameRequest{Username: "other_name"}, nil, user.ID))
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500 on database error, got %d", w.Code)
+	}
+}
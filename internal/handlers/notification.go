@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// NotificationListResponse - Response inbox notifikasi
+type NotificationListResponse struct {
+	Data        []models.Notification `json:"data"`
+	NextCursor  string                `json:"next_cursor,omitempty"`
+	UnreadCount int64                 `json:"unread_count"`
+}
+
+// NotificationPreferenceRequest - Field nil tidak diubah
+type NotificationPreferenceRequest struct {
+	Comment  *bool `json:"comment"`
+	Reaction *bool `json:"reaction"`
+	Mention  *bool `json:"mention"`
+}
+
+// NotificationHandler - Inbox notifikasi dan preferensinya
+type NotificationHandler struct {
+	notifications *service.NotificationService
+}
+
+func NewNotificationHandler(notifications *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{notifications: notifications}
+}
+
+// GetNotifications - Inbox notifikasi user (aktivitas terbaru dulu, cursor pagination)
+// Query params: limit, cursor, unread=true
+func (h *NotificationHandler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+	page, err := h.notifications.List(r.Context(), userID, unreadOnly, service.PageOptions{
+		Limit:  limit,
+		Cursor: r.URL.Query().Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, NotificationListResponse{
+		Data:        page.Notifications,
+		NextCursor:  page.NextCursor,
+		UnreadCount: page.UnreadCount,
+	})
+}
+
+// MarkNotificationRead - Tandai satu notifikasi sebagai sudah dibaca
+func (h *NotificationHandler) MarkNotificationRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	notificationID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid notification ID")
+		return
+	}
+
+	notification, err := h.notifications.MarkRead(r.Context(), userID, uint(notificationID))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, notification)
+}
+
+// MarkAllNotificationsRead - Tandai semua notifikasi user sebagai sudah dibaca
+func (h *NotificationHandler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	updated, err := h.notifications.MarkAllRead(r.Context(), userID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]int64{"updated": updated})
+}
+
+// GetNotificationPreferences - Ambil preferensi notifikasi user
+func (h *NotificationHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	pref, err := h.notifications.Preferences(r.Context(), userID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pref)
+}
+
+// UpdateNotificationPreferences - Update sebagian atau semua preferensi notifikasi
+func (h *NotificationHandler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req NotificationPreferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	pref, err := h.notifications.UpdatePreferences(r.Context(), userID, service.PreferenceInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pref)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blog-api/internal/models"
+)
+
+func getTestNotifications(t *testing.T, h *testHandlers, userID uint) NotificationListResponse {
+	w := httptest.NewRecorder()
+	h.notifications.GetNotifications(w, newTestRequest("GET", "/", nil, nil, userID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response NotificationListResponse
+	json.NewDecoder(w.Body).Decode(&response)
+	return response
+}
+
+func TestCommentNotificationsCoalesce(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	alice := createTestUser(t, h.db, "alice@example.com")
+	bob := createTestUser(t, h.db, "bob@example.com")
+	post := createTestPost(t, h.db, author.ID)
+	vars := map[string]string{"post_id": fmt.Sprint(post.ID)}
+
+	// Comment author sendiri tidak menghasilkan notifikasi
+	for _, userID := range []uint{author.ID, alice.ID, bob.ID, alice.ID} {
+		w := httptest.NewRecorder()
+		h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Hello"}, vars, userID))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+		}
+	}
+
+	response := getTestNotifications(t, h, author.ID)
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected 1 coalesced notification, got %d", len(response.Data))
+	}
+
+	notification := response.Data[0]
+	if notification.ActorCount != 2 {
+		t.Errorf("Expected 2 distinct actors, got %d", notification.ActorCount)
+	}
+	if expected := `2 people commented on your post "Test Post"`; notification.Message != expected {
+		t.Errorf("Expected message %q, got %q", expected, notification.Message)
+	}
+	if response.UnreadCount != 1 {
+		t.Errorf("Expected unread count 1, got %d", response.UnreadCount)
+	}
+
+	w := httptest.NewRecorder()
+	h.notifications.MarkNotificationRead(w, newTestRequest("POST", "/", nil, map[string]string{"id": fmt.Sprint(notification.ID)}, alice.ID))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for other user's notification, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.notifications.MarkNotificationRead(w, newTestRequest("POST", "/", nil, map[string]string{"id": fmt.Sprint(notification.ID)}, author.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	// Setelah dibaca, comment baru membuat notifikasi baru
+	w = httptest.NewRecorder()
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Again"}, vars, bob.ID))
+
+	response = getTestNotifications(t, h, author.ID)
+	if len(response.Data) != 2 || response.UnreadCount != 1 {
+		t.Fatalf("Expected 2 notifications with 1 unread, got %d with %d unread", len(response.Data), response.UnreadCount)
+	}
+
+	w = httptest.NewRecorder()
+	h.notifications.MarkAllNotificationsRead(w, newTestRequest("POST", "/", nil, nil, author.ID))
+	if response = getTestNotifications(t, h, author.ID); response.UnreadCount != 0 {
+		t.Errorf("Expected unread count 0 after mark all read, got %d", response.UnreadCount)
+	}
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "prefs@example.com")
+	reader := createTestUser(t, h.db, "reader@example.com")
+	post := createTestPost(t, h.db, author.ID)
+
+	disabled := false
+	w := httptest.NewRecorder()
+	h.notifications.UpdateNotificationPreferences(w, newTestRequest("PUT", "/", NotificationPreferenceRequest{Comment: &disabled}, nil, author.ID))
+
+	var pref models.NotificationPreference
+	json.NewDecoder(w.Body).Decode(&pref)
+	if pref.Comment || !pref.Reaction {
+		t.Fatalf("Expected only comment notifications disabled, got %+v", pref)
+	}
+
+	w = httptest.NewRecorder()
+	h.comments.CreateComment(w, newTestRequest("POST", "/", CommentRequest{Content: "Hi"}, map[string]string{"post_id": fmt.Sprint(post.ID)}, reader.ID))
+
+	w = httptest.NewRecorder()
+	h.reactions.AddPostReaction(w, newTestRequest("PUT", "/", nil, map[string]string{"id": fmt.Sprint(post.ID), "kind": "like"}, reader.ID))
+
+	response := getTestNotifications(t, h, author.ID)
+	if len(response.Data) != 1 || response.Data[0].Type != models.NotificationTypeReaction {
+		t.Fatalf("Expected only reaction notification, got %+v", response.Data)
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/service"
+)
+
+const (
+	defaultPageLimit = service.DefaultPageLimit
+	maxPageLimit     = service.MaxPageLimit
+)
+
+// PaginatedResponse - Response untuk list dengan cursor pagination
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// parseLimit - Ambil query param limit dengan default dan batas maksimum
+func parseLimit(r *http.Request) (int, error) {
+	raw := r.URL.Query().Get("limit")
+	if raw == "" {
+		return defaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit < 1 {
+		return 0, errors.New("invalid limit")
+	}
+
+	if limit > maxPageLimit {
+		limit = maxPageLimit
+	}
+	return limit, nil
+}
@@ -2,23 +2,47 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 
-	"blog-api/internal/database"
+	"blog-api/internal/config"
 	"blog-api/internal/middleware"
 	"blog-api/internal/models"
+	"blog-api/internal/service"
+	"blog-api/internal/storage"
 
 	"github.com/gorilla/mux"
 )
 
+// maxEmbeddedComments - Batas comment yang ikut di response GetPost dan GetPosts,
+// sisanya diambil lewat GetComments (comments_url)
+const maxEmbeddedComments = 10
+
 type PostRequest struct {
-	Title   string `json:"title"`
-	Content string `json:"content"`
+	Title   string   `json:"title"`
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"` // Opsional, saat update nil berarti tag tidak diubah
+	// FeaturedImageID - Opsional, ID media milik sendiri; saat update nil berarti tidak diubah, 0 menghapus
+	FeaturedImageID *uint `json:"featured_image_id"`
+}
+
+// PostHandler - CRUD post, GetPost menyertakan beberapa comment pertama
+type PostHandler struct {
+	cfg       *config.Config
+	posts     *service.PostService
+	comments  *service.CommentService
+	reactions *service.ReactionService
+	bookmarks *service.BookmarkService
+	files     storage.Storage // URL featured image
+}
+
+func NewPostHandler(cfg *config.Config, posts *service.PostService, comments *service.CommentService, reactions *service.ReactionService, bookmarks *service.BookmarkService, files storage.Storage) *PostHandler {
+	return &PostHandler{cfg: cfg, posts: posts, comments: comments, reactions: reactions, bookmarks: bookmarks, files: files}
 }
 
 // CreatePost - Buat post baru (dengan transaksi)
-func CreatePost(w http.ResponseWriter, r *http.Request) {
+func (h *PostHandler) CreatePost(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
 		respondError(w, http.StatusUnauthorized, "Unauthorized")
@@ -31,71 +55,54 @@ func CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validasi input
-	valid, errMsg := ValidateRequired(map[string]string{
-		"title":   req.Title,
-		"content": req.Content,
-	})
-	if !valid {
-		HandleValidationError(w, errMsg)
-		return
-	}
-
-	if !ValidateStringLength(req.Title, 3, 200) {
-		HandleValidationError(w, "Title must be between 3 and 200 characters")
+	post, err := h.posts.Create(r.Context(), requestOrigin(h.cfg, r), userID, service.PostInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
+	prepareFeaturedImages(h.files, []models.Post{post})
+	respondJSON(w, http.StatusCreated, post)
+}
 
-	if !ValidateStringLength(req.Content, 10, 10000) {
-		HandleValidationError(w, "Content must be between 10 and 10000 characters")
+// GetPosts - Ambil semua posts, masing-masing dengan beberapa comment pertama dan comments_url
+// Query param sort=top mengurutkan berdasarkan jumlah reaction
+func (h *PostHandler) GetPosts(w http.ResponseWriter, r *http.Request) {
+	posts, err := h.posts.All(r.Context(), r.URL.Query().Get("sort"))
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	// Mulai transaksi
-	tx := database.GetDB().Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	post := models.Post{
-		Title:   req.Title,
-		Content: req.Content,
-		UserID:  userID,
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
 	}
-
-	if err := tx.Create(&post).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to create post")
+	previews, err := h.comments.Previews(r.Context(), ids, maxEmbeddedComments)
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
+	for i := range posts {
+		posts[i].Comments = previews[posts[i].ID]
+		posts[i].CommentsURL = commentsURL(posts[i].ID)
+	}
 
-	// Preload user data
-	if err := tx.Preload("User").First(&post, post.ID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to load post data")
+	if err := h.reactions.AttachToPosts(r.Context(), viewerOf(r), posts); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	tx.Commit()
-	respondJSON(w, http.StatusCreated, post)
-}
-
-// GetPosts - Ambil semua posts
-func GetPosts(w http.ResponseWriter, r *http.Request) {
-	var posts []models.Post
-
-	if err := database.GetDB().Preload("User").Preload("Comments").Find(&posts).Error; err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to fetch posts")
+	if err := h.bookmarks.AttachToPosts(r.Context(), viewerOf(r), posts); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
+	prepareFeaturedImages(h.files, posts)
 	respondJSON(w, http.StatusOK, posts)
 }
 
 // GetPost - Ambil single post by ID
-func GetPost(w http.ResponseWriter, r *http.Request) {
+func (h *PostHandler) GetPost(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	postID, err := strconv.ParseUint(vars["id"], 10, 32)
 	if err != nil {
@@ -103,101 +110,77 @@ func GetPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var post models.Post
-	if err := database.GetDB().Preload("User").Preload("Comments.User").First(&post, postID).Error; err != nil {
-		respondError(w, http.StatusNotFound, "Post not found")
-		return
-	}
-
-	respondJSON(w, http.StatusOK, post)
-}
-
-// UpdatePost - Update post (dengan transaksi)
-func UpdatePost(w http.ResponseWriter, r *http.Request) {
-	userID, ok := middleware.GetUserID(r)
-	if !ok {
-		respondError(w, http.StatusUnauthorized, "Unauthorized")
+	post, err := h.posts.Get(r.Context(), uint(postID))
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	vars := mux.Vars(r)
-	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	post.Comments, _, err = h.comments.List(r.Context(), post.ID, service.PageOptions{Limit: maxEmbeddedComments})
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		respondServiceError(w, r, err)
 		return
 	}
 
-	var req PostRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	posts := []models.Post{post}
+	if err := h.reactions.AttachToPosts(r.Context(), viewerOf(r), posts); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	// Validasi input
-	valid, errMsg := ValidateRequired(map[string]string{
-		"title":   req.Title,
-		"content": req.Content,
-	})
-	if !valid {
-		HandleValidationError(w, errMsg)
+	if err := h.bookmarks.AttachToPosts(r.Context(), viewerOf(r), posts); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
+	prepareFeaturedImages(h.files, posts)
+	post = posts[0]
 
-	if !ValidateStringLength(req.Title, 3, 200) {
-		HandleValidationError(w, "Title must be between 3 and 200 characters")
+	if err := h.reactions.AttachToComments(r.Context(), viewerOf(r), post.Comments); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
 
-	if !ValidateStringLength(req.Content, 10, 10000) {
-		HandleValidationError(w, "Content must be between 10 and 10000 characters")
-		return
-	}
+	post.CommentsURL = commentsURL(post.ID)
+	respondJSON(w, http.StatusOK, post)
+}
 
-	// Mulai transaksi
-	tx := database.GetDB().Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
+// commentsURL - Endpoint GetComments untuk comment post yang tidak ikut di-embed
+func commentsURL(postID uint) string {
+	return fmt.Sprintf("/api/posts/%d/comments", postID)
+}
 
-	var post models.Post
-	if err := tx.First(&post, postID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusNotFound, "Post not found")
+// UpdatePost - Update post (dengan transaksi)
+func (h *PostHandler) UpdatePost(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
 		return
 	}
 
-	// Cek ownership
-	if post.UserID != userID {
-		tx.Rollback()
-		respondError(w, http.StatusForbidden, "You can only update your own posts")
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
 		return
 	}
 
-	// Update post
-	post.Title = req.Title
-	post.Content = req.Content
-
-	if err := tx.Save(&post).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to update post")
+	var req PostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Preload user data
-	if err := tx.Preload("User").First(&post, post.ID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to load post data")
+	post, err := h.posts.Update(r.Context(), requestOrigin(h.cfg, r), userID, uint(postID), service.PostInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
-
-	tx.Commit()
+	prepareFeaturedImages(h.files, []models.Post{post})
 	respondJSON(w, http.StatusOK, post)
 }
 
 // DeletePost - Hapus post (dengan transaksi, soft delete)
-func DeletePost(w http.ResponseWriter, r *http.Request) {
+func (h *PostHandler) DeletePost(w http.ResponseWriter, r *http.Request) {
 	userID, ok := middleware.GetUserID(r)
 	if !ok {
 		respondError(w, http.StatusUnauthorized, "Unauthorized")
@@ -211,35 +194,9 @@ func DeletePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Mulai transaksi
-	tx := database.GetDB().Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-
-	var post models.Post
-	if err := tx.First(&post, postID).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusNotFound, "Post not found")
-		return
-	}
-
-	// Cek ownership
-	if post.UserID != userID {
-		tx.Rollback()
-		respondError(w, http.StatusForbidden, "You can only delete your own posts")
+	if err := h.posts.Delete(r.Context(), requestOrigin(h.cfg, r), userID, uint(postID)); err != nil {
+		respondServiceError(w, r, err)
 		return
 	}
-
-	// Soft delete post (dan comments akan ikut ter-cascade karena foreign key)
-	if err := tx.Delete(&post).Error; err != nil {
-		tx.Rollback()
-		respondError(w, http.StatusInternalServerError, "Failed to delete post")
-		return
-	}
-
-	tx.Commit()
 	respondJSON(w, http.StatusOK, map[string]string{"message": "Post deleted successfully"})
 }
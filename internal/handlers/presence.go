@@ -0,0 +1,260 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+	"blog-api/internal/presence"
+	"blog-api/internal/realtime"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// presenceHeartbeatInterval - Interval heartbeat yang disarankan ke client, juga interval ping dan refresh snapshot
+	presenceHeartbeatInterval = 10 * time.Second
+	// presenceWriteTimeout - Batas waktu menulis satu message ke client
+	presenceWriteTimeout = 10 * time.Second
+	// presenceMaxMessage - Ukuran maksimal message dari client
+	presenceMaxMessage = 4096
+)
+
+// PresenceClientMessage - Message dari client: heartbeat, state (viewing/editing), lock.acquire, lock.release
+type PresenceClientMessage struct {
+	Type  string `json:"type"`
+	State string `json:"state,omitempty"`
+}
+
+// PresenceMessage - Message dari server
+type PresenceMessage struct {
+	Type              string                 `json:"type"`
+	SessionID         string                 `json:"session_id,omitempty"`
+	CanEdit           *bool                  `json:"can_edit,omitempty"`
+	HeartbeatInterval int                    `json:"heartbeat_interval,omitempty"` // Detik
+	Participants      []presence.Participant `json:"participants,omitempty"`
+	Lock              *models.PostEditLock   `json:"lock,omitempty"`
+	Saved             *presence.PostSaved    `json:"saved,omitempty"`
+	Message           string                 `json:"message,omitempty"`
+}
+
+// PresenceHandler - WebSocket presence editor
+type PresenceHandler struct {
+	cfg      *config.Config
+	presence *service.PresenceService
+	hub      *realtime.Hub
+}
+
+func NewPresenceHandler(cfg *config.Config, presence *service.PresenceService, hub *realtime.Hub) *PresenceHandler {
+	return &PresenceHandler{cfg: cfg, presence: presence, hub: hub}
+}
+
+// PostPresence - WebSocket presence editor per post: siapa yang sedang melihat/mengedit,
+// advisory edit lock (hanya author) dengan heartbeat, dan notifikasi saat versi baru disimpan
+func (h *PresenceHandler) PostPresence(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	session, err := h.presence.Open(r.Context(), userID, uint(postID))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	if h.hub == nil {
+		respondError(w, http.StatusServiceUnavailable, "Realtime is not available")
+		return
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: h.checkPresenceOrigin}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader sudah menulis response error
+		return
+	}
+	defer conn.Close()
+	conn.SetReadLimit(presenceMaxMessage)
+
+	// Subscribe sebelum mengumumkan join agar tidak ada perubahan yang terlewat
+	sub, _, _ := h.hub.Subscribe(presence.Topic(session.PostID), "")
+	defer sub.Close()
+
+	ctx := r.Context()
+	if err := session.Join(ctx); err != nil {
+		writePresence(conn, PresenceMessage{Type: "error", Message: err.Error()})
+		return
+	}
+	defer func() {
+		if err := session.Leave(ctx); err != nil {
+			logging.FromContext(ctx).Warn("failed to leave presence session", "session_id", session.ID, "error", err)
+		}
+	}()
+
+	canEdit := session.CanEdit
+	err = writePresence(conn, PresenceMessage{
+		Type:              "welcome",
+		SessionID:         session.ID,
+		CanEdit:           &canEdit,
+		HeartbeatInterval: int(presenceHeartbeatInterval.Seconds()),
+	})
+	if err != nil {
+		return
+	}
+
+	// Hanya goroutine ini yang menulis ke conn; goroutine reader meneruskan message client lewat channel
+	incoming := make(chan PresenceClientMessage)
+	done := make(chan struct{})
+	defer close(done)
+	go readPresenceMessages(conn, incoming, done)
+
+	var lastSnapshot []byte
+	sendSnapshot := func() error {
+		snapshot, err := session.Snapshot(ctx)
+		if err != nil {
+			return err
+		}
+		data, err := json.Marshal(PresenceMessage{Type: "presence", Participants: snapshot.Participants, Lock: snapshot.Lock})
+		if err != nil || bytes.Equal(data, lastSnapshot) {
+			return err
+		}
+		lastSnapshot = data
+		conn.SetWriteDeadline(time.Now().Add(presenceWriteTimeout))
+		return conn.WriteMessage(websocket.TextMessage, data)
+	}
+
+	ticker := time.NewTicker(presenceHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		select {
+		case msg, ok := <-incoming:
+			if !ok {
+				return
+			}
+			err = handlePresenceMessage(ctx, conn, session, msg)
+
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			switch msg.Event {
+			case presence.EventChanged:
+				err = sendSnapshot()
+			case presence.EventSaved:
+				var saved presence.PostSaved
+				if json.Unmarshal(msg.Data, &saved) == nil {
+					err = writePresence(conn, PresenceMessage{Type: presence.EventSaved, Saved: &saved})
+				}
+			}
+
+		case <-ticker.C:
+			// Ping menjaga koneksi lewat proxy, snapshot ulang menangkap session/lock yang expired
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(presenceWriteTimeout)); err == nil {
+				err = sendSnapshot()
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// handlePresenceMessage - Proses satu message client. Error service (state tidak valid, bukan author)
+// dikirim ke client, error lain menutup koneksi.
+func handlePresenceMessage(ctx context.Context, conn *websocket.Conn, session *service.PresenceSession, msg PresenceClientMessage) error {
+	var err error
+	switch msg.Type {
+	case "heartbeat":
+		err = session.Heartbeat(ctx)
+
+	case "state":
+		err = session.SetState(ctx, msg.State)
+
+	case "lock.acquire":
+		var lock models.PostEditLock
+		var ok bool
+		lock, ok, err = session.AcquireLock(ctx)
+		if err == nil && !ok {
+			return writePresence(conn, PresenceMessage{Type: "lock.denied", Lock: &lock})
+		}
+
+	case "lock.release":
+		err = session.ReleaseLock(ctx)
+
+	default:
+		return writePresence(conn, PresenceMessage{Type: "error", Message: "Unknown message type"})
+	}
+
+	if code := service.ErrorCode(err); err != nil && code != service.CodeInternal {
+		return writePresence(conn, PresenceMessage{Type: "error", Message: err.Error()})
+	}
+	return err
+}
+
+// readPresenceMessages - Baca message client sampai koneksi putus. Read deadline hanya diperpanjang
+// oleh message client (bukan pong) sehingga tab yang berhenti mengirim heartbeat dianggap pergi.
+func readPresenceMessages(conn *websocket.Conn, incoming chan<- PresenceClientMessage, done <-chan struct{}) {
+	defer close(incoming)
+	for {
+		conn.SetReadDeadline(time.Now().Add(presence.SessionTimeout))
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		// JSON tidak valid diteruskan dengan type kosong dan dijawab sebagai message tidak dikenal
+		var msg PresenceClientMessage
+		json.Unmarshal(data, &msg)
+		select {
+		case incoming <- msg:
+		case <-done:
+			return
+		}
+	}
+}
+
+// writePresence - Kirim message JSON dengan write deadline
+func writePresence(conn *websocket.Conn, msg PresenceMessage) error {
+	conn.SetWriteDeadline(time.Now().Add(presenceWriteTimeout))
+	return conn.WriteJSON(msg)
+}
+
+// checkPresenceOrigin - Izinkan client non-browser (tanpa Origin), origin yang sama dengan host,
+// atau PUBLIC_BASE_URL (frontend di domain lain)
+func (h *PresenceHandler) checkPresenceOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	base := h.cfg.PublicBaseURL
+	return base != "" && strings.EqualFold(origin, base)
+}
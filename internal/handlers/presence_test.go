@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-api/internal/middleware"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+// dialPresence - Buka koneksi presence sebagai user tertentu
+func dialPresence(t *testing.T, serverURL string, userID uint) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(serverURL, "http") + "?user=" + fmt.Sprint(userID)
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Failed to dial presence: %v (%v)", err, resp)
+	}
+	return conn
+}
+
+// readPresenceUntil - Baca message sampai ketemu yang memenuhi match
+func readPresenceUntil(t *testing.T, conn *websocket.Conn, match func(PresenceMessage) bool) PresenceMessage {
+	conn.SetReadDeadline(time.Now().Add(3 * time.Second))
+	for {
+		var msg PresenceMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("Failed to read presence message: %v", err)
+		}
+		if match(msg) {
+			return msg
+		}
+	}
+}
+
+func presenceType(messageType string) func(PresenceMessage) bool {
+	return func(msg PresenceMessage) bool { return msg.Type == messageType }
+}
+
+func TestPostPresence(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	reader := createTestUser(t, h.db, "reader@example.com")
+	post := createTestPost(t, h.db, author.ID)
+
+	// User diambil dari query agar test tidak perlu JWT (auth diuji di middleware)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := strconv.ParseUint(r.URL.Query().Get("user"), 10, 32)
+		r = r.WithContext(context.WithValue(r.Context(), middleware.UserIDKey, uint(userID)))
+		h.presence.PostPresence(w, mux.SetURLVars(r, map[string]string{"id": fmt.Sprint(post.ID)}))
+	}))
+	defer server.Close()
+
+	first := dialPresence(t, server.URL, author.ID)
+	defer first.Close()
+	welcome := readPresenceUntil(t, first, presenceType("welcome"))
+	if welcome.SessionID == "" || welcome.CanEdit == nil || !*welcome.CanEdit {
+		t.Fatalf("Unexpected welcome %+v", welcome)
+	}
+
+	second := dialPresence(t, server.URL, author.ID)
+	defer second.Close()
+	secondWelcome := readPresenceUntil(t, second, presenceType("welcome"))
+
+	viewer := dialPresence(t, server.URL, reader.ID)
+	defer viewer.Close()
+	if msg := readPresenceUntil(t, viewer, presenceType("welcome")); *msg.CanEdit {
+		t.Error("Expected reader not to be able to edit")
+	}
+	readPresenceUntil(t, first, func(msg PresenceMessage) bool {
+		return msg.Type == "presence" && len(msg.Participants) == 3
+	})
+
+	// Hanya author yang bisa mengambil lock
+	viewer.WriteJSON(PresenceClientMessage{Type: "lock.acquire"})
+	readPresenceUntil(t, viewer, presenceType("error"))
+
+	first.WriteJSON(PresenceClientMessage{Type: "lock.acquire"})
+	locked := readPresenceUntil(t, second, func(msg PresenceMessage) bool {
+		return msg.Type == "presence" && msg.Lock != nil
+	})
+	if locked.Lock.SessionID != welcome.SessionID {
+		t.Fatalf("Expected lock held by first session, got %+v", locked.Lock)
+	}
+
+	second.WriteJSON(PresenceClientMessage{Type: "lock.acquire"})
+	if denied := readPresenceUntil(t, second, presenceType("lock.denied")); denied.Lock.SessionID != welcome.SessionID {
+		t.Errorf("Expected denial naming the holder, got %+v", denied.Lock)
+	}
+
+	// Versi baru yang disimpan diumumkan ke semua session
+	req := newTestRequest("PUT", "/", PostRequest{Title: "Saved Title", Content: "Saved content here"}, map[string]string{"id": fmt.Sprint(post.ID)}, author.ID)
+	req.Header.Set("X-Editor-Session", welcome.SessionID)
+	w := httptest.NewRecorder()
+	h.posts.UpdatePost(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	saved := readPresenceUntil(t, second, presenceType("post.saved"))
+	if saved.Saved.PostID != post.ID || saved.Saved.SessionID != welcome.SessionID {
+		t.Errorf("Unexpected post.saved %+v", saved.Saved)
+	}
+
+	// Pemegang lock menutup koneksi, lock dilepas dan session hilang dari presence
+	first.Close()
+	readPresenceUntil(t, second, func(msg PresenceMessage) bool {
+		return msg.Type == "presence" && msg.Lock == nil && len(msg.Participants) == 2
+	})
+	second.WriteJSON(PresenceClientMessage{Type: "lock.acquire"})
+	relocked := readPresenceUntil(t, second, func(msg PresenceMessage) bool {
+		return msg.Type == "presence" && msg.Lock != nil
+	})
+	if relocked.Lock.SessionID != secondWelcome.SessionID {
+		t.Errorf("Expected second session to take the lock, got %+v", relocked.Lock)
+	}
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// ReactionHandler - Reaction pada post dan comment
+type ReactionHandler struct {
+	reactions *service.ReactionService
+}
+
+func NewReactionHandler(reactions *service.ReactionService) *ReactionHandler {
+	return &ReactionHandler{reactions: reactions}
+}
+
+// AddPostReaction - Tambah reaction ke post (idempotent)
+func (h *ReactionHandler) AddPostReaction(w http.ResponseWriter, r *http.Request) {
+	h.setPostReaction(w, r, true)
+}
+
+// RemovePostReaction - Hapus reaction dari post (idempotent)
+func (h *ReactionHandler) RemovePostReaction(w http.ResponseWriter, r *http.Request) {
+	h.setPostReaction(w, r, false)
+}
+
+// AddCommentReaction - Tambah reaction ke comment (idempotent)
+func (h *ReactionHandler) AddCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.setCommentReaction(w, r, true)
+}
+
+// RemoveCommentReaction - Hapus reaction dari comment (idempotent)
+func (h *ReactionHandler) RemoveCommentReaction(w http.ResponseWriter, r *http.Request) {
+	h.setCommentReaction(w, r, false)
+}
+
+// setPostReaction - PUT/DELETE /posts/{id}/reactions/{kind}
+func (h *ReactionHandler) setPostReaction(w http.ResponseWriter, r *http.Request, add bool) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	summary, err := h.reactions.SetPostReaction(r.Context(), userID, uint(postID), vars["kind"], add)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// setCommentReaction - PUT/DELETE /posts/{post_id}/comments/{comment_id}/reactions/{kind}
+func (h *ReactionHandler) setCommentReaction(w http.ResponseWriter, r *http.Request, add bool) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	vars := mux.Vars(r)
+	postID, err := strconv.ParseUint(vars["post_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	commentID, err := strconv.ParseUint(vars["comment_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid comment ID")
+		return
+	}
+
+	summary, err := h.reactions.SetCommentReaction(r.Context(), userID, uint(postID), uint(commentID), vars["kind"], add)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, summary)
+}
+
+// viewerOf - User yang login untuk personalisasi (viewer_reactions, bookmarked), 0 untuk anonymous
+func viewerOf(r *http.Request) uint {
+	userID, _ := middleware.GetUserID(r)
+	return userID
+}
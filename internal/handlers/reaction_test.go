@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+)
+
+func TestPostReactions(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "author@example.com")
+	reader := createTestUser(t, h.db, "reader@example.com")
+	post := createTestPost(t, h.db, author.ID)
+
+	react := func(handler http.HandlerFunc, kind string, userID uint) (*httptest.ResponseRecorder, service.ReactionSummary) {
+		vars := map[string]string{"id": fmt.Sprint(post.ID), "kind": kind}
+		w := httptest.NewRecorder()
+		handler(w, newTestRequest("PUT", "/", nil, vars, userID))
+
+		var summary service.ReactionSummary
+		json.NewDecoder(w.Body).Decode(&summary)
+		return w, summary
+	}
+
+	score := func() int64 {
+		var p models.Post
+		h.db.First(&p, post.ID)
+		return p.Score
+	}
+
+	react(h.reactions.AddPostReaction, "like", author.ID)
+	w, summary := react(h.reactions.AddPostReaction, "like", reader.ID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if summary.Reactions["like"] != 2 {
+		t.Errorf("Expected 2 likes, got %d", summary.Reactions["like"])
+	}
+	if len(summary.ViewerReactions) != 1 || summary.ViewerReactions[0] != "like" {
+		t.Errorf("Expected viewer reactions [like], got %v", summary.ViewerReactions)
+	}
+
+	// Reaction yang sama tidak boleh dihitung dua kali
+	react(h.reactions.AddPostReaction, "like", reader.ID)
+	if got := score(); got != 2 {
+		t.Errorf("Expected score 2 after duplicate reaction, got %d", got)
+	}
+
+	_, summary = react(h.reactions.RemovePostReaction, "like", reader.ID)
+	if summary.Reactions["like"] != 1 || len(summary.ViewerReactions) != 0 {
+		t.Errorf("Unexpected summary after removal: %+v", summary)
+	}
+	if got := score(); got != 1 {
+		t.Errorf("Expected score 1 after removal, got %d", got)
+	}
+
+	w, _ = react(h.reactions.AddPostReaction, "not-a-kind", reader.ID)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for invalid kind, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestCommentReactionsAffectTopSort(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "top@example.com")
+	post := createTestPost(t, h.db, user.ID)
+	first := models.Comment{Content: "First", UserID: user.ID, PostID: post.ID}
+	second := models.Comment{Content: "Second", UserID: user.ID, PostID: post.ID}
+	h.db.Create(&first)
+	h.db.Create(&second)
+
+	vars := map[string]string{
+		"post_id":    fmt.Sprint(post.ID),
+		"comment_id": fmt.Sprint(first.ID),
+		"kind":       "love",
+	}
+	w := httptest.NewRecorder()
+	h.reactions.AddCommentReaction(w, newTestRequest("PUT", "/", nil, vars, user.ID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.comments.GetComments(w, newTestRequest("GET", "/?sort=top", nil, map[string]string{"post_id": fmt.Sprint(post.ID)}, user.ID))
+
+	var response struct {
+		Data []models.Comment `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+
+	if len(response.Data) != 2 || response.Data[0].ID != first.ID {
+		t.Fatalf("Expected reacted comment first, got %+v", response.Data)
+	}
+	if response.Data[0].Reactions["love"] != 1 {
+		t.Errorf("Expected 1 love reaction, got %v", response.Data[0].Reactions)
+	}
+	if len(response.Data[0].ViewerReactions) != 1 {
+		t.Errorf("Expected viewer reactions for authenticated caller, got %v", response.Data[0].ViewerReactions)
+	}
+}
@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+type ReadingListRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// ReadingListHandler - Reading list milik user dan link share publiknya
+type ReadingListHandler struct {
+	lists *service.ReadingListService
+}
+
+func NewReadingListHandler(lists *service.ReadingListService) *ReadingListHandler {
+	return &ReadingListHandler{lists: lists}
+}
+
+// CreateReadingList - Buat reading list baru milik user yang login
+func (h *ReadingListHandler) CreateReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req ReadingListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	list, err := h.lists.Create(r.Context(), userID, service.ReadingListInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, list)
+}
+
+// GetReadingLists - Ambil semua reading list milik user yang login
+func (h *ReadingListHandler) GetReadingLists(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	lists, err := h.lists.List(r.Context(), userID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, lists)
+}
+
+// GetReadingList - Ambil satu reading list milik user beserta post di dalamnya
+func (h *ReadingListHandler) GetReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	listID, ok := readingListID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.lists.Get(r.Context(), userID, listID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// GetSharedReadingList - Ambil reading list publik lewat share token,
+// pemilik yang login tetap bisa melihat list miliknya yang belum publik
+func (h *ReadingListHandler) GetSharedReadingList(w http.ResponseWriter, r *http.Request) {
+	list, err := h.lists.GetShared(r.Context(), viewerOf(r), mux.Vars(r)["token"])
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// UpdateReadingList - Update nama, deskripsi, dan visibilitas reading list
+func (h *ReadingListHandler) UpdateReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req ReadingListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	listID, ok := readingListID(w, r)
+	if !ok {
+		return
+	}
+
+	list, err := h.lists.Update(r.Context(), userID, listID, service.ReadingListInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// DeleteReadingList - Hapus reading list (soft delete)
+func (h *ReadingListHandler) DeleteReadingList(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	listID, ok := readingListID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.lists.Delete(r.Context(), userID, listID); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Reading list deleted successfully"})
+}
+
+// AddReadingListItem - Tambah post ke reading list (idempotent)
+func (h *ReadingListHandler) AddReadingListItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	postID, err := strconv.ParseUint(mux.Vars(r)["post_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	listID, ok := readingListID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.lists.AddItem(r.Context(), userID, listID, uint(postID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Post added to reading list"})
+}
+
+// RemoveReadingListItem - Hapus post dari reading list (idempotent)
+func (h *ReadingListHandler) RemoveReadingListItem(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	postID, err := strconv.ParseUint(mux.Vars(r)["post_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid post ID")
+		return
+	}
+
+	listID, ok := readingListID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.lists.RemoveItem(r.Context(), userID, listID, uint(postID)); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Post removed from reading list"})
+}
+
+// readingListID - Ambil path param {list_id}, menulis response error jika tidak valid
+func readingListID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	listID, err := strconv.ParseUint(mux.Vars(r)["list_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid reading list ID")
+		return 0, false
+	}
+	return uint(listID), true
+}
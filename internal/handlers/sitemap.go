@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/repository"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// sitemapMaxURLs - Batas URL per file sitemap menurut protokol sitemaps.org,
+// jika total lebih dari ini /sitemap.xml menjadi sitemap index
+const sitemapMaxURLs = 50000
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name       `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []sitemapEntry `xml:"sitemap"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+// SitemapHandler - Sitemap dan robots.txt untuk mesin pencari
+type SitemapHandler struct {
+	cfg     *config.Config
+	sitemap *service.SitemapService
+	maxURLs int // sitemapMaxURLs, dikecilkan di test
+}
+
+func NewSitemapHandler(cfg *config.Config, sitemap *service.SitemapService) *SitemapHandler {
+	return &SitemapHandler{cfg: cfg, sitemap: sitemap, maxURLs: sitemapMaxURLs}
+}
+
+// Sitemap - GET /sitemap.xml, berisi semua halaman post, author dan tag,
+// atau sitemap index jika total URL melebihi batas per file
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	base := publicBaseURL(h.cfg, r)
+
+	counts, err := h.sitemap.Counts(r.Context())
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	var total int64
+	for _, count := range counts {
+		total += count
+	}
+
+	// +1 untuk halaman utama
+	if total+1 > int64(h.maxURLs) {
+		index := sitemapIndex{}
+		for _, section := range service.SitemapSections {
+			pages := (counts[section] + int64(h.maxURLs) - 1) / int64(h.maxURLs)
+			for page := int64(1); page <= pages; page++ {
+				index.Sitemaps = append(index.Sitemaps, sitemapEntry{
+					Loc: fmt.Sprintf("%s/sitemaps/%s-%d.xml", base, section, page),
+				})
+			}
+		}
+		writeXML(w, index)
+		return
+	}
+
+	urlSet := sitemapURLSet{URLs: []sitemapURL{{Loc: base + "/"}}}
+	for _, section := range service.SitemapSections {
+		urls, err := h.sectionURLs(r, base, section, 1)
+		if err != nil {
+			respondServiceError(w, r, err)
+			return
+		}
+		urlSet.URLs = append(urlSet.URLs, urls...)
+	}
+
+	writeXML(w, urlSet)
+}
+
+// SitemapSection - GET /sitemaps/{section}-{page}.xml, satu halaman sitemap dari sitemap index
+func (h *SitemapHandler) SitemapSection(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	page, err := strconv.Atoi(vars["page"])
+	if err != nil || page < 1 {
+		respondError(w, http.StatusNotFound, "Sitemap not found")
+		return
+	}
+
+	urls, err := h.sectionURLs(r, publicBaseURL(h.cfg, r), vars["section"], page)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	if len(urls) == 0 {
+		respondError(w, http.StatusNotFound, "Sitemap not found")
+		return
+	}
+
+	writeXML(w, sitemapURLSet{URLs: urls})
+}
+
+// RobotsTxt - GET /robots.txt, izinkan halaman publik dan tunjuk ke sitemap
+func (h *SitemapHandler) RobotsTxt(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "User-agent: *\nDisallow: /api/\nAllow: /\n\nSitemap: %s/sitemap.xml\n", publicBaseURL(h.cfg, r))
+}
+
+// sectionURLs - URL untuk satu halaman section, lastmod dari UpdatedAt post
+// (untuk author dan tag: post terbaru yang terkait)
+func (h *SitemapHandler) sectionURLs(r *http.Request, base, section string, page int) ([]sitemapURL, error) {
+	entries, err := h.sitemap.Entries(r.Context(), section, page, h.maxURLs)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]sitemapURL, len(entries))
+	for i, entry := range entries {
+		switch section {
+		case repository.SitemapPosts:
+			urls[i].Loc = base + "/posts/" + entry.Slug
+		case repository.SitemapAuthors:
+			urls[i].Loc = authorPageURL(base, entry.Slug)
+		case repository.SitemapTags:
+			urls[i].Loc = tagPageURL(base, entry.Slug)
+		}
+		if !entry.UpdatedAt.IsZero() {
+			urls[i].LastMod = entry.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return urls, nil
+}
+
+// writeXML - Tulis response XML dengan header deklarasi
+func writeXML(w http.ResponseWriter, v interface{}) {
+	body, err := marshalXML(v)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to encode XML")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
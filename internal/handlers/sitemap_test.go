@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+)
+
+func TestSitemap(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t, func(cfg *config.Config) { cfg.PublicBaseURL = "https://blog.example.com" })
+
+	author := createTestUser(t, h.db, "writer@example.com")
+	createTestUser(t, h.db, "lurker@example.com")
+
+	w := httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "Tagged", Content: "Post with a tag", Tags: []string{"go"}}, nil, author.ID))
+	deleted := createTestPost(t, h.db, author.ID)
+	h.db.Delete(&models.Post{}, deleted.ID)
+
+	w = httptest.NewRecorder()
+	h.sitemap.Sitemap(w, newTestRequest("GET", "/sitemap.xml", nil, nil, 0))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var urlSet sitemapURLSet
+	if err := xml.Unmarshal(w.Body.Bytes(), &urlSet); err != nil {
+		t.Fatalf("Expected well-formed XML: %v", err)
+	}
+
+	var locs []string
+	for _, u := range urlSet.URLs {
+		locs = append(locs, u.Loc)
+		if u.Loc != "https://blog.example.com/" && u.LastMod == "" {
+			t.Errorf("Expected lastmod for %s", u.Loc)
+		}
+	}
+	expected := "https://blog.example.com/,https://blog.example.com/posts/1,https://blog.example.com/authors/writer,https://blog.example.com/tags/go"
+	if strings.Join(locs, ",") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(locs, ","))
+	}
+
+	t.Run("Index when over limit", func(t *testing.T) {
+		h.sitemap.maxURLs = 2
+		defer func() { h.sitemap.maxURLs = sitemapMaxURLs }()
+
+		createTestPost(t, h.db, author.ID)
+		createTestPost(t, h.db, author.ID)
+
+		w := httptest.NewRecorder()
+		h.sitemap.Sitemap(w, newTestRequest("GET", "/sitemap.xml", nil, nil, 0))
+
+		var index sitemapIndex
+		if err := xml.Unmarshal(w.Body.Bytes(), &index); err != nil {
+			t.Fatalf("Expected sitemap index: %v", err)
+		}
+		if len(index.Sitemaps) != 4 || index.Sitemaps[1].Loc != "https://blog.example.com/sitemaps/posts-2.xml" {
+			t.Fatalf("Unexpected sitemap index: %+v", index.Sitemaps)
+		}
+
+		w = httptest.NewRecorder()
+		h.sitemap.SitemapSection(w, newTestRequest("GET", "/", nil, map[string]string{"section": "posts", "page": "2"}, 0))
+		var page sitemapURLSet
+		xml.Unmarshal(w.Body.Bytes(), &page)
+		if len(page.URLs) != 1 {
+			t.Errorf("Expected 1 URL on second posts page, got %d", len(page.URLs))
+		}
+
+		w = httptest.NewRecorder()
+		h.sitemap.SitemapSection(w, newTestRequest("GET", "/", nil, map[string]string{"section": "posts", "page": "3"}, 0))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d for empty page, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestRobotsTxt(t *testing.T) {
+	t.Parallel()
+	h := NewSitemapHandler(&config.Config{PublicBaseURL: "https://blog.example.com"}, nil)
+
+	w := httptest.NewRecorder()
+	h.RobotsTxt(w, httptest.NewRequest("GET", "/robots.txt", nil))
+
+	if !strings.Contains(w.Body.String(), "Sitemap: https://blog.example.com/sitemap.xml") {
+		t.Errorf("Expected sitemap reference, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,432 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// syndicationItemLimit - Jumlah post terbaru yang dimasukkan ke feed RSS/Atom/JSON Feed
+const syndicationItemLimit = 20
+
+const syndicationSiteTitle = "Blog API"
+
+const (
+	feedFormatRSS  = "rss"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+)
+
+// syndicationFeed - Data feed yang netral terhadap format output
+type syndicationFeed struct {
+	Title        string
+	Description  string
+	HomeURL      string
+	SelfURL      string
+	Posts        []models.Post
+	LastModified time.Time
+}
+
+// SyndicationHandler - Feed RSS, Atom dan JSON Feed
+type SyndicationHandler struct {
+	cfg   *config.Config
+	users *service.UserService
+	posts *service.PostService
+}
+
+func NewSyndicationHandler(cfg *config.Config, users *service.UserService, posts *service.PostService) *SyndicationHandler {
+	return &SyndicationHandler{cfg: cfg, users: users, posts: posts}
+}
+
+// RSSFeed - Feed RSS 2.0 (global, per author atau per tag)
+func (h *SyndicationHandler) RSSFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveSyndication(w, r, feedFormatRSS)
+}
+
+// AtomFeed - Feed Atom 1.0 (global, per author atau per tag)
+func (h *SyndicationHandler) AtomFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveSyndication(w, r, feedFormatAtom)
+}
+
+// JSONFeed - Feed JSON Feed 1.1 (global, per author atau per tag)
+func (h *SyndicationHandler) JSONFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveSyndication(w, r, feedFormatJSON)
+}
+
+// serveSyndication - Ambil post terbaru sesuai scope (mux var username/tag),
+// jawab 304 jika client sudah punya versi terbaru, lalu tulis feed dalam format yang diminta
+func (h *SyndicationHandler) serveSyndication(w http.ResponseWriter, r *http.Request, format string) {
+	base := publicBaseURL(h.cfg, r)
+	feed := syndicationFeed{
+		Title:       syndicationSiteTitle,
+		Description: "Latest posts",
+		HomeURL:     base + "/",
+		SelfURL:     requestBaseURL(r) + r.URL.Path,
+	}
+
+	var authorID, tagID uint
+	vars := mux.Vars(r)
+	if username := vars["username"]; username != "" {
+		author, err := h.users.FindByUsername(r.Context(), username)
+		if err != nil {
+			respondServiceError(w, r, err)
+			return
+		}
+		feed.Title = fmt.Sprintf("%s - Posts by %s", syndicationSiteTitle, author.Name)
+		feed.Description = fmt.Sprintf("Latest posts by @%s", author.Username)
+		feed.HomeURL = authorPageURL(base, author.Username)
+		authorID = author.ID
+	}
+	if name := vars["tag"]; name != "" {
+		tag, err := h.posts.FindTag(r.Context(), name)
+		if err != nil {
+			respondServiceError(w, r, err)
+			return
+		}
+		feed.Title = fmt.Sprintf("%s - #%s", syndicationSiteTitle, tag.Name)
+		feed.Description = fmt.Sprintf("Latest posts tagged #%s", tag.Name)
+		feed.HomeURL = tagPageURL(base, tag.Name)
+		tagID = tag.ID
+	}
+
+	posts, err := h.posts.Latest(r.Context(), authorID, tagID, syndicationItemLimit)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	feed.Posts = posts
+
+	// ETag dihitung dari format, URL dan versi setiap post, berubah jika ada post baru/diubah/dihapus
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s", format, feed.SelfURL)
+	for _, post := range feed.Posts {
+		fmt.Fprintf(hash, "|%d:%d", post.ID, post.UpdatedAt.UnixNano())
+		if post.UpdatedAt.After(feed.LastModified) {
+			feed.LastModified = post.UpdatedAt
+		}
+	}
+	etag := `"` + hex.EncodeToString(hash.Sum(nil))[:32] + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	if !feed.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", feed.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(r, etag, feed.LastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if err := h.posts.RenderPosts(r.Context(), feed.Posts); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to render posts")
+		return
+	}
+
+	var body []byte
+	switch format {
+	case feedFormatRSS:
+		w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		body, err = marshalXML(buildRSS(feed, base))
+	case feedFormatAtom:
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		body, err = marshalXML(buildAtom(feed, base))
+	default:
+		w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+		body, err = json.Marshal(buildJSONFeed(feed, base))
+	}
+	if err != nil {
+		w.Header().Del("Content-Type")
+		respondError(w, http.StatusInternalServerError, "Failed to build feed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
+
+// notModified - Conditional GET: If-None-Match diutamakan, lalu If-Modified-Since
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, candidate := range strings.Split(match, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if since := r.Header.Get("If-Modified-Since"); since != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(since)
+		if err == nil && !lastModified.Truncate(time.Second).After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBaseURL - Scheme dan host dari request (menghormati X-Forwarded-Proto di belakang proxy)
+func requestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
+
+// publicBaseURL - Base URL halaman publik (PUBLIC_BASE_URL, fallback ke host request)
+func publicBaseURL(cfg *config.Config, r *http.Request) string {
+	if base := cfg.PublicBaseURL; base != "" {
+		return base
+	}
+	return requestBaseURL(r)
+}
+
+// requestOrigin - Info request yang diteruskan ke package service
+func requestOrigin(cfg *config.Config, r *http.Request) service.Origin {
+	return service.Origin{
+		BaseURL:       publicBaseURL(cfg, r),
+		EditorSession: r.Header.Get("X-Editor-Session"),
+	}
+}
+
+// authorPageURL - URL halaman publik author
+func authorPageURL(base, username string) string {
+	return base + "/authors/" + url.PathEscape(username)
+}
+
+// tagPageURL - URL halaman publik tag
+func tagPageURL(base, name string) string {
+	return base + "/tags/" + url.PathEscape(name)
+}
+
+// tagNames - Nama tag post untuk kategori feed
+func tagNames(post models.Post) []string {
+	names := make([]string, len(post.Tags))
+	for i, tag := range post.Tags {
+		names[i] = tag.Name
+	}
+	return names
+}
+
+// marshalXML - encoding/xml meng-escape semua text dan mengganti karakter yang tidak valid di XML
+func marshalXML(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	DCNS    string     `xml:"xmlns:dc,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string      `xml:"title"`
+	Link          string      `xml:"link"`
+	Description   string      `xml:"description"`
+	AtomLink      rssAtomLink `xml:"atom:link"`
+	LastBuildDate string      `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem   `xml:"item"`
+}
+
+type rssAtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        rssGUID  `xml:"guid"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	PubDate     string   `xml:"pubDate"`
+	Categories  []string `xml:"category"`
+	Description string   `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func buildRSS(feed syndicationFeed, base string) rssFeed {
+	channel := rssChannel{
+		Title:       feed.Title,
+		Link:        feed.HomeURL,
+		Description: feed.Description,
+		AtomLink:    rssAtomLink{Href: feed.SelfURL, Rel: "self", Type: "application/rss+xml"},
+	}
+	if !feed.LastModified.IsZero() {
+		channel.LastBuildDate = feed.LastModified.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, post := range feed.Posts {
+		link := service.PostPermalink(base, post)
+		channel.Items = append(channel.Items, rssItem{
+			Title:       post.Title,
+			Link:        link,
+			GUID:        rssGUID{IsPermaLink: "true", Value: link},
+			Creator:     post.User.Name,
+			PubDate:     post.CreatedAt.UTC().Format(time.RFC1123Z),
+			Categories:  tagNames(post),
+			Description: post.ContentHTML,
+		})
+	}
+
+	return rssFeed{
+		Version: "2.0",
+		AtomNS:  "http://www.w3.org/2005/Atom",
+		DCNS:    "http://purl.org/dc/elements/1.1/",
+		Channel: channel,
+	}
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title      string         `xml:"title"`
+	ID         string         `xml:"id"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomAuthor     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Content    atomContent    `xml:"content"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+func buildAtom(feed syndicationFeed, base string) atomFeed {
+	// Atom mewajibkan <updated>, feed kosong memakai waktu sekarang
+	updated := feed.LastModified
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+
+	atom := atomFeed{
+		Title:   feed.Title,
+		ID:      feed.SelfURL,
+		Updated: updated.UTC().Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: feed.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feed.HomeURL, Rel: "alternate"},
+		},
+	}
+
+	for _, post := range feed.Posts {
+		link := service.PostPermalink(base, post)
+		entry := atomEntry{
+			Title:     post.Title,
+			ID:        link,
+			Link:      atomLink{Href: link, Rel: "alternate"},
+			Published: post.CreatedAt.UTC().Format(time.RFC3339),
+			Updated:   post.UpdatedAt.UTC().Format(time.RFC3339),
+			Author:    atomAuthor{Name: post.User.Name},
+			Content:   atomContent{Type: "html", Body: post.ContentHTML},
+		}
+		for _, name := range tagNames(post) {
+			entry.Categories = append(entry.Categories, atomCategory{Term: name})
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return atom
+}
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []jsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+func buildJSONFeed(feed syndicationFeed, base string) jsonFeed {
+	result := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       feed.Title,
+		Description: feed.Description,
+		HomePageURL: feed.HomeURL,
+		FeedURL:     feed.SelfURL,
+		Items:       []jsonFeedItem{},
+	}
+
+	for _, post := range feed.Posts {
+		link := service.PostPermalink(base, post)
+		item := jsonFeedItem{
+			ID:            link,
+			URL:           link,
+			Title:         post.Title,
+			ContentHTML:   post.ContentHTML,
+			DatePublished: post.CreatedAt.UTC().Format(time.RFC3339),
+			DateModified:  post.UpdatedAt.UTC().Format(time.RFC3339),
+			Tags:          tagNames(post),
+		}
+		if post.User.Name != "" {
+			item.Authors = []jsonFeedAuthor{{Name: post.User.Name}}
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"blog-api/internal/models"
+)
+
+func TestSyndicationFeeds(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	author := createTestUser(t, h.db, "writer@example.com")
+	other := createTestUser(t, h.db, "other@example.com")
+
+	w := httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{
+		Title:   "Escaping <b>&</b> \"quotes\"",
+		Content: "Body with <script>alert(1)</script> & a control \x01 char",
+		Tags:    []string{"Go", "#web"},
+	}, nil, author.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	createTestPost(t, h.db, other.ID)
+
+	t.Run("RSS escapes content", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.syndication.RSSFeed(w, newTestRequest("GET", "/feed.xml", nil, nil, 0))
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		if strings.Contains(w.Body.String(), "<script>") {
+			t.Error("Expected post content to be escaped")
+		}
+
+		var feed rssFeed
+		if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+			t.Fatalf("Expected well-formed XML: %v", err)
+		}
+		if len(feed.Channel.Items) != 2 || feed.Channel.Items[1].Title != "Escaping <b>&</b> \"quotes\"" {
+			t.Errorf("Unexpected items: %+v", feed.Channel.Items)
+		}
+		if got := strings.Join(feed.Channel.Items[1].Categories, ","); got != "go,web" {
+			t.Errorf("Expected categories go,web, got %s", got)
+		}
+	})
+
+	t.Run("Atom per author", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.syndication.AtomFeed(w, newTestRequest("GET", "/authors/writer/atom.xml", nil, map[string]string{"username": "writer"}, 0))
+
+		var feed atomFeed
+		if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+			t.Fatalf("Expected well-formed XML: %v", err)
+		}
+		if len(feed.Entries) != 1 || feed.Entries[0].Content.Type != "html" {
+			t.Errorf("Expected one html entry, got %+v", feed.Entries)
+		}
+	})
+
+	t.Run("JSON Feed per tag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.syndication.JSONFeed(w, newTestRequest("GET", "/tags/go/feed.json", nil, map[string]string{"tag": "go"}, 0))
+
+		if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "application/feed+json") {
+			t.Errorf("Unexpected content type %q", ct)
+		}
+
+		var feed jsonFeed
+		json.NewDecoder(w.Body).Decode(&feed)
+		if feed.Version != "https://jsonfeed.org/version/1.1" || len(feed.Items) != 1 {
+			t.Errorf("Unexpected feed: %+v", feed)
+		}
+	})
+
+	t.Run("Unknown tag", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		h.syndication.RSSFeed(w, newTestRequest("GET", "/tags/rust/feed.xml", nil, map[string]string{"tag": "rust"}, 0))
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+		}
+	})
+}
+
+func TestSyndicationConditionalGet(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+
+	user := createTestUser(t, h.db, "cond@example.com")
+	post := createTestPost(t, h.db, user.ID)
+
+	w := httptest.NewRecorder()
+	h.syndication.RSSFeed(w, newTestRequest("GET", "/feed.xml", nil, nil, 0))
+	etag := w.Header().Get("ETag")
+	lastModified := w.Header().Get("Last-Modified")
+	if etag == "" || lastModified == "" {
+		t.Fatalf("Expected ETag and Last-Modified headers, got %v", w.Header())
+	}
+
+	req := newTestRequest("GET", "/feed.xml", nil, nil, 0)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.syndication.RSSFeed(w, req)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("Expected empty 304 for matching ETag, got %d", w.Code)
+	}
+
+	req = newTestRequest("GET", "/feed.xml", nil, nil, 0)
+	req.Header.Set("If-Modified-Since", lastModified)
+	w = httptest.NewRecorder()
+	h.syndication.RSSFeed(w, req)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for If-Modified-Since, got %d", w.Code)
+	}
+
+	// Post yang diubah menghasilkan ETag baru
+	h.db.Model(&models.Post{}).Where("id = ?", post.ID).Update("title", "Changed title")
+
+	req = newTestRequest("GET", "/feed.xml", nil, nil, 0)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	h.syndication.RSSFeed(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 after update, got %d", w.Code)
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/service"
+)
+
+type UsernameRequest struct {
+	Username string `json:"username"`
+}
+
+// UserSummary - Data user publik untuk autocomplete (tanpa email)
+type UserSummary struct {
+	ID       uint   `json:"id"`
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// UserHandler - Profil user dan pencarian username
+type UserHandler struct {
+	users *service.UserService
+}
+
+func NewUserHandler(users *service.UserService) *UserHandler {
+	return &UserHandler{users: users}
+}
+
+// UpdateUsername - Ganti username user yang login
+func (h *UserHandler) UpdateUsername(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req UsernameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.users.UpdateUsername(r.Context(), userID, req.Username)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, user)
+}
+
+// SearchUsers - Autocomplete username untuk @mention
+// Query params: prefix (wajib), limit (default 10)
+func (h *UserHandler) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	limit := 10
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+		if parsed < limit {
+			limit = parsed
+		}
+	}
+
+	users, err := h.users.Search(r.Context(), r.URL.Query().Get("prefix"), limit)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	summaries := make([]UserSummary, 0, len(users))
+	for _, user := range users {
+		summaries = append(summaries, UserSummary{ID: user.ID, Username: user.Username, Name: user.Name})
+	}
+	respondJSON(w, http.StatusOK, summaries)
+}
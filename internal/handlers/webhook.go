@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"blog-api/internal/middleware"
+	"blog-api/internal/models"
+	"blog-api/internal/service"
+
+	"github.com/gorilla/mux"
+)
+
+// WebhookRequest - Saat update field nil tidak diubah
+type WebhookRequest struct {
+	URL    *string  `json:"url"`
+	Secret *string  `json:"secret"` // Opsional, kosong saat create berarti dibuatkan secret acak
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookWithSecret - Response create/rotate secret, satu-satunya tempat secret ditampilkan
+type WebhookWithSecret struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookHandler - Subscription webhook milik user dan riwayat delivery-nya
+type WebhookHandler struct {
+	webhooks *service.WebhookService
+}
+
+func NewWebhookHandler(webhooks *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhooks: webhooks}
+}
+
+// CreateWebhook - Daftarkan endpoint webhook untuk event pada konten milik user
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	hook, err := h.webhooks.Create(r.Context(), userID, service.WebhookInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, WebhookWithSecret{Webhook: hook, Secret: hook.Secret})
+}
+
+// GetWebhooks - Daftar webhook milik user
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	webhooks, err := h.webhooks.List(r.Context(), userID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, webhooks)
+}
+
+// GetWebhook - Detail satu webhook milik user
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := webhookParams(w, r)
+	if !ok {
+		return
+	}
+
+	hook, err := h.webhooks.Get(r.Context(), userID, webhookID)
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, hook)
+}
+
+// UpdateWebhook - Ubah URL, event, status aktif atau rotate secret
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := webhookParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	hook, err := h.webhooks.Update(r.Context(), userID, webhookID, service.WebhookInput(req))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	if req.Secret != nil {
+		respondJSON(w, http.StatusOK, WebhookWithSecret{Webhook: hook, Secret: hook.Secret})
+		return
+	}
+	respondJSON(w, http.StatusOK, hook)
+}
+
+// DeleteWebhook - Hapus webhook beserta log delivery-nya (dengan transaksi)
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := webhookParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhooks.Delete(r.Context(), userID, webhookID); err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Webhook deleted successfully"})
+}
+
+// GetWebhookDeliveries - Log delivery webhook, terbaru dulu (cursor pagination)
+// Query params: limit, cursor, status=pending|succeeded|dead
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := webhookParams(w, r)
+	if !ok {
+		return
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	query := r.URL.Query()
+	deliveries, nextCursor, err := h.webhooks.Deliveries(r.Context(), userID, webhookID, query.Get("status"), service.PageOptions{
+		Limit:  limit,
+		Cursor: query.Get("cursor"),
+	})
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, PaginatedResponse{Data: deliveries, NextCursor: nextCursor})
+}
+
+// RedeliverWebhook - Kirim ulang event sebuah delivery sebagai delivery baru (log lama tetap ada)
+func (h *WebhookHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, webhookID, ok := webhookParams(w, r)
+	if !ok {
+		return
+	}
+
+	deliveryID, err := strconv.ParseUint(mux.Vars(r)["delivery_id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	redelivery, err := h.webhooks.Redeliver(r.Context(), userID, webhookID, uint(deliveryID))
+	if err != nil {
+		respondServiceError(w, r, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, redelivery)
+}
+
+// webhookParams - User yang login dan path param {id}, menulis response error jika gagal
+func webhookParams(w http.ResponseWriter, r *http.Request) (uint, uint, bool) {
+	userID, ok := middleware.GetUserID(r)
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return 0, 0, false
+	}
+
+	webhookID, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid webhook ID")
+		return 0, 0, false
+	}
+	return userID, uint(webhookID), true
+}
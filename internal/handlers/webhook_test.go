@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/webhook"
+)
+
+// webhookReceiver - Endpoint test yang mencatat request dan menjawab dengan status yang bisa diubah
+type webhookReceiver struct {
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (rcv *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	rcv.requests = append(rcv.requests, r)
+	rcv.bodies = append(rcv.bodies, body)
+	w.WriteHeader(rcv.status)
+}
+
+func getTestDeliveries(t *testing.T, h *testHandlers, hookID, userID uint) []models.WebhookDelivery {
+	w := httptest.NewRecorder()
+	h.webhooks.GetWebhookDeliveries(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(hookID)}, userID))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data []models.WebhookDelivery `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	return response.Data
+}
+
+func TestWebhookValidation(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	user := createTestUser(t, h.db, "hooks@example.com")
+
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{"Missing URL", map[string]interface{}{"events": []string{"post.created"}}},
+		{"Private URL", map[string]interface{}{"url": "http://127.0.0.1/hook", "events": []string{"post.created"}}},
+		{"Unknown Event", map[string]interface{}{"url": "https://hooks.example.com", "events": []string{"post.liked"}}},
+		{"No Events", map[string]interface{}{"url": "https://hooks.example.com"}},
+		{"Short Secret", map[string]interface{}{"url": "https://hooks.example.com", "events": []string{"*"}, "secret": "short"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.webhooks.CreateWebhook(w, newTestRequest("POST", "/", tt.body, nil, user.ID))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestWebhookDeliveryFlow(t *testing.T) {
+	t.Parallel()
+	h := setupTestDB(t)
+	h.cfg.WebhookAllowPrivate = true
+	h.cfg.WebhookMaxAttempts = 2
+
+	receiver := &webhookReceiver{status: http.StatusOK}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	author := createTestUser(t, h.db, "author@example.com")
+	reader := createTestUser(t, h.db, "reader@example.com")
+	ctx := context.Background()
+
+	w := httptest.NewRecorder()
+	h.webhooks.CreateWebhook(w, newTestRequest("POST", "/", map[string]interface{}{
+		"url":    server.URL,
+		"events": []string{models.WebhookEventPostCreated, models.WebhookEventCommentCreated},
+	}, nil, author.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var hook WebhookWithSecret
+	json.NewDecoder(w.Body).Decode(&hook)
+	if hook.Secret == "" || !hook.Active {
+		t.Fatalf("Expected generated secret and active webhook, got %+v", hook)
+	}
+
+	// Secret tidak ditampilkan lagi setelah dibuat
+	w = httptest.NewRecorder()
+	h.webhooks.GetWebhook(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(hook.ID)}, author.ID))
+	var shown map[string]interface{}
+	json.NewDecoder(w.Body).Decode(&shown)
+	if _, ok := shown["secret"]; ok {
+		t.Error("Expected secret to be hidden")
+	}
+
+	// Webhook milik user lain tidak terlihat
+	w = httptest.NewRecorder()
+	h.webhooks.GetWebhook(w, newTestRequest("GET", "/", nil, map[string]string{"id": fmt.Sprint(hook.ID)}, reader.ID))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	h.posts.CreatePost(w, newTestRequest("POST", "/", PostRequest{Title: "Hooked Post", Content: "Content for the webhook test"}, nil, author.ID))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+	var post models.Post
+	json.NewDecoder(w.Body).Decode(&post)
+
+	// post.updated tidak di-subscribe sehingga tidak masuk outbox
+	w = httptest.NewRecorder()
+	h.posts.UpdatePost(w, newTestRequest("PUT", "/", PostRequest{Title: "Hooked Post", Content: "Updated content for the hook"}, map[string]string{"id": fmt.Sprint(post.ID)}, author.ID))
+	var events int64
+	h.db.Model(&models.WebhookEvent{}).Count(&events)
+	if events != 1 {
+		t.Fatalf("Expected 1 outbox event, got %d", events)
+	}
+
+	if err := h.dispatcher.DispatchPending(ctx); err != nil {
+		t.Fatalf("DispatchPending failed: %v", err)
+	}
+	if len(receiver.requests) != 1 {
+		t.Fatalf("Expected 1 delivery request, got %d", len(receiver.requests))
+	}
+
+	req, body := receiver.requests[0], receiver.bodies[0]
+	timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.HeaderTimestamp), 10, 64)
+	if req.Header.Get(webhook.HeaderSignature) != webhook.Sign(hook.Secret, timestamp, body) {
+		t.Error("Expected valid HMAC signature")
+	}
+	if req.Header.Get(webhook.HeaderEvent) != models.WebhookEventPostCreated {
+		t.Errorf("Expected event header %s, got %s", models.WebhookEventPostCreated, req.Header.Get(webhook.HeaderEvent))
+	}
+	var envelope struct {
+		Type string `json:"type"`
+		Data struct {
+			ID    uint   `json:"id"`
+			Title string `json:"title"`
+		} `json:"data"`
+	}
+	json.Unmarshal(body, &envelope)
+	if envelope.Type != models.WebhookEventPostCreated || envelope.Data.ID != post.ID || envelope.Data.Title != "Hooked Post" {
+		t.Errorf("Unexpected envelope %s", body)
+	}
+
+	deliveries := getTestDeliveries(t, h, hook.ID, author.ID)
+	if len(deliveries) != 1 || deliveries[0].Status != models.WebhookDeliverySucceeded || deliveries[0].LastStatusCode != http.StatusOK {
+		t.Fatalf("Expected 1 succeeded delivery, got %+v", deliveries)
+	}
+
+	// Redelivery manual dengan endpoint yang sedang gagal: retry lalu dead letter
+	receiver.status = http.StatusInternalServerError
+	w = httptest.NewRecorder()
+	h.webhooks.RedeliverWebhook(w, newTestRequest("POST", "/", nil, map[string]string{
+		"id":          fmt.Sprint(hook.ID),
+		"delivery_id": fmt.Sprint(deliveries[0].ID),
+	}, author.ID))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected status %d, got %d", http.StatusAccepted, w.Code)
+	}
+
+	h.dispatcher.DispatchPending(ctx)
+	deliveries = getTestDeliveries(t, h, hook.ID, author.ID)
+	retry := deliveries[0]
+	if len(deliveries) != 2 || retry.Status != models.WebhookDeliveryPending || retry.Attempts != 1 || !retry.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("Expected pending retry scheduled in the future, got %+v", retry)
+	}
+
+	// Belum jatuh tempo, tidak dikirim ulang
+	h.dispatcher.DispatchPending(ctx)
+	if len(receiver.requests) != 2 {
+		t.Fatalf("Expected retry to wait for backoff, got %d requests", len(receiver.requests))
+	}
+
+	h.db.Model(&models.WebhookDelivery{}).Where("id = ?", retry.ID).Update("next_attempt_at", time.Now().Add(-time.Second))
+	h.dispatcher.DispatchPending(ctx)
+	deliveries = getTestDeliveries(t, h, hook.ID, author.ID)
+	if deliveries[0].Status != models.WebhookDeliveryDead || deliveries[0].Attempts != 2 || deliveries[0].LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected dead delivery after 2 attempts, got %+v", deliveries[0])
+	}
+}
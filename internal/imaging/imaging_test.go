@@ -0,0 +1,176 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/database"
+	"blog-api/internal/models"
+	"blog-api/internal/storage"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// jpegWithEXIF - JPEG dengan segment APP1 berisi Orientation dan data GPS palsu
+func jpegWithEXIF(t *testing.T, width, height, orientation int) []byte {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// TIFF little endian: IFD0 dengan Orientation dan GPS IFD pointer, diikuti "payload GPS"
+	tiff := []byte("II\x2A\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = append(tiff, 0x12, 0x01, 3, 0, 1, 0, 0, 0, byte(orientation), 0, 0, 0)
+	tiff = append(tiff, 0x25, 0x88, 4, 0, 1, 0, 0, 0, 38, 0, 0, 0)
+	tiff = append(tiff, 0, 0, 0, 0)
+	tiff = append(tiff, []byte("SECRET-GPS-LOCATION")...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestStripJPEGKeepsOrientation(t *testing.T) {
+	data := jpegWithEXIF(t, 40, 20, 6)
+	if JPEGOrientation(data) != 6 {
+		t.Fatalf("Expected orientation 6 in input, got %d", JPEGOrientation(data))
+	}
+
+	stripped, err := StripMetadata(data, "image/jpeg")
+	if err != nil {
+		t.Fatalf("StripMetadata failed: %v", err)
+	}
+	if bytes.Contains(stripped, []byte("SECRET-GPS-LOCATION")) {
+		t.Error("Expected GPS data to be removed")
+	}
+	if JPEGOrientation(stripped) != 6 {
+		t.Errorf("Expected orientation to be kept, got %d", JPEGOrientation(stripped))
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(stripped)); err != nil {
+		t.Errorf("Expected stripped JPEG to decode: %v", err)
+	}
+}
+
+func TestStripPNGTextChunks(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	data := buf.Bytes()
+
+	// Sisipkan chunk tEXt setelah IHDR (8 byte signature + 25 byte IHDR)
+	text := []byte("Comment\x00SECRET")
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(chunk, "tEXt"...)
+	chunk = append(chunk, text...)
+	chunk = append(chunk, 0, 0, 0, 0)
+	withText := append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+
+	stripped, err := StripMetadata(withText, "image/png")
+	if err != nil {
+		t.Fatalf("StripMetadata failed: %v", err)
+	}
+	if !bytes.Equal(stripped, data) {
+		t.Error("Expected tEXt chunk to be removed and the rest untouched")
+	}
+}
+
+func TestApplyOrientation(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	rotated := ApplyOrientation(src, 6)
+	if rotated.Bounds().Dx() != 2 || rotated.Bounds().Dy() != 4 {
+		t.Errorf("Expected 2x4 after orientation 6, got %v", rotated.Bounds())
+	}
+}
+
+func TestProcessCreatesVariants(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := storage.NewLocal(t.TempDir(), "/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{MediaMaxPixels: 1000, MediaVariantWidths: []int{8, 16, 64}, MediaVariantFormats: []string{"jpeg", "webp"}}
+	workers := NewWorkers(cfg, db, local)
+
+	data := jpegWithEXIF(t, 40, 20, 1)
+	local.Put(context.Background(), "1/photo.jpg", bytes.NewReader(data), int64(len(data)), "image/jpeg")
+	media := models.Media{UserID: 1, Key: "1/photo.jpg", ContentType: "image/jpeg", Size: int64(len(data)), VariantStatus: models.MediaVariantPending}
+	db.Create(&media)
+
+	if err := workers.Process(context.Background(), media.ID); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	db.Preload("Variants").First(&media, media.ID)
+	if media.VariantStatus != models.MediaVariantReady {
+		t.Errorf("Expected status ready, got %s", media.VariantStatus)
+	}
+	// Lebar 64 lebih besar dari aslinya (40) sehingga dilewati
+	if len(media.Variants) != 4 {
+		t.Fatalf("Expected 4 variants, got %d", len(media.Variants))
+	}
+	for _, variant := range media.Variants {
+		reader, err := local.Open(context.Background(), variant.Key)
+		if err != nil {
+			t.Fatalf("Variant %s not stored: %v", variant.Key, err)
+		}
+		img, _, err := image.Decode(reader)
+		reader.Close()
+		if err != nil || img.Bounds().Dx() != variant.Width || variant.Height != variant.Width/2 {
+			t.Errorf("Unexpected variant %+v (decode err %v)", variant, err)
+		}
+	}
+
+	// Diproses ulang tidak membuat variant ganda
+	if err := workers.Process(context.Background(), media.ID); err != nil {
+		t.Fatalf("Second Process failed: %v", err)
+	}
+}
+
+func TestProcessRejectsTooManyPixels(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+
+	local, err := storage.NewLocal(t.TempDir(), "/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	workers := NewWorkers(&config.Config{MediaMaxPixels: 100, MediaVariantWidths: []int{8}, MediaVariantFormats: []string{"jpeg"}}, db, local)
+
+	data := jpegWithEXIF(t, 40, 20, 1)
+	local.Put(context.Background(), "1/big.jpg", bytes.NewReader(data), int64(len(data)), "image/jpeg")
+	media := models.Media{UserID: 1, Key: "1/big.jpg", ContentType: "image/jpeg", Size: int64(len(data)), VariantStatus: models.MediaVariantPending}
+	db.Create(&media)
+
+	if err := workers.Process(context.Background(), media.ID); err == nil {
+		t.Fatal("Expected Process to reject image over the pixel limit")
+	}
+	db.First(&media, media.ID)
+	if media.VariantStatus != models.MediaVariantFailed {
+		t.Errorf("Expected status failed, got %s", media.VariantStatus)
+	}
+}
@@ -0,0 +1,241 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+var errInvalidImage = errors.New("imaging: invalid image data")
+
+// StripMetadata - Hapus metadata (EXIF termasuk GPS, XMP, IPTC, komentar) tanpa encode ulang gambar.
+// Untuk JPEG, tag Orientation dipertahankan dalam EXIF minimal agar foto tetap tampil tegak.
+func StripMetadata(data []byte, contentType string) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	default:
+		return data, nil
+	}
+}
+
+// JPEGOrientation - Nilai tag EXIF Orientation (1-8), 1 jika tidak ada
+func JPEGOrientation(data []byte) int {
+	orientation := 1
+	walkJPEG(data, func(marker byte, segment []byte) bool {
+		if marker == 0xE1 {
+			if o := exifOrientation(segment[4:]); o > 0 {
+				orientation = o
+				return false
+			}
+		}
+		return true
+	})
+	return orientation
+}
+
+// walkJPEG - Panggil fn untuk setiap segment sebelum SOS (segment termasuk marker dan length).
+// Mengembalikan offset awal SOS (sisa file yang disalin apa adanya).
+func walkJPEG(data []byte, fn func(marker byte, segment []byte) bool) (int, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0, errInvalidImage
+	}
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return 0, errInvalidImage
+		}
+		// Lewati fill byte 0xFF
+		for pos+1 < len(data) && data[pos+1] == 0xFF {
+			pos++
+		}
+		if pos+1 >= len(data) {
+			return 0, errInvalidImage
+		}
+
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			return pos, nil
+		}
+		// Marker tanpa length (TEM, RSTn)
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			pos += 2
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return 0, errInvalidImage
+		}
+		end := pos + 2 + int(binary.BigEndian.Uint16(data[pos+2:]))
+		if end > len(data) || end < pos+4 {
+			return 0, errInvalidImage
+		}
+		if !fn(marker, data[pos:end]) {
+			return pos, nil
+		}
+		pos = end
+	}
+	return 0, errInvalidImage
+}
+
+func stripJPEG(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	out.Write(data[:2])
+
+	orientation := 1
+	var kept [][]byte
+	sos, err := walkJPEG(data, func(marker byte, segment []byte) bool {
+		switch {
+		case marker == 0xE1: // EXIF / XMP
+			if o := exifOrientation(segment[4:]); o > 0 {
+				orientation = o
+			}
+		case marker == 0xED, marker == 0xFE: // IPTC (Photoshop), komentar
+		default:
+			kept = append(kept, segment)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if orientation > 1 {
+		out.Write(minimalOrientationEXIF(orientation))
+	}
+	for _, segment := range kept {
+		out.Write(segment)
+	}
+	out.Write(data[sos:])
+	return out.Bytes(), nil
+}
+
+// exifOrientation - Baca tag 0x0112 dari IFD0 payload APP1 ("Exif\0\0" + TIFF), 0 jika tidak ada
+func exifOrientation(payload []byte) int {
+	if len(payload) < 14 || string(payload[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+	tiff := payload[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value >= 1 && value <= 8 {
+				return value
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// minimalOrientationEXIF - Segment APP1 yang hanya berisi tag Orientation
+func minimalOrientationEXIF(orientation int) []byte {
+	payload := []byte("Exif\x00\x00" +
+		"MM\x00\x2A\x00\x00\x00\x08" + // TIFF header big endian, IFD0 di offset 8
+		"\x00\x01" + // 1 entry
+		"\x01\x12\x00\x03\x00\x00\x00\x01" + // Orientation, SHORT, count 1
+		string([]byte{0, byte(orientation), 0, 0}) +
+		"\x00\x00\x00\x00") // Tidak ada IFD berikutnya
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+// pngMetadataChunks - Chunk PNG yang berisi metadata
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "iTXt": true, "zTXt": true, "tIME": true}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if len(data) < 8 || string(data[:8]) != "\x89PNG\r\n\x1a\n" {
+		return nil, errInvalidImage
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:8])
+	pos := 8
+	for pos < len(data) {
+		if pos+12 > len(data) {
+			return nil, errInvalidImage
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, errInvalidImage
+		}
+
+		chunkType := string(data[pos+4 : pos+8])
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[pos:end])
+		}
+		pos = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return out.Bytes(), nil
+}
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errInvalidImage
+	}
+
+	var body bytes.Buffer
+	body.WriteString("WEBP")
+	pos := 12
+	for pos+8 <= len(data) {
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size + size%2 // Chunk di-padding ke ukuran genap
+		if size < 0 || end > len(data) {
+			if pos+8+size == len(data) {
+				end = len(data)
+			} else {
+				return nil, errInvalidImage
+			}
+		}
+
+		switch fourCC {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[pos:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04 // Flag EXIF dan XMP
+			}
+			body.Write(chunk)
+		default:
+			body.Write(data[pos:end])
+		}
+		pos = end
+	}
+
+	out := make([]byte, 8, 8+body.Len())
+	copy(out, "RIFF")
+	binary.LittleEndian.PutUint32(out[4:], uint32(body.Len()))
+	return append(out, body.Bytes()...), nil
+}
@@ -0,0 +1,103 @@
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+)
+
+// jpegQuality - Kualitas encode ulang variant JPEG
+const jpegQuality = 82
+
+// Resize - Perkecil gambar ke lebar tertentu dengan rasio tetap
+func Resize(src image.Image, width int) image.Image {
+	bounds := src.Bounds()
+	height := bounds.Dy() * width / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+	return dst
+}
+
+// ApplyOrientation - Putar/balik gambar sesuai tag EXIF Orientation agar pixel sudah tegak
+func ApplyOrientation(src image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	// Orientation 5-8 menukar lebar dan tinggi
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, src.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// Encode - Encode gambar ke format variant (jpeg atau webp lossless)
+func Encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	switch format {
+	case "jpeg":
+		// JPEG tidak punya alpha, area transparan dijadikan putih
+		flat := image.NewRGBA(img.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+		if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+	case "webp":
+		if err := nativewebp.Encode(&buf, img, nil); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("imaging: unsupported format %q", format)
+	}
+	return buf.Bytes(), nil
+}
+
+// ContentType - Content type untuk format variant
+func ContentType(format string) string {
+	return "image/" + format
+}
+
+// Extension - Ekstensi file untuk format variant
+func Extension(format string) string {
+	if format == "jpeg" {
+		return ".jpg"
+	}
+	return "." + format
+}
@@ -0,0 +1,226 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/storage"
+
+	// Decoder format gambar
+	_ "image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+	"gorm.io/gorm"
+)
+
+const (
+	// sweepInterval - Interval pengecekan media pending yang terlewat dari queue
+	sweepInterval = time.Minute
+	// processingTimeout - Media yang terlalu lama berstatus processing (worker mati) diproses ulang
+	processingTimeout = 10 * time.Minute
+)
+
+// Workers - Worker pembuat variant gambar dari queue media dan sweep berkala
+type Workers struct {
+	cfg   *config.Config
+	db    *gorm.DB
+	files storage.Storage
+	queue chan uint
+}
+
+// NewWorkers - Buat Workers, worker baru berjalan setelah Start
+func NewWorkers(cfg *config.Config, db *gorm.DB, files storage.Storage) *Workers {
+	return &Workers{cfg: cfg, db: db, files: files, queue: make(chan uint, 256)}
+}
+
+// Enqueue - Minta variant dibuat untuk media, tidak pernah blocking
+// (jika queue penuh media tetap pending dan diambil oleh sweep berikutnya)
+func (w *Workers) Enqueue(mediaID uint) {
+	select {
+	case w.queue <- mediaID:
+	default:
+	}
+}
+
+// Start - Jalankan cfg.MediaWorkers worker pembuat variant sampai ctx selesai,
+// fungsi yang dikembalikan menunggu semua worker berhenti
+func (w *Workers) Start(ctx context.Context) (wait func()) {
+	var wg sync.WaitGroup
+	for i := 0; i < w.cfg.MediaWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case mediaID := <-w.queue:
+					if err := w.Process(ctx, mediaID); err != nil {
+						slog.Error("failed to process media", "media_id", mediaID, "error", err)
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+		for {
+			w.sweep()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return wg.Wait
+}
+
+// sweep - Masukkan kembali media pending (dan processing yang macet) ke queue
+func (w *Workers) sweep() {
+	w.db.Model(&models.Media{}).
+		Where("variant_status = ? AND updated_at < ?", models.MediaVariantProcessing, time.Now().Add(-processingTimeout)).
+		Update("variant_status", models.MediaVariantPending)
+
+	var ids []uint
+	if err := w.db.Model(&models.Media{}).Where("variant_status = ?", models.MediaVariantPending).
+		Order("id ASC").Limit(cap(w.queue)).Pluck("id", &ids).Error; err != nil {
+		slog.Error("failed to load pending media", "error", err)
+		return
+	}
+	for _, id := range ids {
+		w.Enqueue(id)
+	}
+}
+
+// Process - Buat semua variant untuk satu media. Media di-claim dulu (pending → processing)
+// sehingga aman jika ID yang sama masuk queue lebih dari sekali.
+func (w *Workers) Process(ctx context.Context, mediaID uint) error {
+	claim := w.db.Model(&models.Media{}).
+		Where("id = ? AND variant_status = ?", mediaID, models.MediaVariantPending).
+		Update("variant_status", models.MediaVariantProcessing)
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return claim.Error
+	}
+
+	var media models.Media
+	if err := w.db.First(&media, mediaID).Error; err != nil {
+		return err
+	}
+
+	variants, err := w.generateVariants(ctx, media)
+	if err != nil {
+		w.db.Model(&media).Update("variant_status", models.MediaVariantFailed)
+		return err
+	}
+
+	status := models.MediaVariantReady
+	if variants == nil {
+		status = models.MediaVariantSkipped
+	}
+
+	tx := w.db.Begin()
+	if err := tx.Where("media_id = ?", media.ID).Delete(&models.MediaVariant{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(variants) > 0 {
+		if err := tx.Create(&variants).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := tx.Model(&media).Update("variant_status", status).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// ExceedsPixels - Dimensi gambar melebihi batas jumlah pixel (memory decode sebanding lebar x tinggi)
+func ExceedsPixels(width, height int, maxPixels int64) bool {
+	return int64(width)*int64(height) > maxPixels
+}
+
+// generateVariants - Decode file asli, resize ke setiap lebar yang lebih kecil dari aslinya
+// dan upload hasil encode ulang (nil untuk format yang tidak dibuatkan variant)
+func (w *Workers) generateVariants(ctx context.Context, media models.Media) ([]models.MediaVariant, error) {
+	// GIF bisa berupa animasi, resize frame pertama saja akan merusaknya
+	if media.ContentType == "image/gif" {
+		return nil, nil
+	}
+
+	reader, err := w.files.Open(ctx, media.Key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// Cek ulang dimensi dari header sebelum decode penuh (media lama atau batas yang diturunkan)
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if ExceedsPixels(imageConfig.Width, imageConfig.Height, w.cfg.MediaMaxPixels) {
+		return nil, fmt.Errorf("image %dx%d exceeds %d pixels", imageConfig.Width, imageConfig.Height, w.cfg.MediaMaxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if media.ContentType == "image/jpeg" {
+		img = ApplyOrientation(img, JPEGOrientation(data))
+	}
+
+	variants := []models.MediaVariant{}
+	base := strings.TrimSuffix(media.Key, path.Ext(media.Key))
+	for _, width := range w.cfg.MediaVariantWidths {
+		if width >= img.Bounds().Dx() {
+			continue
+		}
+
+		resized := Resize(img, width)
+		for _, format := range w.cfg.MediaVariantFormats {
+			encoded, err := Encode(resized, format)
+			if err != nil {
+				return nil, err
+			}
+
+			key := fmt.Sprintf("%s_w%d%s", base, width, Extension(format))
+			if err := w.files.Put(ctx, key, bytes.NewReader(encoded), int64(len(encoded)), ContentType(format)); err != nil {
+				return nil, err
+			}
+
+			variants = append(variants, models.MediaVariant{
+				MediaID: media.ID,
+				Width:   width,
+				Height:  resized.Bounds().Dy(),
+				Format:  format,
+				Key:     key,
+				Size:    int64(len(encoded)),
+			})
+		}
+	}
+	return variants, nil
+}
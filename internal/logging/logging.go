@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"blog-api/internal/config"
+)
+
+type contextKey string
+
+const loggerKey contextKey = "logger"
+
+// Setup - Pasang logger default sesuai LOG_FORMAT dan LOG_LEVEL. Pemanggil package log
+// (termasuk library) ikut ditulis lewat handler yang sama.
+func Setup(cfg *config.Config) error {
+	logger, err := New(os.Stderr, cfg.LogFormat, cfg.LogLevel)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
+
+// New - Logger dengan format json atau text dan level debug, info, warn atau error
+func New(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid LOG_LEVEL %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	}
+	return nil, fmt.Errorf("invalid LOG_FORMAT %q (json or text)", format)
+}
+
+// WithLogger - Simpan logger request-scoped ke context
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// FromContext - Logger dari context (membawa request_id), logger default jika tidak ada
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With - Tambah atribut ke logger di context, misal user_id setelah autentikasi
+func With(ctx context.Context, args ...any) context.Context {
+	return WithLogger(ctx, FromContext(ctx).With(args...))
+}
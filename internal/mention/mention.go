@@ -0,0 +1,48 @@
+package mention
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// MaxPerContent - Batas jumlah mention yang diproses per post/comment
+const MaxPerContent = 20
+
+// Pattern @username: huruf, angka, underscore (3-30 karakter), tidak boleh
+// diawali karakter username lain (misal email "john@example.com" bukan mention)
+var pattern = regexp.MustCompile(`(^|[^a-zA-Z0-9_@])@([a-zA-Z0-9_]{3,30})\b`)
+
+// Parse - Ambil daftar username unik (lowercase) yang di-mention di content
+func Parse(content string) []string {
+	seen := make(map[string]bool)
+	var usernames []string
+
+	for _, match := range pattern.FindAllStringSubmatch(content, -1) {
+		username := strings.ToLower(match[2])
+		if seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+
+		if len(usernames) == MaxPerContent {
+			break
+		}
+	}
+	return usernames
+}
+
+// Render - Escape content menjadi HTML dan ubah mention yang valid menjadi link profil
+func Render(content string, resolved map[string]bool) string {
+	escaped := html.EscapeString(content)
+
+	return pattern.ReplaceAllStringFunc(escaped, func(match string) string {
+		groups := pattern.FindStringSubmatch(match)
+		username := strings.ToLower(groups[2])
+		if !resolved[username] {
+			return match
+		}
+		return groups[1] + `<a href="/@` + username + `" class="mention">@` + groups[2] + `</a>`
+	})
+}
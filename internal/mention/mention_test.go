@@ -0,0 +1,37 @@
+package mention
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{"Single mention", "Hi @john_doe!", []string{"john_doe"}},
+		{"Multiple and duplicate", "@Jane and @john, thanks @jane", []string{"jane", "john"}},
+		{"Email is not a mention", "mail john@example.com", nil},
+		{"Too short", "hey @jo", nil},
+		{"Start of line after newline", "first\n@alice second", []string{"alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse(tt.content)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Parse(%q) = %v, expected %v", tt.content, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	result := Render("<b>Hi</b> @Jane and @ghost", map[string]bool{"jane": true})
+	expected := `&lt;b&gt;Hi&lt;/b&gt; <a href="/@jane" class="mention">@Jane</a> and @ghost`
+	if result != expected {
+		t.Errorf("Render() = %q, expected %q", result, expected)
+	}
+}
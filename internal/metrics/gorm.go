@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"time"
+
+	"blog-api/internal/database"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// startKey - Waktu mulai statement, disimpan per instance GORM
+const startKey = "metrics:start"
+
+// GormPlugin - Plugin GORM yang mencatat durasi setiap query ke DBQueryDuration
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "metrics"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	return database.RegisterCallbacks(db, "metrics", before, after)
+}
+
+func before(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		db.InstanceSet(startKey, time.Now())
+	}
+}
+
+func after(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		DBQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+	}
+}
+
+// InstrumentDB - Pasang GormPlugin dan ekspor statistik connection pool (sql.DB.Stats) ke reg
+// (biasanya Registry; test memakai registry sendiri)
+func InstrumentDB(db *gorm.DB, reg prometheus.Registerer) error {
+	if err := db.Use(GormPlugin{}); err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return reg.Register(collectors.NewDBStatsCollector(sqlDB, "blog"))
+}
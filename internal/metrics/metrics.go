@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace - Prefix semua metric aplikasi (runtime Go tetap go_* dan process_*)
+const namespace = "blog"
+
+// Registry - Registry sendiri (bukan prometheus.DefaultRegisterer) agar test dan library lain tidak ikut mendaftar
+var Registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequests - Jumlah request HTTP per method, template route mux dan status
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	// HTTPDuration - Latency request HTTP dalam detik
+	HTTPDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	// DBQueryDuration - Durasi query GORM per operasi dan tabel
+	DBQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "GORM query latency by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+
+	// Registrations - User yang berhasil mendaftar
+	Registrations = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "user_registrations_total",
+		Help:      "Successful user registrations.",
+	})
+
+	// Logins - Percobaan login, result success atau failure
+	Logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by result (success, failure).",
+	}, []string{"result"})
+
+	// PostsCreated - Post yang dibuat (REST, GraphQL dan gRPC)
+	PostsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "posts_created_total",
+		Help:      "Posts created.",
+	})
+
+	// CommentsCreated - Comment yang dibuat (REST, GraphQL dan gRPC)
+	CommentsCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "comments_created_total",
+		Help:      "Comments created.",
+	})
+
+	// RateLimited - Request yang ditolak rate limiter (429) per policy
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by the rate limiter by policy.",
+	}, []string{"policy"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPDuration,
+		DBQueryDuration,
+		Registrations,
+		Logins,
+		PostsCreated,
+		CommentsCreated,
+		RateLimited,
+	)
+
+	// Label yang sudah diketahui langsung diekspor dengan nilai 0
+	Logins.WithLabelValues("success")
+	Logins.WithLabelValues("failure")
+}
+
+// Handler - Endpoint /metrics dalam format teks Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// scrape - Isi /metrics dalam format teks
+func scrape(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	return string(body)
+}
+
+// sample - Nilai satu series dari hasil scrape, 0 jika belum ada
+func sample(body, series string) float64 {
+	for _, line := range strings.Split(body, "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			v, _ := strconv.ParseFloat(value, 64)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestInstrumentDB(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Registry sendiri agar collector statistik pool bisa didaftarkan ulang (go test -count=N)
+	reg := prometheus.NewRegistry()
+	if err := InstrumentDB(db, reg); err != nil {
+		t.Fatalf("InstrumentDB failed: %v", err)
+	}
+
+	// Histogram query tetap global, jadi yang dicek selisihnya
+	queries := []string{
+		`blog_db_query_duration_seconds_count{operation="create",table="widgets"}`,
+		`blog_db_query_duration_seconds_count{operation="query",table="widgets"}`,
+		`blog_db_query_duration_seconds_count{operation="raw",table="unknown"}`,
+	}
+	before := scrape(t, Handler())
+
+	type widget struct {
+		ID   uint
+		Name string
+	}
+	db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)")
+	db.Create(&widget{Name: "a"})
+	var widgets []widget
+	db.Find(&widgets)
+
+	after := scrape(t, Handler())
+	for _, series := range queries {
+		if delta := sample(after, series) - sample(before, series); delta != 1 {
+			t.Errorf("Expected %s to increase by 1, got %v", series, delta)
+		}
+	}
+	for _, expected := range []string{`blog_logins_total{result="failure"}`, `go_goroutines`} {
+		if !strings.Contains(after, expected) {
+			t.Errorf("Expected metrics to contain %s", expected)
+		}
+	}
+
+	pool := scrape(t, promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	if !strings.Contains(pool, `go_sql_max_open_connections{db_name="blog"}`) {
+		t.Errorf("Expected connection pool stats, got %s", pool)
+	}
+}
@@ -1,20 +1,27 @@
 package middleware
 
 import (
-	"context"
 	"net/http"
 	"strings"
 
-	"blog-api/internal/config"
-
-	"github.com/golang-jwt/jwt/v5"
+	"blog-api/internal/service"
 )
 
 type contextKey string
 
 const UserIDKey contextKey = "user_id"
 
-func AuthMiddleware(next http.Handler) http.Handler {
+// Auth - Middleware autentikasi JWT dengan TokenService yang di-inject
+type Auth struct {
+	tokens *service.TokenService
+}
+
+func NewAuth(tokens *service.TokenService) *Auth {
+	return &Auth{tokens: tokens}
+}
+
+// Required - Tolak request tanpa token yang valid dengan 401
+func (a *Auth) Required(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Ambil token dari Authorization header
 		authHeader := r.Header.Get("Authorization")
@@ -23,55 +30,90 @@ func AuthMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Format: "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			respondError(w, http.StatusUnauthorized, "Invalid authorization format")
+		userID, errMsg := a.parseToken(authHeader)
+		if errMsg != "" {
+			respondError(w, http.StatusUnauthorized, errMsg)
 			return
 		}
 
-		tokenString := parts[1]
+		// Simpan user_id ke context
+		next.ServeHTTP(w, withUserID(r, userID))
+	})
+}
 
-		// Parse dan validasi token
-		cfg := config.LoadConfig()
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			// Validasi signing method
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(cfg.JWTSecret), nil
-		})
+// Optional - Varian Required untuk public routes: request tanpa
+// Authorization header diteruskan sebagai anonymous, token valid ditempel ke
+// context, tapi token yang malformed atau expired tetap ditolak dengan 401
+func (a *Auth) Optional(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-		if err != nil || !token.Valid {
-			respondError(w, http.StatusUnauthorized, "Invalid or expired token")
+		userID, errMsg := a.parseToken(authHeader)
+		if errMsg != "" {
+			respondError(w, http.StatusUnauthorized, errMsg)
 			return
 		}
 
-		// Ekstrak user_id dari claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			respondError(w, http.StatusUnauthorized, "Invalid token claims")
+		next.ServeHTTP(w, withUserID(r, userID))
+	})
+}
+
+// WebSocket - Varian Required untuk endpoint WebSocket: API WebSocket browser tidak bisa
+// mengirim header Authorization saat handshake, sehingga token juga diterima lewat query access_token
+func (a *Auth) WebSocket(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if token := r.URL.Query().Get("access_token"); authHeader == "" && token != "" {
+			authHeader = "Bearer " + token
+		}
+		if authHeader == "" {
+			respondError(w, http.StatusUnauthorized, "Authorization header or access_token required")
 			return
 		}
 
-		userID, ok := claims["user_id"].(float64)
-		if !ok {
-			respondError(w, http.StatusUnauthorized, "Invalid user_id in token")
+		userID, errMsg := a.parseToken(authHeader)
+		if errMsg != "" {
+			respondError(w, http.StatusUnauthorized, errMsg)
 			return
 		}
 
-		// Simpan user_id ke context
-		ctx := context.WithValue(r.Context(), UserIDKey, uint(userID))
-		next.ServeHTTP(w, r.WithContext(ctx))
+		next.ServeHTTP(w, withUserID(r, userID))
 	})
 }
 
+// parseToken - Validasi Authorization header dan ambil user_id,
+// mengembalikan pesan error jika token tidak valid
+func (a *Auth) parseToken(authHeader string) (uint, string) {
+	// Format: "Bearer <token>"
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return 0, "Invalid authorization format"
+	}
+
+	userID, err := a.tokens.Validate(parts[1])
+	if err != nil {
+		return 0, err.Error()
+	}
+	return userID, ""
+}
+
 // Helper untuk mengambil user_id dari context
 func GetUserID(r *http.Request) (uint, bool) {
 	userID, ok := r.Context().Value(UserIDKey).(uint)
 	return userID, ok
 }
 
+// IsAuthenticated - Helper untuk handler di route Auth.Optional, true jika
+// request membawa token yang valid
+func IsAuthenticated(r *http.Request) bool {
+	_, ok := GetUserID(r)
+	return ok
+}
+
 func respondError(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"blog-api/internal/service"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testJWTSecret = "test-secret"
+
+// testAuth - Middleware auth dengan secret yang sama seperti signTestToken
+var testAuth = NewAuth(service.NewTokenService(testJWTSecret))
+
+func signTestToken(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return signed
+}
+
+func TestOptionalAuth(t *testing.T) {
+	validToken := signTestToken(t, jwt.MapClaims{
+		"user_id": 7,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	expiredToken := signTestToken(t, jwt.MapClaims{
+		"user_id": 7,
+		"exp":     time.Now().Add(-time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name           string
+		authHeader     string
+		expectedStatus int
+		expectUser     bool
+	}{
+		{"No token", "", http.StatusOK, false},
+		{"Valid token", "Bearer " + validToken, http.StatusOK, true},
+		{"Malformed header", "Token " + validToken, http.StatusUnauthorized, false},
+		{"Invalid token", "Bearer not-a-jwt", http.StatusUnauthorized, false},
+		{"Expired token", "Bearer " + expiredToken, http.StatusUnauthorized, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var authenticated bool
+			var userID uint
+			handler := testAuth.Optional(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authenticated = IsAuthenticated(r)
+				userID, _ = GetUserID(r)
+			}))
+
+			req := httptest.NewRequest("GET", "/api/posts", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if authenticated != tt.expectUser {
+				t.Errorf("Expected authenticated %v, got %v", tt.expectUser, authenticated)
+			}
+			if tt.expectUser && userID != 7 {
+				t.Errorf("Expected user_id 7, got %d", userID)
+			}
+		})
+	}
+}
+
+func TestRequiredAuthRequiresToken(t *testing.T) {
+	called := false
+	handler := testAuth.Required(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("POST", "/api/posts", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+	if called {
+		t.Error("Expected handler not to be called without token")
+	}
+}
+
+func TestWebSocketAuthQueryToken(t *testing.T) {
+	validToken := signTestToken(t, jwt.MapClaims{
+		"user_id": 7,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+	}{
+		{"Query token", "/api/posts/1/presence?access_token=" + validToken, http.StatusOK},
+		{"Invalid query token", "/api/posts/1/presence?access_token=not-a-jwt", http.StatusUnauthorized},
+		{"No token", "/api/posts/1/presence", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userID uint
+			handler := testAuth.WebSocket(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				userID, _ = GetUserID(r)
+			}))
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest("GET", tt.target, nil))
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && userID != 7 {
+				t.Errorf("Expected user_id 7, got %d", userID)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"blog-api/internal/logging"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader - Header request ID, diteruskan dari client/proxy atau dibuat baru
+const RequestIDHeader = "X-Request-ID"
+
+const (
+	requestIDKey   contextKey = "request_id"
+	requestInfoKey contextKey = "request_info"
+)
+
+// maxRequestIDLength - Request ID dari client yang lebih panjang diganti agar log tidak bisa dibanjiri
+const maxRequestIDLength = 128
+
+// requestInfo - Diisi middleware di dalam router (route template, user) lalu dibaca access log
+type requestInfo struct {
+	route  string
+	userID uint
+}
+
+// RequestLogger - Pasang X-Request-ID, logger request-scoped di context (dengan trace_id jika
+// Tracing dipasang di luarnya), dan tulis satu baris access log setelah response selesai
+func RequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		info, r := withRequestInfo(r)
+		logger := slog.Default().With("request_id", requestID)
+		// Trace ID dari middleware Tracing, agar log bisa dicari dari trace dan sebaliknya
+		if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+			logger = logger.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+		}
+		ctx := context.WithValue(r.Context(), requestIDKey, requestID)
+		ctx = logging.WithLogger(ctx, logger)
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		attrs := []any{
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"bytes", rec.bytes,
+			"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+		}
+		if info.route != "" {
+			attrs = append(attrs, "route", info.route)
+		}
+		if info.userID != 0 {
+			attrs = append(attrs, "user_id", info.userID)
+		}
+
+		level := slog.LevelInfo
+		if rec.status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logger.Log(ctx, level, "request completed", attrs...)
+	})
+}
+
+// RouteTemplate - Catat template route mux (misal /api/posts/{id}) untuk access log,
+// dipasang dengan router.Use karena route baru diketahui setelah mux melakukan matching
+func RouteTemplate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if info, ok := r.Context().Value(requestInfoKey).(*requestInfo); ok {
+			if route := mux.CurrentRoute(r); route != nil {
+				info.route, _ = route.GetPathTemplate()
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withRequestInfo - requestInfo bersama untuk RequestLogger, Metrics dan Tracing,
+// dibuat oleh middleware terluar dan dipakai ulang oleh yang di dalamnya
+func withRequestInfo(r *http.Request) (*requestInfo, *http.Request) {
+	if info, ok := r.Context().Value(requestInfoKey).(*requestInfo); ok {
+		return info, r
+	}
+	info := &requestInfo{}
+	return info, r.WithContext(context.WithValue(r.Context(), requestInfoKey, info))
+}
+
+// GetRequestID - Request ID dari context, kosong jika RequestLogger tidak dipasang
+func GetRequestID(r *http.Request) string {
+	requestID, _ := r.Context().Value(requestIDKey).(string)
+	return requestID
+}
+
+// withUserID - Simpan user_id ke context, access log dan logger request-scoped
+func withUserID(r *http.Request, userID uint) *http.Request {
+	ctx := context.WithValue(r.Context(), UserIDKey, userID)
+	if info, ok := ctx.Value(requestInfoKey).(*requestInfo); ok {
+		info.userID = userID
+		ctx = logging.With(ctx, "user_id", userID)
+	}
+	return r.WithContext(ctx)
+}
+
+// validRequestID - Hanya karakter aman untuk log dan header: huruf, angka, - _ . :
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-' || c == '_' || c == '.' || c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// responseRecorder - Catat status dan jumlah byte response. Flush dan Hijack diteruskan agar
+// SSE dan WebSocket tetap jalan; Unwrap untuk http.ResponseController (SetWriteDeadline, dll)
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+func (rw *responseRecorder) WriteHeader(status int) {
+	if !rw.wroteHeader {
+		rw.status = status
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+func (rw *responseRecorder) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	n, err := rw.ResponseWriter.Write(b)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+func (rw *responseRecorder) Flush() {
+	http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+func (rw *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.status = http.StatusSwitchingProtocols
+		rw.wroteHeader = true
+	}
+	return conn, buf, err
+}
+
+func (rw *responseRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
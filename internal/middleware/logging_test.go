@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-api/internal/logging"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+)
+
+// captureLogs - Arahkan logger default ke buffer JSON selama test
+func captureLogs(t *testing.T) *bytes.Buffer {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "json", "debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buf
+}
+
+// logLines - Decode baris log JSON yang membawa request_id
+func logLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid log line %q: %v", line, err)
+		}
+		if _, ok := entry["request_id"]; ok {
+			lines = append(lines, entry)
+		}
+	}
+	return lines
+}
+
+func TestRequestLogger(t *testing.T) {
+	logs := captureLogs(t)
+	token := signTestToken(t, jwt.MapClaims{"user_id": 7, "exp": time.Now().Add(time.Hour).Unix()})
+
+	router := mux.NewRouter()
+	router.Use(RouteTemplate)
+	router.Handle("/api/posts/{id}", testAuth.Required(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("handler log")
+		if _, ok := w.(http.Flusher); !ok {
+			t.Error("Expected wrapped writer to support http.Flusher")
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})))
+	handler := RequestLogger(router)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/posts/42", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(RequestIDHeader, "req-123")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if got := rr.Header().Get(RequestIDHeader); got != "req-123" {
+		t.Errorf("Expected incoming request ID to be echoed, got %q", got)
+	}
+
+	lines := logLines(t, logs)
+	if len(lines) != 2 {
+		t.Fatalf("Expected handler log and access log, got %v", lines)
+	}
+	if lines[0]["request_id"] != "req-123" || lines[0]["user_id"] != float64(7) {
+		t.Errorf("Expected handler log to carry request and user ID, got %v", lines[0])
+	}
+	access := lines[1]
+	expected := map[string]any{
+		"request_id": "req-123",
+		"method":     "POST",
+		"route":      "/api/posts/{id}",
+		"path":       "/api/posts/42",
+		"status":     float64(http.StatusCreated),
+		"bytes":      float64(5),
+		"user_id":    float64(7),
+	}
+	for key, value := range expected {
+		if access[key] != value {
+			t.Errorf("Expected access log %s=%v, got %v", key, value, access[key])
+		}
+	}
+}
+
+func TestRequestLoggerGeneratesRequestID(t *testing.T) {
+	logs := captureLogs(t)
+	handler := RequestLogger(mux.NewRouter())
+
+	for _, incoming := range []string{"", "bad id\nwith newline", strings.Repeat("a", maxRequestIDLength+1)} {
+		req := httptest.NewRequest(http.MethodGet, "/missing", nil)
+		req.Header.Set(RequestIDHeader, incoming)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		got := rr.Header().Get(RequestIDHeader)
+		if got == "" || got == incoming || !validRequestID(got) {
+			t.Errorf("Expected generated request ID for %q, got %q", incoming, got)
+		}
+	}
+
+	// Route tidak ditemukan tetap tercatat, tanpa route template
+	access := logLines(t, logs)[0]
+	if access["status"] != float64(http.StatusNotFound) || access["route"] != nil {
+		t.Errorf("Expected 404 access log without route, got %v", access)
+	}
+}
+
+func TestResponseRecorderUnwrap(t *testing.T) {
+	rr := httptest.NewRecorder()
+	rec := &responseRecorder{ResponseWriter: rr, status: http.StatusOK}
+
+	// ResponseController menemukan Flush milik writer asli lewat Unwrap
+	rec.Write([]byte("data"))
+	if err := http.NewResponseController(rec).Flush(); err != nil || !rr.Flushed {
+		t.Errorf("Expected flush through recorder, got %v", err)
+	}
+	if rec.bytes != 4 {
+		t.Errorf("Expected 4 bytes recorded, got %d", rec.bytes)
+	}
+}
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"blog-api/internal/metrics"
+)
+
+// unmatchedRoute - Label route untuk request yang tidak cocok dengan route mana pun (404/405),
+// path asli tidak dipakai agar jumlah label tetap terbatas
+const unmatchedRoute = "unmatched"
+
+// Metrics - Catat jumlah dan latency request per method, template route dan status.
+// Dipasang di luar router (route template diisi RouteTemplate di dalam router).
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		info, r := withRequestInfo(r)
+
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		route := info.route
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(rec.status)
+		method := methodLabel(r.Method)
+		metrics.HTTPRequests.WithLabelValues(method, route, status).Inc()
+		metrics.HTTPDuration.WithLabelValues(method, route, status).Observe(time.Since(start).Seconds())
+	})
+}
+
+// methodLabel - Method di luar daftar standar digabung menjadi OTHER (method bebas diisi client)
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return method
+	}
+	return "OTHER"
+}
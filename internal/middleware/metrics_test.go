@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"blog-api/internal/metrics"
+
+	"github.com/gorilla/mux"
+)
+
+// scrapeMetrics - Isi /metrics dalam format teks
+func scrapeMetrics() string {
+	rr := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, _ := io.ReadAll(rr.Body)
+	return string(body)
+}
+
+// metricSample - Nilai satu series dari hasil scrape, 0 jika belum ada
+func metricSample(body, series string) float64 {
+	for _, line := range strings.Split(body, "\n") {
+		if value, ok := strings.CutPrefix(line, series+" "); ok {
+			v, _ := strconv.ParseFloat(value, 64)
+			return v
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(RouteTemplate)
+	router.HandleFunc("/api/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}).Methods("GET")
+	handler := Metrics(router)
+
+	// Counter global, jadi yang dicek selisihnya (aman untuk go test -count=N)
+	before := scrapeMetrics()
+	for _, path := range []string{"/api/metrics-test/1", "/api/metrics-test/2", "/nope"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("PURGE", "/nope", nil))
+	after := scrapeMetrics()
+
+	for series, want := range map[string]float64{
+		`blog_http_requests_total{method="GET",route="/api/metrics-test/{id}",status="202"}`:                 2,
+		`blog_http_requests_total{method="GET",route="unmatched",status="404"}`:                              1,
+		`blog_http_requests_total{method="OTHER",route="unmatched",status="404"}`:                            1,
+		`blog_http_request_duration_seconds_count{method="GET",route="/api/metrics-test/{id}",status="202"}`: 2,
+	} {
+		if delta := metricSample(after, series) - metricSample(before, series); delta != want {
+			t.Errorf("Expected %s to increase by %v, got %v", series, want, delta)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/metrics"
+	"blog-api/internal/ratelimit"
+)
+
+// RateLimiter - Token bucket per route, kebijakan dari config.RateLimitPolicies
+type RateLimiter struct {
+	store    ratelimit.Store
+	policies map[string]config.RateLimitPolicy
+	trusted  []netip.Prefix
+	now      func() time.Time
+}
+
+// NewRateLimiter - Buat RateLimiter; nil store berarti rate limiting dimatikan
+func NewRateLimiter(cfg *config.Config, store ratelimit.Store) *RateLimiter {
+	return &RateLimiter{
+		store:    store,
+		policies: cfg.RateLimitPolicies,
+		trusted:  cfg.TrustedProxies,
+		now:      time.Now,
+	}
+}
+
+// Limit - Middleware untuk satu route dengan policy name. Pasang setelah Auth.Required untuk
+// policy KeyBy "user". Route tanpa policy (atau "off") dilewatkan tanpa limit.
+func (l *RateLimiter) Limit(name string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		policy, ok := l.policies[name]
+		if l.store == nil || !ok {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, err := l.store.Take(r.Context(), l.key(name, policy, r), policy, l.now())
+			if err != nil {
+				// Fail open: gangguan store tidak boleh memblokir login dan registrasi
+				logging.FromContext(r.Context()).Error("rate limiter unavailable", "policy", name, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			SetRateLimitHeaders(w.Header(), policy, res)
+			if !res.Allowed {
+				metrics.RateLimited.WithLabelValues(name).Inc()
+				respondError(w, http.StatusTooManyRequests, "Too many requests, please try again later")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetRateLimitHeaders - Header RateLimit-* dari hasil Take, plus Retry-After jika request ditolak.
+// Dipakai juga handler untuk limit yang dicek di service (comment).
+func SetRateLimitHeaders(h http.Header, policy config.RateLimitPolicy, res ratelimit.Result) {
+	h.Set("RateLimit-Limit", strconv.Itoa(policy.Requests))
+	h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(res.Reset)))
+	h.Set("RateLimit-Policy", strconv.Itoa(policy.Requests)+";w="+strconv.Itoa(ceilSeconds(policy.Period)))
+	if !res.Allowed {
+		h.Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(res.RetryAfter))))
+	}
+}
+
+// key - Bucket per policy dan user login (KeyBy "user") atau IP client
+func (l *RateLimiter) key(name string, policy config.RateLimitPolicy, r *http.Request) string {
+	if policy.KeyBy == "user" {
+		if userID, ok := GetUserID(r); ok {
+			return name + ":user:" + strconv.FormatUint(uint64(userID), 10)
+		}
+	}
+	return name + ":ip:" + ClientIP(r, l.trusted)
+}
+
+// ClientIP - IP client dari RemoteAddr. Jika koneksi berasal dari trusted proxy, X-Forwarded-For
+// dibaca dari kanan dan IP pertama yang bukan trusted proxy dipakai (IP paling kiri bisa dipalsukan client).
+func ClientIP(r *http.Request, trusted []netip.Prefix) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !isTrusted(addr, trusted) {
+		return host
+	}
+
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		addr = hop
+		if !isTrusted(hop, trusted) {
+			break
+		}
+	}
+	return addr.Unmap().String()
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	addr = addr.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ceilSeconds - Durasi dalam detik, dibulatkan ke atas untuk header
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
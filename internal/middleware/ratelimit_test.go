@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	cfg := &config.Config{RateLimitPolicies: map[string]config.RateLimitPolicy{
+		"login":   {Requests: 2, Period: time.Minute, KeyBy: "ip"},
+		"comment": {Requests: 1, Period: time.Minute, KeyBy: "user"},
+	}}
+	limiter := NewRateLimiter(cfg, ratelimit.NewMemoryStore())
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	login := limiter.Limit("login")(ok)
+
+	send := func(handler http.Handler, remoteAddr string, userID uint) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/api/login", nil)
+		req.RemoteAddr = remoteAddr
+		if userID != 0 {
+			req = withUserID(req, userID)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send(login, "10.0.0.1:1234", 0)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	for header, expected := range map[string]string{
+		"RateLimit-Limit":     "2",
+		"RateLimit-Remaining": "1",
+		"RateLimit-Reset":     "30",
+		"RateLimit-Policy":    "2;w=60",
+	} {
+		if got := w.Header().Get(header); got != expected {
+			t.Errorf("Expected %s %q, got %q", header, expected, got)
+		}
+	}
+
+	send(login, "10.0.0.1:1234", 0)
+	w = send(login, "10.0.0.1:5678", 0)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected status 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Errorf("Expected Retry-After 30, got %q", got)
+	}
+	if w := send(login, "10.0.0.2:1234", 0); w.Code != http.StatusOK {
+		t.Errorf("Expected other IP to be allowed, got %d", w.Code)
+	}
+
+	// Policy "user" dihitung per user, bukan per IP
+	comment := limiter.Limit("comment")(ok)
+	send(comment, "10.0.0.3:1234", 7)
+	if w := send(comment, "10.0.0.3:1234", 8); w.Code != http.StatusOK {
+		t.Errorf("Expected other user on the same IP to be allowed, got %d", w.Code)
+	}
+	if w := send(comment, "10.0.0.4:1234", 7); w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected same user on another IP to be limited, got %d", w.Code)
+	}
+
+	// Route tanpa policy tidak dibatasi
+	if w := send(limiter.Limit("register")(ok), "10.0.0.1:1234", 0); w.Header().Get("RateLimit-Limit") != "" {
+		t.Error("Expected no rate limit headers for route without policy")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		expected   string
+	}{
+		{"Direct client", "203.0.113.5:1234", "", "203.0.113.5"},
+		{"Untrusted peer ignores header", "203.0.113.5:1234", "198.51.100.1", "203.0.113.5"},
+		{"Trusted proxy", "10.0.0.1:1234", "198.51.100.1", "198.51.100.1"},
+		{"Spoofed leftmost hop", "10.0.0.1:1234", "1.2.3.4, 198.51.100.1, 10.0.0.2", "198.51.100.1"},
+		{"All hops trusted", "10.0.0.1:1234", "10.0.0.3, 10.0.0.2", "10.0.0.3"},
+		{"Invalid hop stops walk", "10.0.0.1:1234", "198.51.100.1, garbage", "10.0.0.1"},
+		{"IPv6 trusted proxy", "[::1]:1234", "2001:db8::1", "2001:db8::1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if got := ClientIP(req, trusted); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
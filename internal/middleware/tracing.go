@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+
+	"blog-api/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing - Span server per request, melanjutkan trace dari header traceparent jika ada.
+// Nama span memakai template route mux (misal "GET /api/posts/{id}") setelah routing selesai.
+func Tracing(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		method := methodLabel(r.Method)
+		ctx, span := tracing.Tracer().Start(ctx, method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("user_agent.original", r.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		info, r := withRequestInfo(r.WithContext(ctx))
+		rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if info.route != "" {
+			span.SetName(method + " " + info.route)
+			span.SetAttributes(attribute.String("http.route", info.route))
+		}
+		if info.userID != 0 {
+			span.SetAttributes(attribute.Int64("enduser.id", int64(info.userID)))
+		}
+		span.SetAttributes(attribute.Int("http.response.status_code", rec.status))
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+		}
+	})
+}
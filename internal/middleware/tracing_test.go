@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	logs := captureLogs(t)
+
+	router := mux.NewRouter()
+	router.Use(RouteTemplate)
+	router.HandleFunc("/api/posts/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	handler := Tracing(RequestLogger(router))
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/api/posts/1", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected one server span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /api/posts/{id}" {
+		t.Errorf("Expected span named by route template, got %q", span.Name())
+	}
+	if span.SpanContext().TraceID().String() != traceID || span.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Error("Expected span to continue the incoming traceparent")
+	}
+	if span.Status().Code.String() != "Error" {
+		t.Errorf("Expected error status for 500, got %v", span.Status())
+	}
+
+	access := logLines(t, logs)[0]
+	if access["trace_id"] != traceID || access["span_id"] != span.SpanContext().SpanID().String() {
+		t.Errorf("Expected access log to carry trace and span ID, got %v", access)
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Bookmark struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_post,priority:1" json:"user_id"`
+	PostID    uint      `gorm:"not null;uniqueIndex:idx_bookmarks_user_post,priority:2;index" json:"post_id"`
+	Post      Post      `gorm:"foreignKey:PostID" json:"post,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -7,13 +7,17 @@ import (
 )
 
 type Comment struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Content   string         `gorm:"type:text;not null" json:"content"`
-	UserID    uint           `gorm:"not null;index" json:"user_id"`
-	PostID    uint           `gorm:"not null;index" json:"post_id"`
-	User      User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Post      Post           `gorm:"foreignKey:PostID" json:"post,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint             `gorm:"primaryKey" json:"id"`
+	Content         string           `gorm:"type:text;not null" json:"content"`
+	ContentHTML     string           `gorm:"-" json:"content_html,omitempty"` // Content yang sudah di-escape dengan link mention
+	UserID          uint             `gorm:"not null;index" json:"user_id"`
+	PostID          uint             `gorm:"not null;index;index:idx_comments_post_score,priority:1" json:"post_id"`
+	Score           int64            `gorm:"not null;default:0;index:idx_comments_post_score,priority:2" json:"score"` // Total reactions, dipakai untuk sort=top
+	Reactions       map[string]int64 `gorm:"-" json:"reactions,omitempty"`
+	ViewerReactions []string         `gorm:"-" json:"viewer_reactions,omitempty"`
+	User            User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Post            Post             `gorm:"foreignKey:PostID" json:"post,omitempty"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt   `gorm:"index" json:"-"`
 }
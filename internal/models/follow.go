@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type Follow struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	FollowerID uint      `gorm:"not null;uniqueIndex:idx_follows_pair,priority:1" json:"follower_id"`
+	FolloweeID uint      `gorm:"not null;uniqueIndex:idx_follows_pair,priority:2;index" json:"followee_id"`
+	Follower   User      `gorm:"foreignKey:FollowerID" json:"follower,omitempty"`
+	Followee   User      `gorm:"foreignKey:FolloweeID" json:"followee,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// FeedEntry - Tabel fan-out feed (dipakai jika FEED_FANOUT aktif),
+// satu baris per pembaca per post dari author yang di-follow
+type FeedEntry struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	PostID    uint      `gorm:"primaryKey;autoIncrement:false" json:"post_id"`
+	AuthorID  uint      `gorm:"not null;index" json:"author_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Status pembuatan variant ukuran gambar oleh background worker
+const (
+	MediaVariantPending    = "pending"
+	MediaVariantProcessing = "processing"
+	MediaVariantReady      = "ready"
+	MediaVariantFailed     = "failed"
+	MediaVariantSkipped    = "skipped" // Format yang tidak dibuatkan variant (misal GIF animasi)
+)
+
+// Media - File yang di-upload user, isi file disimpan di storage backend (local / S3)
+type Media struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	UserID        uint              `gorm:"not null;index" json:"user_id"`
+	Key           string            `gorm:"size:255;not null;uniqueIndex" json:"key"` // Path object di storage
+	Filename      string            `gorm:"size:255" json:"filename"`                 // Nama file asli dari client
+	ContentType   string            `gorm:"size:100;not null" json:"content_type"`    // Hasil sniffing, bukan dari header client
+	Size          int64             `gorm:"not null" json:"size"`
+	Width         int               `json:"width,omitempty"`
+	Height        int               `json:"height,omitempty"`
+	URL           string            `gorm:"-" json:"url"`
+	VariantStatus string            `gorm:"size:20;not null;default:pending;index" json:"variant_status"`
+	Variants      []MediaVariant    `gorm:"foreignKey:MediaID" json:"variants,omitempty"`
+	SrcSet        map[string]string `gorm:"-" json:"srcset,omitempty"` // Per format, siap dipakai di atribut srcset
+	CreatedAt     time.Time         `json:"created_at"`
+	UpdatedAt     time.Time         `json:"updated_at"`
+}
+
+// MediaVariant - Versi gambar yang di-resize dan di-encode ulang (tanpa metadata EXIF)
+type MediaVariant struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	MediaID   uint      `gorm:"not null;uniqueIndex:idx_media_variants_unique,priority:1" json:"-"`
+	Width     int       `gorm:"not null;uniqueIndex:idx_media_variants_unique,priority:2" json:"width"`
+	Height    int       `gorm:"not null" json:"height"`
+	Format    string    `gorm:"size:10;not null;uniqueIndex:idx_media_variants_unique,priority:3" json:"format"`
+	Key       string    `gorm:"size:255;not null" json:"-"`
+	Size      int64     `gorm:"not null" json:"size"`
+	URL       string    `gorm:"-" json:"url"`
+	CreatedAt time.Time `json:"-"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// Mention - User yang di-mention (@username) di post atau comment,
+// TargetType memakai konstanta yang sama dengan Reaction
+type Mention struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_mentions_unique,priority:3;index" json:"user_id"`
+	TargetType string    `gorm:"size:20;not null;uniqueIndex:idx_mentions_unique,priority:1" json:"target_type"`
+	TargetID   uint      `gorm:"not null;uniqueIndex:idx_mentions_unique,priority:2" json:"target_id"`
+	PostID     uint      `gorm:"not null;index" json:"post_id"`
+	AuthorID   uint      `gorm:"not null" json:"author_id"`
+	User       User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// Tipe notifikasi
+const (
+	NotificationTypeComment  = "comment"
+	NotificationTypeReaction = "reaction"
+	NotificationTypeMention  = "mention"
+)
+
+type Notification struct {
+	ID         uint       `gorm:"primaryKey" json:"id"`
+	UserID     uint       `gorm:"not null;index:idx_notifications_user_latest,priority:1" json:"user_id"` // Penerima notifikasi
+	Type       string     `gorm:"size:32;not null" json:"type"`
+	ActorID    uint       `gorm:"not null" json:"actor_id"` // Actor terakhir
+	Actor      User       `gorm:"foreignKey:ActorID" json:"actor,omitempty"`
+	ActorCount int        `gorm:"not null;default:1" json:"actor_count"` // Jumlah actor berbeda (coalescing)
+	PostID     *uint      `json:"post_id,omitempty"`
+	CommentID  *uint      `json:"comment_id,omitempty"`
+	Message    string     `gorm:"not null" json:"message"`
+	ReadAt     *time.Time `gorm:"index" json:"read_at"`
+	LatestAt   time.Time  `gorm:"not null;index:idx_notifications_user_latest,priority:2" json:"latest_at"` // Aktivitas terakhir, dipakai untuk urutan
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// NotificationActor - Actor yang sudah tergabung dalam satu notifikasi
+type NotificationActor struct {
+	NotificationID uint `gorm:"primaryKey;autoIncrement:false"`
+	UserID         uint `gorm:"primaryKey;autoIncrement:false"`
+}
+
+// NotificationPreference - Preferensi notifikasi per user,
+// user tanpa baris di tabel ini menerima semua notifikasi
+type NotificationPreference struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Comment   bool      `gorm:"not null" json:"comment"`
+	Reaction  bool      `gorm:"not null" json:"reaction"`
+	Mention   bool      `gorm:"not null;default:true" json:"mention"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// DefaultNotificationPreference - Preferensi default (semua aktif)
+func DefaultNotificationPreference(userID uint) NotificationPreference {
+	return NotificationPreference{UserID: userID, Comment: true, Reaction: true, Mention: true}
+}
+
+// Enabled - Cek apakah tipe notifikasi diaktifkan user
+func (p NotificationPreference) Enabled(notificationType string) bool {
+	switch notificationType {
+	case NotificationTypeComment:
+		return p.Comment
+	case NotificationTypeReaction:
+		return p.Reaction
+	case NotificationTypeMention:
+		return p.Mention
+	default:
+		return true
+	}
+}
@@ -7,13 +7,23 @@ import (
 )
 
 type Post struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Title     string         `gorm:"not null" json:"title"`
-	Content   string         `gorm:"type:text;not null" json:"content"`
-	UserID    uint           `gorm:"not null;index" json:"user_id"`
-	User      User           `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	Comments  []Comment      `gorm:"foreignKey:PostID" json:"comments,omitempty"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              uint             `gorm:"primaryKey;index:idx_posts_user_feed,priority:2" json:"id"`
+	Title           string           `gorm:"not null" json:"title"`
+	Content         string           `gorm:"type:text;not null" json:"content"`
+	ContentHTML     string           `gorm:"-" json:"content_html,omitempty"` // Content yang sudah di-escape dengan link mention
+	UserID          uint             `gorm:"not null;index;index:idx_posts_user_feed,priority:1" json:"user_id"`
+	User            User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Comments        []Comment        `gorm:"foreignKey:PostID" json:"comments,omitempty"`
+	Tags            []Tag            `gorm:"many2many:post_tags" json:"tags,omitempty"`
+	FeaturedImageID *uint            `gorm:"index" json:"featured_image_id"`
+	FeaturedImage   *Media           `gorm:"foreignKey:FeaturedImageID;constraint:OnDelete:SET NULL" json:"featured_image,omitempty"`
+	CommentCount    int64            `gorm:"not null;default:0" json:"comment_count"` // Denormalisasi, di-update dalam transaksi
+	CommentsURL     string           `gorm:"-" json:"comments_url,omitempty"`
+	Score           int64            `gorm:"not null;default:0;index" json:"score"` // Total reactions, dipakai untuk sort=top
+	Reactions       map[string]int64 `gorm:"-" json:"reactions,omitempty"`
+	ViewerReactions []string         `gorm:"-" json:"viewer_reactions,omitempty"`
+	Bookmarked      *bool            `gorm:"-" json:"bookmarked,omitempty"` // Hanya diisi jika request membawa token
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt   `gorm:"index" json:"-"`
 }
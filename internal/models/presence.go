@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// State editor pada presence
+const (
+	EditorStateViewing = "viewing"
+	EditorStateEditing = "editing"
+)
+
+// EditorSession - Satu koneksi WebSocket presence yang terbuka pada post (satu per tab/perangkat)
+type EditorSession struct {
+	ID         string    `gorm:"primaryKey;size:32" json:"session_id"`
+	PostID     uint      `gorm:"not null;index" json:"post_id"`
+	UserID     uint      `gorm:"not null" json:"user_id"`
+	User       User      `gorm:"foreignKey:UserID" json:"-"`
+	State      string    `gorm:"size:16;not null" json:"state"`
+	LastSeenAt time.Time `gorm:"not null;index" json:"last_seen_at"` // Heartbeat terakhir
+	CreatedAt  time.Time `json:"joined_at"`
+}
+
+// PostEditLock - Advisory lock edit per post, dipegang satu session dan diperpanjang lewat heartbeat
+type PostEditLock struct {
+	PostID     uint      `gorm:"primaryKey;autoIncrement:false" json:"post_id"`
+	SessionID  string    `gorm:"size:32;not null" json:"session_id"`
+	UserID     uint      `gorm:"not null" json:"user_id"`
+	AcquiredAt time.Time `gorm:"not null" json:"acquired_at"`
+	ExpiresAt  time.Time `gorm:"not null" json:"expires_at"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// RateLimitBucket - Token bucket rate limiter yang dibagi antar replica (RATE_LIMIT_STORE=postgres).
+// RefilledAt dalam milidetik Unix agar perhitungan refill bisa dilakukan di SQL tanpa fungsi tanggal.
+type RateLimitBucket struct {
+	Key        string  `gorm:"primaryKey;size:255" json:"key"`
+	Tokens     float64 `gorm:"not null" json:"tokens"`
+	RefilledAt int64   `gorm:"not null;index" json:"refilled_at"`
+}
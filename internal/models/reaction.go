@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Target type untuk reaction
+const (
+	ReactionTargetPost    = "post"
+	ReactionTargetComment = "comment"
+)
+
+type Reaction struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_reactions_unique,priority:1" json:"user_id"`
+	TargetType string    `gorm:"size:20;not null;uniqueIndex:idx_reactions_unique,priority:2;index:idx_reactions_target,priority:1" json:"target_type"`
+	TargetID   uint      `gorm:"not null;uniqueIndex:idx_reactions_unique,priority:3;index:idx_reactions_target,priority:2" json:"target_id"`
+	Kind       string    `gorm:"size:32;not null;uniqueIndex:idx_reactions_unique,priority:4" json:"kind"`
+	User       User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReadingList struct {
+	ID          uint              `gorm:"primaryKey" json:"id"`
+	UserID      uint              `gorm:"not null;index" json:"user_id"`
+	Name        string            `gorm:"not null" json:"name"`
+	Description string            `gorm:"type:text" json:"description"`
+	IsPublic    bool              `gorm:"not null;default:false" json:"is_public"`
+	ShareToken  string            `gorm:"size:64;uniqueIndex;not null" json:"share_token,omitempty"` // Token untuk link publik
+	User        User              `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Items       []ReadingListItem `gorm:"foreignKey:ReadingListID" json:"items,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `gorm:"index" json:"-"`
+}
+
+type ReadingListItem struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ReadingListID uint      `gorm:"not null;uniqueIndex:idx_reading_list_items_list_post,priority:1" json:"reading_list_id"`
+	PostID        uint      `gorm:"not null;uniqueIndex:idx_reading_list_items_list_post,priority:2;index" json:"post_id"`
+	Post          Post      `gorm:"foreignKey:PostID" json:"post,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// Tag - Label post, Name disimpan lowercase dan dipakai sebagai slug di URL
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex" json:"name"`
+	CreatedAt time.Time `json:"-"`
+}
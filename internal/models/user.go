@@ -6,11 +6,19 @@ import (
 	"gorm.io/gorm"
 )
 
+// Role user, diubah lewat "blog-api user promote"
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type User struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	Email     string         `gorm:"uniqueIndex;not null" json:"email"`
 	Password  string         `gorm:"not null" json:"-"` // "-" agar tidak muncul di JSON response
 	Name      string         `gorm:"not null" json:"name"`
+	Username  string         `gorm:"size:30;uniqueIndex" json:"username"` // Handle untuk @mention (lowercase)
+	Role      string         `gorm:"size:16;not null;default:user" json:"-"`
 	Posts     []Post         `gorm:"foreignKey:UserID" json:"posts,omitempty"`
 	Comments  []Comment      `gorm:"foreignKey:UserID" json:"comments,omitempty"`
 	CreatedAt time.Time      `json:"created_at"`
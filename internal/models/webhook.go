@@ -0,0 +1,79 @@
+package models
+
+import "time"
+
+// Tipe event webhook
+const (
+	WebhookEventPostCreated    = "post.created"
+	WebhookEventPostUpdated    = "post.updated"
+	WebhookEventPostDeleted    = "post.deleted"
+	WebhookEventCommentCreated = "comment.created"
+	WebhookEventCommentDeleted = "comment.deleted"
+)
+
+// WebhookEventTypes - Semua tipe event yang bisa di-subscribe ("*" berarti semuanya)
+var WebhookEventTypes = []string{
+	WebhookEventPostCreated,
+	WebhookEventPostUpdated,
+	WebhookEventPostDeleted,
+	WebhookEventCommentCreated,
+	WebhookEventCommentDeleted,
+}
+
+// Status pengiriman webhook
+const (
+	WebhookDeliveryPending   = "pending"   // Menunggu dikirim (termasuk retry berikutnya)
+	WebhookDeliverySucceeded = "succeeded" // Endpoint menjawab 2xx
+	WebhookDeliveryDead      = "dead"      // Gagal setelah semua percobaan (dead letter)
+)
+
+// Webhook - Subscription endpoint milik user untuk event pada konten miliknya
+type Webhook struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	URL       string    `gorm:"size:2048;not null" json:"url"`
+	Secret    string    `gorm:"size:128;not null" json:"-"` // Kunci HMAC, hanya ditampilkan saat dibuat
+	Events    []string  `gorm:"type:text;serializer:json;not null" json:"events"`
+	Active    bool      `gorm:"not null" json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Subscribed - Cek apakah webhook menerima tipe event
+func (w Webhook) Subscribed(eventType string) bool {
+	for _, event := range w.Events {
+		if event == "*" || event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent - Outbox event, ditulis di transaksi yang sama dengan perubahan data
+// lalu di-fan-out menjadi delivery oleh dispatcher setelah commit
+type WebhookEvent struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"` // Pemilik konten (subscription yang dicocokkan)
+	Type         string     `gorm:"size:64;not null" json:"type"`
+	Payload      string     `gorm:"type:text;not null" json:"-"` // JSON body "data"
+	DispatchedAt *time.Time `gorm:"index" json:"dispatched_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// WebhookDelivery - Pengiriman satu event ke satu webhook beserta log percobaan terakhir
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	WebhookID      uint       `gorm:"not null;index" json:"webhook_id"`
+	EventID        uint       `gorm:"not null;index" json:"event_id"`
+	EventType      string     `gorm:"size:64;not null" json:"event_type"`
+	Status         string     `gorm:"size:16;not null;index:idx_webhook_deliveries_due,priority:1" json:"status"`
+	Attempts       int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"not null;index:idx_webhook_deliveries_due,priority:2" json:"next_attempt_at"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `gorm:"type:text" json:"last_error,omitempty"`
+	LastResponse   string     `gorm:"type:text" json:"last_response,omitempty"` // Potongan body response terakhir
+	DurationMs     int64      `json:"duration_ms"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CoalesceWindow - Notifikasi sejenis yang belum dibaca dalam rentang ini
+// digabung menjadi satu ("3 people commented on ...")
+const CoalesceWindow = time.Hour
+
+// Event - Aktivitas yang memicu notifikasi
+type Event struct {
+	Type        string
+	RecipientID uint
+	ActorID     uint
+	PostID      uint
+	CommentID   *uint // Diisi jika target aktivitas adalah comment
+}
+
+// Notify - Simpan notifikasi untuk event, dipanggil di dalam transaksi handler
+// agar notifikasi ikut rollback jika aksi utama gagal
+func Notify(tx *gorm.DB, event Event) error {
+	// Tidak perlu notifikasi untuk aktivitas sendiri
+	if event.RecipientID == 0 || event.RecipientID == event.ActorID {
+		return nil
+	}
+
+	pref, err := LoadPreference(tx, event.RecipientID)
+	if err != nil {
+		return err
+	}
+	if !pref.Enabled(event.Type) {
+		return nil
+	}
+
+	// Precision microsecond agar konsisten di Postgres dan SQLite (dipakai untuk cursor)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	query := tx.Where("user_id = ? AND type = ? AND post_id = ? AND read_at IS NULL AND latest_at >= ?",
+		event.RecipientID, event.Type, event.PostID, now.Add(-CoalesceWindow))
+	if event.CommentID != nil {
+		query = query.Where("comment_id = ?", *event.CommentID)
+	} else {
+		query = query.Where("comment_id IS NULL")
+	}
+
+	var notification models.Notification
+	err = query.Order("latest_at DESC").First(&notification).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		postID := event.PostID
+		notification = models.Notification{
+			UserID:     event.RecipientID,
+			Type:       event.Type,
+			ActorID:    event.ActorID,
+			ActorCount: 1,
+			PostID:     &postID,
+			CommentID:  event.CommentID,
+			LatestAt:   now,
+			CreatedAt:  now,
+		}
+		if notification.Message, err = buildMessage(tx, notification); err != nil {
+			return err
+		}
+		if err := tx.Create(&notification).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.NotificationActor{NotificationID: notification.ID, UserID: event.ActorID}).Error
+	}
+
+	// Gabungkan ke notifikasi yang sudah ada, actor_count hanya bertambah untuk actor baru
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.NotificationActor{NotificationID: notification.ID, UserID: event.ActorID})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	notification.ActorCount += int(result.RowsAffected)
+	notification.ActorID = event.ActorID
+	notification.LatestAt = now
+	if notification.Message, err = buildMessage(tx, notification); err != nil {
+		return err
+	}
+
+	return tx.Model(&notification).UpdateColumns(map[string]interface{}{
+		"actor_count": notification.ActorCount,
+		"actor_id":    notification.ActorID,
+		"latest_at":   notification.LatestAt,
+		"message":     notification.Message,
+	}).Error
+}
+
+// LoadPreference - Ambil preferensi notifikasi user, default jika belum diatur
+func LoadPreference(db *gorm.DB, userID uint) (models.NotificationPreference, error) {
+	var pref models.NotificationPreference
+	err := db.Where("user_id = ?", userID).First(&pref).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.DefaultNotificationPreference(userID), nil
+	}
+	return pref, err
+}
+
+// buildMessage - Susun pesan notifikasi yang sudah digabung
+func buildMessage(tx *gorm.DB, notification models.Notification) (string, error) {
+	var actor models.User
+	if err := tx.Select("id", "name").First(&actor, notification.ActorID).Error; err != nil {
+		return "", err
+	}
+
+	var post models.Post
+	if err := tx.Select("id", "title").First(&post, *notification.PostID).Error; err != nil {
+		return "", err
+	}
+
+	return Message(notification, actor.Name, post.Title), nil
+}
+
+// Message - Pesan notifikasi dari actor terakhir (atau jumlah actor jika sudah digabung) dan judul post
+func Message(notification models.Notification, actorName, postTitle string) string {
+	subject := actorName
+	if notification.ActorCount > 1 {
+		subject = fmt.Sprintf("%d people", notification.ActorCount)
+	}
+
+	switch {
+	case notification.Type == models.NotificationTypeComment:
+		return fmt.Sprintf("%s commented on your post %q", subject, postTitle)
+	case notification.Type == models.NotificationTypeReaction && notification.CommentID != nil:
+		return fmt.Sprintf("%s reacted to your comment on %q", subject, postTitle)
+	case notification.Type == models.NotificationTypeReaction:
+		return fmt.Sprintf("%s reacted to your post %q", subject, postTitle)
+	case notification.Type == models.NotificationTypeMention && notification.CommentID != nil:
+		return fmt.Sprintf("%s mentioned you in a comment on %q", subject, postTitle)
+	case notification.Type == models.NotificationTypeMention:
+		return fmt.Sprintf("%s mentioned you in %q", subject, postTitle)
+	default:
+		return fmt.Sprintf("%s interacted with your post %q", subject, postTitle)
+	}
+}
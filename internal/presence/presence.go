@@ -0,0 +1,195 @@
+package presence
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// SessionTimeout - Session tanpa heartbeat selama ini dianggap sudah pergi
+	SessionTimeout = 45 * time.Second
+	// LockTimeout - Lock edit dilepas otomatis jika pemegangnya tidak mengirim heartbeat selama ini
+	LockTimeout = 30 * time.Second
+)
+
+// Event realtime pada topic presence
+const (
+	EventChanged = "presence.changed"
+	EventSaved   = "post.saved"
+)
+
+// PostSaved - Versi baru post tersimpan, dikirim ke semua editor yang membuka post
+type PostSaved struct {
+	PostID    uint      `json:"post_id"`
+	UserID    uint      `json:"user_id"`
+	SessionID string    `json:"session_id,omitempty"` // Dari header X-Editor-Session jika dikirim
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Participant - Session yang sedang membuka post
+type Participant struct {
+	SessionID string    `json:"session_id"`
+	UserID    uint      `json:"user_id"`
+	Name      string    `json:"name"`
+	Username  string    `json:"username"`
+	State     string    `json:"state"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+// Snapshot - Kondisi presence sebuah post
+type Snapshot struct {
+	Participants []Participant        `json:"participants"`
+	Lock         *models.PostEditLock `json:"lock"`
+}
+
+// Topic - Topic realtime untuk perubahan presence dan penyimpanan post
+func Topic(postID uint) string {
+	return fmt.Sprintf("post:%d:presence", postID)
+}
+
+// NewSessionID - ID session editor acak (32 karakter hex)
+func NewSessionID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Join - Catat session baru (state viewing) dan kembalikan ID-nya
+func Join(db *gorm.DB, postID, userID uint) (string, error) {
+	id, err := NewSessionID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	session := models.EditorSession{
+		ID:         id,
+		PostID:     postID,
+		UserID:     userID,
+		State:      models.EditorStateViewing,
+		LastSeenAt: now,
+		CreatedAt:  now,
+	}
+	return session.ID, db.Create(&session).Error
+}
+
+// Touch - Heartbeat: perpanjang session dan lock yang dipegang session
+func Touch(db *gorm.DB, sessionID string) error {
+	now := time.Now()
+	if err := db.Model(&models.EditorSession{}).Where("id = ?", sessionID).Update("last_seen_at", now).Error; err != nil {
+		return err
+	}
+	return db.Model(&models.PostEditLock{}).
+		Where("session_id = ? AND expires_at > ?", sessionID, now).
+		Update("expires_at", now.Add(LockTimeout)).Error
+}
+
+// SetState - Ubah state session (viewing / editing)
+func SetState(db *gorm.DB, sessionID, state string) error {
+	return db.Model(&models.EditorSession{}).Where("id = ?", sessionID).
+		Updates(map[string]interface{}{"state": state, "last_seen_at": time.Now()}).Error
+}
+
+// Leave - Hapus session beserta lock yang dipegangnya
+func Leave(db *gorm.DB, sessionID string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("session_id = ?", sessionID).Delete(&models.PostEditLock{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", sessionID).Delete(&models.EditorSession{}).Error
+	})
+}
+
+// AcquireLock - Ambil lock edit post untuk session. Jika lock dipegang session lain yang masih aktif,
+// ok false dan lock pemegang saat ini dikembalikan.
+func AcquireLock(db *gorm.DB, postID, userID uint, sessionID string) (lock models.PostEditLock, ok bool, err error) {
+	err = db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		// Lock yang expired dianggap sudah dilepas
+		if err := tx.Where("post_id = ? AND expires_at <= ?", postID, now).Delete(&models.PostEditLock{}).Error; err != nil {
+			return err
+		}
+
+		candidate := models.PostEditLock{
+			PostID:     postID,
+			SessionID:  sessionID,
+			UserID:     userID,
+			AcquiredAt: now,
+			ExpiresAt:  now.Add(LockTimeout),
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&candidate).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("post_id = ?", postID).First(&lock).Error; err != nil {
+			return err
+		}
+		if lock.SessionID != sessionID {
+			return nil
+		}
+
+		ok = true
+		lock.ExpiresAt = now.Add(LockTimeout)
+		return tx.Model(&lock).Update("expires_at", lock.ExpiresAt).Error
+	})
+	return lock, ok, err
+}
+
+// ReleaseLock - Lepas lock jika dipegang session, false jika session tidak memegang lock
+func ReleaseLock(db *gorm.DB, postID uint, sessionID string) (bool, error) {
+	result := db.Where("post_id = ? AND session_id = ?", postID, sessionID).Delete(&models.PostEditLock{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// Load - Snapshot presence post: session yang masih aktif (urut waktu join) dan lock yang belum expired
+func Load(db *gorm.DB, postID uint) (Snapshot, error) {
+	now := time.Now()
+	snapshot := Snapshot{Participants: []Participant{}}
+
+	var sessions []models.EditorSession
+	err := db.Preload("User").
+		Where("post_id = ? AND last_seen_at > ?", postID, now.Add(-SessionTimeout)).
+		Order("created_at ASC, id ASC").Find(&sessions).Error
+	if err != nil {
+		return snapshot, err
+	}
+	for _, session := range sessions {
+		snapshot.Participants = append(snapshot.Participants, Participant{
+			SessionID: session.ID,
+			UserID:    session.UserID,
+			Name:      session.User.Name,
+			Username:  session.User.Username,
+			State:     session.State,
+			JoinedAt:  session.CreatedAt,
+		})
+	}
+
+	var lock models.PostEditLock
+	err = db.Where("post_id = ? AND expires_at > ?", postID, now).First(&lock).Error
+	switch {
+	case err == nil:
+		snapshot.Lock = &lock
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+// Cleanup - Hapus session yang tidak mengirim heartbeat (server mati tanpa Leave) dan lock expired
+func Cleanup(db *gorm.DB) error {
+	now := time.Now()
+	if err := db.Where("last_seen_at <= ?", now.Add(-SessionTimeout)).Delete(&models.EditorSession{}).Error; err != nil {
+		return err
+	}
+	return db.Where("expires_at <= ?", now).Delete(&models.PostEditLock{}).Error
+}
@@ -0,0 +1,70 @@
+package presence
+
+import (
+	"testing"
+	"time"
+
+	"blog-api/internal/database"
+	"blog-api/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestEditLock(t *testing.T) {
+	db := setupTestDB(t)
+	user := models.User{Email: "editor@example.com", Password: "x", Name: "Editor", Username: "editor"}
+	db.Create(&user)
+
+	first, _ := Join(db, 1, user.ID)
+	second, _ := Join(db, 1, user.ID)
+
+	lock, ok, err := AcquireLock(db, 1, user.ID, first)
+	if err != nil || !ok || lock.SessionID != first {
+		t.Fatalf("Expected first session to get the lock, got %+v ok=%v err=%v", lock, ok, err)
+	}
+
+	lock, ok, _ = AcquireLock(db, 1, user.ID, second)
+	if ok || lock.SessionID != first {
+		t.Fatalf("Expected lock to be denied while held, got %+v ok=%v", lock, ok)
+	}
+
+	snapshot, _ := Load(db, 1)
+	if len(snapshot.Participants) != 2 || snapshot.Lock == nil || snapshot.Lock.SessionID != first {
+		t.Fatalf("Unexpected snapshot %+v", snapshot)
+	}
+	if snapshot.Participants[0].Username != "editor" {
+		t.Errorf("Expected participant username, got %+v", snapshot.Participants[0])
+	}
+
+	// Lock tanpa heartbeat expired dan bisa diambil session lain
+	db.Model(&models.PostEditLock{}).Where("post_id = ?", 1).Update("expires_at", time.Now().Add(-time.Second))
+	if _, ok, _ := AcquireLock(db, 1, user.ID, second); !ok {
+		t.Fatal("Expected expired lock to be taken over")
+	}
+
+	// Leave melepas lock yang dipegang
+	Leave(db, second)
+	snapshot, _ = Load(db, 1)
+	if len(snapshot.Participants) != 1 || snapshot.Lock != nil {
+		t.Errorf("Expected 1 participant and no lock after leave, got %+v", snapshot)
+	}
+
+	// Session tanpa heartbeat tidak lagi muncul
+	db.Model(&models.EditorSession{}).Where("id = ?", first).Update("last_seen_at", time.Now().Add(-SessionTimeout-time.Second))
+	snapshot, _ = Load(db, 1)
+	if len(snapshot.Participants) != 0 {
+		t.Errorf("Expected stale session to be hidden, got %+v", snapshot.Participants)
+	}
+}
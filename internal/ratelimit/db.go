@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// takeSQL - Refill dan ambil satu token dalam satu upsert atomic. Jika bucket kosong, WHERE
+// membatalkan update dan tidak ada baris yang dikembalikan. CASE dipakai (bukan LEAST/GREATEST)
+// dan semua parameter di-CAST agar SQL yang sama jalan di Postgres dan SQLite.
+const takeSQL = `
+INSERT INTO rate_limit_buckets (key, tokens, refilled_at)
+VALUES (@key, CAST(@initial AS double precision), CAST(@now AS bigint))
+ON CONFLICT (key) DO UPDATE SET
+    tokens = ` + refilledTokens + ` - 1,
+    refilled_at = CASE WHEN CAST(@now AS bigint) > rate_limit_buckets.refilled_at
+        THEN CAST(@now AS bigint) ELSE rate_limit_buckets.refilled_at END
+WHERE ` + refilledTokens + ` >= 1
+RETURNING tokens`
+
+// refilledTokens - Token bucket setelah diisi ulang sejak refilled_at, maksimal kapasitas
+// (jam replica yang sedikit mundur tidak mengurangi token)
+const refilledTokens = `(CASE
+        WHEN rate_limit_buckets.tokens + ` + elapsedMillis + ` * CAST(@rate AS double precision) > CAST(@capacity AS double precision)
+        THEN CAST(@capacity AS double precision)
+        ELSE rate_limit_buckets.tokens + ` + elapsedMillis + ` * CAST(@rate AS double precision)
+    END)`
+
+const elapsedMillis = `(CASE WHEN CAST(@now AS bigint) > rate_limit_buckets.refilled_at
+        THEN CAST(@now AS bigint) - rate_limit_buckets.refilled_at ELSE 0 END)`
+
+// DBStore - Store di tabel rate_limit_buckets, dibagi semua replica server
+type DBStore struct {
+	db *gorm.DB
+}
+
+// NewDBStore - Buat DBStore
+func NewDBStore(db *gorm.DB) *DBStore {
+	return &DBStore{db: db}
+}
+
+// Take - Ambil satu token; jika ditolak, baca bucket untuk menghitung Retry-After
+func (s *DBStore) Take(ctx context.Context, key string, policy config.RateLimitPolicy, now time.Time) (Result, error) {
+	nowMillis := now.UnixMilli()
+	var tokens []float64
+	err := s.db.WithContext(ctx).Raw(takeSQL, map[string]any{
+		"key":      key,
+		"initial":  float64(policy.Requests - 1),
+		"capacity": float64(policy.Requests),
+		"rate":     refillRate(policy),
+		"now":      nowMillis,
+	}).Scan(&tokens).Error
+	if err != nil {
+		return Result{}, err
+	}
+	if len(tokens) > 0 {
+		return result(true, tokens[0], policy), nil
+	}
+
+	var b models.RateLimitBucket
+	if err := s.db.WithContext(ctx).Where("key = ?", key).Take(&b).Error; err != nil {
+		return Result{}, err
+	}
+	current := b.Tokens
+	if elapsed := nowMillis - b.RefilledAt; elapsed > 0 {
+		current = math.Min(float64(policy.Requests), current+float64(elapsed)*refillRate(policy))
+	}
+	return result(false, current, policy), nil
+}
+
+// Sweep - Hapus bucket yang terakhir diisi ulang sebelum before
+func (s *DBStore) Sweep(ctx context.Context, before time.Time) error {
+	return s.db.WithContext(ctx).Where("refilled_at < ?", before.UnixMilli()).Delete(&models.RateLimitBucket{}).Error
+}
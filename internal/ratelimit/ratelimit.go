@@ -0,0 +1,136 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-api/internal/config"
+
+	"gorm.io/gorm"
+)
+
+// Result - Hasil mengambil satu token dari bucket
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter - Waktu sampai satu token tersedia lagi (0 jika Allowed)
+	RetryAfter time.Duration
+	// Reset - Waktu sampai bucket terisi penuh kembali
+	Reset time.Duration
+}
+
+// Store - Penyimpanan token bucket. Take harus atomic per key agar request bersamaan
+// (dari satu atau banyak replica) tidak melewati limit.
+type Store interface {
+	Take(ctx context.Context, key string, policy config.RateLimitPolicy, now time.Time) (Result, error)
+	// Sweep - Hapus bucket yang tidak disentuh sejak before (sudah penuh kembali)
+	Sweep(ctx context.Context, before time.Time) error
+}
+
+// NewStore - Store sesuai RATE_LIMIT_STORE
+func NewStore(cfg *config.Config, db *gorm.DB) (Store, error) {
+	switch strings.ToLower(cfg.RateLimitStore) {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "postgres":
+		return NewDBStore(db), nil
+	default:
+		return nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", cfg.RateLimitStore)
+	}
+}
+
+// StartSweeper - Bersihkan bucket lama secara berkala sampai ctx selesai. Bucket yang tidak
+// disentuh lebih lama dari maxPeriod sudah penuh, sama dengan bucket yang belum ada.
+// Fungsi yang dikembalikan menunggu sweeper berhenti.
+func StartSweeper(ctx context.Context, store Store, maxPeriod, interval time.Duration) func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				// Error diabaikan, dicoba lagi di tick berikutnya
+				store.Sweep(ctx, now.Add(-maxPeriod))
+			}
+		}
+	}()
+	return func() { <-done }
+}
+
+// refillRate - Token per milidetik
+func refillRate(policy config.RateLimitPolicy) float64 {
+	return float64(policy.Requests) / float64(policy.Period.Milliseconds())
+}
+
+// result - Hitung header dari sisa token setelah Take
+func result(allowed bool, tokens float64, policy config.RateLimitPolicy) Result {
+	rate := refillRate(policy)
+	res := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(policy.Requests)-tokens)/rate) * time.Millisecond,
+	}
+	if !allowed {
+		res.Remaining = 0
+		res.RetryAfter = time.Duration(math.Ceil((1-tokens)/rate)) * time.Millisecond
+	}
+	return res
+}
+
+// MemoryStore - Store untuk satu instance server (default dan untuk test)
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens     float64
+	refilledAt time.Time
+}
+
+// NewMemoryStore - Buat MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: make(map[string]*bucket)}
+}
+
+// Take - Isi ulang bucket sesuai waktu yang berlalu lalu ambil satu token jika ada
+func (s *MemoryStore) Take(_ context.Context, key string, policy config.RateLimitPolicy, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	capacity := float64(policy.Requests)
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, refilledAt: now}
+		s.buckets[key] = b
+	}
+	if elapsed := now.Sub(b.refilledAt); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+float64(elapsed.Milliseconds())*refillRate(policy))
+		b.refilledAt = now
+	}
+	if b.tokens < 1 {
+		return result(false, b.tokens, policy), nil
+	}
+	b.tokens--
+	return result(true, b.tokens, policy), nil
+}
+
+// Sweep - Hapus bucket yang terakhir diisi ulang sebelum before
+func (s *MemoryStore) Sweep(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, b := range s.buckets {
+		if b.refilledAt.Before(before) {
+			delete(s.buckets, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/database"
+	"blog-api/internal/models"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDBStore(t *testing.T) (*gorm.DB, *DBStore) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("Failed to create migrator: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	return db, NewDBStore(db)
+}
+
+func TestStores(t *testing.T) {
+	_, dbStore := setupDBStore(t)
+	stores := map[string]Store{"memory": NewMemoryStore(), "db": dbStore}
+
+	policy := config.RateLimitPolicy{Requests: 3, Period: 3 * time.Second}
+	start := time.UnixMilli(1_700_000_000_000)
+	ctx := context.Background()
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			for i := 2; i >= 0; i-- {
+				res, err := store.Take(ctx, "login:ip:10.0.0.1", policy, start)
+				if err != nil {
+					t.Fatalf("Take failed: %v", err)
+				}
+				if !res.Allowed || res.Remaining != i {
+					t.Fatalf("Expected allowed with %d remaining, got %+v", i, res)
+				}
+			}
+
+			res, err := store.Take(ctx, "login:ip:10.0.0.1", policy, start.Add(500*time.Millisecond))
+			if err != nil {
+				t.Fatalf("Take failed: %v", err)
+			}
+			if res.Allowed || res.RetryAfter != 500*time.Millisecond {
+				t.Fatalf("Expected denied with 500ms retry, got %+v", res)
+			}
+			if res.Reset != 2500*time.Millisecond {
+				t.Errorf("Expected bucket full in 2.5s, got %v", res.Reset)
+			}
+
+			// Key lain punya bucket sendiri
+			if res, _ := store.Take(ctx, "login:ip:10.0.0.2", policy, start); !res.Allowed {
+				t.Error("Expected other key to be allowed")
+			}
+
+			// Satu token terisi setiap detik
+			res, _ = store.Take(ctx, "login:ip:10.0.0.1", policy, start.Add(time.Second))
+			if !res.Allowed || res.Remaining != 0 {
+				t.Fatalf("Expected refilled token, got %+v", res)
+			}
+
+			// Jam mundur (replica lain) tidak mengisi atau mengurangi token
+			if res, _ := store.Take(ctx, "login:ip:10.0.0.1", policy, start); res.Allowed {
+				t.Errorf("Expected denied when clock goes backwards, got %+v", res)
+			}
+
+			if err := store.Sweep(ctx, start.Add(2*time.Second)); err != nil {
+				t.Fatalf("Sweep failed: %v", err)
+			}
+			if res, _ := store.Take(ctx, "login:ip:10.0.0.1", policy, start.Add(3*time.Second)); !res.Allowed || res.Remaining != 2 {
+				t.Errorf("Expected full bucket after sweep, got %+v", res)
+			}
+		})
+	}
+}
+
+func TestDBStoreSweep(t *testing.T) {
+	db, store := setupDBStore(t)
+	now := time.UnixMilli(1_700_000_000_000)
+	policy := config.RateLimitPolicy{Requests: 1, Period: time.Minute}
+
+	store.Take(context.Background(), "old", policy, now.Add(-2*time.Minute))
+	store.Take(context.Background(), "fresh", policy, now)
+	if err := store.Sweep(context.Background(), now.Add(-time.Minute)); err != nil {
+		t.Fatalf("Sweep failed: %v", err)
+	}
+
+	var keys []string
+	db.Model(&models.RateLimitBucket{}).Pluck("key", &keys)
+	if len(keys) != 1 || keys[0] != "fresh" {
+		t.Errorf("Expected only fresh bucket to remain, got %v", keys)
+	}
+}
@@ -0,0 +1,59 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Message - Event realtime untuk satu topic (misal "post:1:comments")
+type Message struct {
+	ID    string          `json:"id"` // Unik dan dibuat publisher, sama di semua replica (dipakai Last-Event-ID)
+	Topic string          `json:"topic"`
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Broker - Pub/sub antar replica server. Setiap message yang di-publish (dari replica mana pun)
+// diteruskan ke semua fungsi deliver yang terdaftar, dengan urutan yang sama di setiap replica.
+type Broker interface {
+	Publish(ctx context.Context, msg Message) error
+	// Subscribe - Daftarkan penerima semua message, dipanggil sebelum Run
+	Subscribe(deliver func(Message))
+	// Run - Terima message sampai ctx selesai (no-op untuk broker in-memory)
+	Run(ctx context.Context) error
+}
+
+// MemoryBroker - Broker untuk satu instance server (default dan untuk test)
+type MemoryBroker struct {
+	mu       sync.Mutex
+	handlers []func(Message)
+}
+
+// NewMemoryBroker - Buat MemoryBroker
+func NewMemoryBroker() *MemoryBroker {
+	return &MemoryBroker{}
+}
+
+// Publish - Teruskan langsung ke semua penerima; lock dipegang agar urutan konsisten
+func (b *MemoryBroker) Publish(_ context.Context, msg Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, deliver := range b.handlers {
+		deliver(msg)
+	}
+	return nil
+}
+
+// Subscribe - Daftarkan penerima
+func (b *MemoryBroker) Subscribe(deliver func(Message)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, deliver)
+}
+
+// Run - Tidak ada koneksi yang perlu dijaga
+func (b *MemoryBroker) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
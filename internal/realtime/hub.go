@@ -0,0 +1,217 @@
+package realtime
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/database"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// bufferSize - Jumlah message terakhir (semua topic) yang disimpan untuk resume Last-Event-ID
+	bufferSize = 1000
+	// subscriptionBuffer - Antrian per koneksi; koneksi yang tertinggal lebih dari ini diputus
+	subscriptionBuffer = 64
+)
+
+// Hub - Menerima semua message dari broker, menyimpan buffer singkat
+// dan meneruskan message ke subscriber per topic di replica ini
+type Hub struct {
+	broker Broker
+
+	mu      sync.Mutex
+	buffer  []Message // Ring buffer, start menunjuk message tertua
+	start   int
+	subs    map[string]map[*Subscription]struct{}
+	clients map[string]int // Jumlah koneksi aktif per client (cap koneksi)
+	closing bool           // Shutdown sudah dipanggil
+}
+
+// Subscription - Koneksi ke satu topic. C ditutup jika subscriber terlalu lambat atau Close dipanggil.
+type Subscription struct {
+	C <-chan Message
+
+	ch     chan Message
+	topic  string
+	hub    *Hub
+	closed bool
+}
+
+// NewHub - Buat Hub dan daftarkan ke broker
+func NewHub(broker Broker) *Hub {
+	h := &Hub{
+		broker:  broker,
+		subs:    make(map[string]map[*Subscription]struct{}),
+		clients: make(map[string]int),
+	}
+	broker.Subscribe(h.dispatch)
+	return h
+}
+
+// Start - Buat Hub sesuai REALTIME_BROKER (memory atau postgres) dan jalankan broker sampai ctx selesai,
+// fungsi yang dikembalikan menunggu broker berhenti
+func Start(ctx context.Context, cfg *config.Config, db *gorm.DB) (hub *Hub, wait func(), err error) {
+	var broker Broker
+	switch cfg.RealtimeBroker {
+	case "memory":
+		broker = NewMemoryBroker()
+	case "postgres":
+		broker = NewPostgresBroker(db, database.DSN(cfg))
+	default:
+		return nil, nil, fmt.Errorf("unknown REALTIME_BROKER %q", cfg.RealtimeBroker)
+	}
+
+	hub = NewHub(broker)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := broker.Run(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("realtime broker stopped", "error", err)
+		}
+	}()
+	return hub, func() { <-done }, nil
+}
+
+// Publish - Kirim event ke semua subscriber topic di semua replica
+func (h *Hub) Publish(ctx context.Context, topic, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return h.broker.Publish(ctx, Message{ID: newMessageID(), Topic: topic, Event: event, Data: payload})
+}
+
+// Subscribe - Daftarkan subscriber topic. Jika lastEventID diisi, message setelahnya yang masih
+// ada di buffer dikembalikan sebagai backlog; resumed false berarti ID sudah tidak ada di buffer
+// (client perlu memuat ulang data). Backlog dan subscription dibuat dalam satu lock sehingga tidak ada gap.
+func (h *Hub) Subscribe(topic, lastEventID string) (sub *Subscription, backlog []Message, resumed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	resumed = true
+	if lastEventID != "" {
+		resumed = false
+		for i := 0; i < len(h.buffer); i++ {
+			msg := h.buffer[(h.start+i)%len(h.buffer)]
+			if resumed && msg.Topic == topic {
+				backlog = append(backlog, msg)
+			}
+			if msg.ID == lastEventID {
+				resumed = true
+			}
+		}
+		if !resumed {
+			backlog = nil
+		}
+	}
+
+	ch := make(chan Message, subscriptionBuffer)
+	sub = &Subscription{C: ch, ch: ch, topic: topic, hub: h}
+	if h.closing {
+		// Server sedang shutdown: stream langsung selesai dan client reconnect ke replica lain
+		sub.closed = true
+		close(ch)
+		return sub, backlog, resumed
+	}
+	if h.subs[topic] == nil {
+		h.subs[topic] = make(map[*Subscription]struct{})
+	}
+	h.subs[topic][sub] = struct{}{}
+	return sub, backlog, resumed
+}
+
+// Close - Lepas subscription
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Shutdown - Tutup semua subscription agar stream SSE, WebSocket dan gRPC yang sedang terbuka
+// selesai saat server shutdown (client reconnect dengan Last-Event-ID), subscription baru langsung ditutup
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closing = true
+	for _, subs := range h.subs {
+		for sub := range subs {
+			h.remove(sub)
+		}
+	}
+}
+
+// AcquireClient - Ambil slot koneksi untuk client (misal IP), false jika sudah mencapai max
+func (h *Hub) AcquireClient(client string, max int) (release func(), ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if max > 0 && h.clients[client] >= max {
+		return nil, false
+	}
+	h.clients[client]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			if h.clients[client]--; h.clients[client] <= 0 {
+				delete(h.clients, client)
+			}
+		})
+	}, true
+}
+
+// dispatch - Simpan message ke buffer dan teruskan ke subscriber topic tanpa blocking
+func (h *Hub) dispatch(msg Message) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.buffer) < bufferSize {
+		h.buffer = append(h.buffer, msg)
+	} else {
+		h.buffer[h.start] = msg
+		h.start = (h.start + 1) % bufferSize
+	}
+
+	for sub := range h.subs[msg.Topic] {
+		select {
+		case sub.ch <- msg:
+		default:
+			// Subscriber terlalu lambat, putus agar client reconnect dengan Last-Event-ID
+			h.remove(sub)
+		}
+	}
+}
+
+// remove - Hapus dan tutup subscription, lock harus sudah dipegang
+func (h *Hub) remove(sub *Subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+
+	delete(h.subs[sub.topic], sub)
+	if len(h.subs[sub.topic]) == 0 {
+		delete(h.subs, sub.topic)
+	}
+}
+
+// newMessageID - ID unik yang kira-kira berurutan waktu
+func newMessageID() string {
+	suffix := make([]byte, 4)
+	rand.Read(suffix)
+	return strconv.FormatInt(time.Now().UnixMilli(), 36) + "-" + hex.EncodeToString(suffix)
+}
@@ -0,0 +1,98 @@
+package realtime
+
+import (
+	"context"
+	"testing"
+)
+
+func TestHubResumeFromLastEventID(t *testing.T) {
+	h := NewHub(NewMemoryBroker())
+	ctx := context.Background()
+
+	h.Publish(ctx, "post:1:comments", "comment.created", map[string]int{"id": 1})
+	h.Publish(ctx, "post:2:comments", "comment.created", map[string]int{"id": 2})
+	h.Publish(ctx, "post:1:comments", "comment.created", map[string]int{"id": 3})
+
+	first := h.buffer[0]
+	sub, backlog, resumed := h.Subscribe("post:1:comments", first.ID)
+	defer sub.Close()
+	if !resumed || len(backlog) != 1 || string(backlog[0].Data) != `{"id":3}` {
+		t.Fatalf("Expected backlog with only the later post:1 event, got %+v (resumed %v)", backlog, resumed)
+	}
+
+	// Event baru diteruskan ke subscriber topic yang sama saja
+	h.Publish(ctx, "post:2:comments", "comment.created", map[string]int{"id": 4})
+	h.Publish(ctx, "post:1:comments", "comment.deleted", map[string]int{"id": 1})
+	msg := <-sub.C
+	if msg.Event != "comment.deleted" {
+		t.Errorf("Expected comment.deleted, got %s", msg.Event)
+	}
+
+	_, backlog, resumed = h.Subscribe("post:1:comments", "unknown-id")
+	if resumed || backlog != nil {
+		t.Errorf("Expected unknown Last-Event-ID to require a reset")
+	}
+}
+
+func TestHubDropsSlowSubscriber(t *testing.T) {
+	h := NewHub(NewMemoryBroker())
+	sub, _, _ := h.Subscribe("post:1:comments", "")
+
+	for i := 0; i <= subscriptionBuffer; i++ {
+		h.Publish(context.Background(), "post:1:comments", "comment.created", i)
+	}
+
+	count := 0
+	for range sub.C {
+		count++
+	}
+	if count != subscriptionBuffer {
+		t.Errorf("Expected %d buffered events before close, got %d", subscriptionBuffer, count)
+	}
+	sub.Close() // Aman dipanggil setelah diputus hub
+}
+
+func TestHubBufferWrapsAround(t *testing.T) {
+	h := NewHub(NewMemoryBroker())
+	for i := 0; i < bufferSize+10; i++ {
+		h.Publish(context.Background(), "t", "e", i)
+	}
+	if len(h.buffer) != bufferSize || string(h.buffer[h.start].Data) != "10" {
+		t.Errorf("Expected oldest buffered event to be 10, got %s", h.buffer[h.start].Data)
+	}
+}
+
+func TestAcquireClientCap(t *testing.T) {
+	h := NewHub(NewMemoryBroker())
+	release, ok := h.AcquireClient("1.2.3.4", 1)
+	if !ok {
+		t.Fatal("Expected first connection to be allowed")
+	}
+	if _, ok := h.AcquireClient("1.2.3.4", 1); ok {
+		t.Error("Expected second connection to be rejected")
+	}
+	release()
+	release()
+	if _, ok := h.AcquireClient("1.2.3.4", 1); !ok {
+		t.Error("Expected connection to be allowed after release")
+	}
+}
+
+func TestHubShutdownClosesSubscriptions(t *testing.T) {
+	h := NewHub(NewMemoryBroker())
+	sub, _, _ := h.Subscribe("post:1:comments", "")
+
+	h.Shutdown()
+	if _, ok := <-sub.C; ok {
+		t.Error("Expected open subscription to be closed on shutdown")
+	}
+	sub.Close()
+
+	late, _, _ := h.Subscribe("post:1:comments", "")
+	if _, ok := <-late.C; ok {
+		t.Error("Expected subscription after shutdown to be closed immediately")
+	}
+	if len(h.subs) != 0 {
+		t.Errorf("Expected no registered subscriptions, got %d topics", len(h.subs))
+	}
+}
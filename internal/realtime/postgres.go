@@ -0,0 +1,114 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	// notifyChannel - Channel LISTEN/NOTIFY yang dipakai semua replica
+	notifyChannel = "blog_realtime"
+	// maxNotifyPayload - Batas payload NOTIFY Postgres (8000 byte termasuk overhead)
+	maxNotifyPayload = 7900
+	// maxReconnectDelay - Jeda maksimal sebelum koneksi LISTEN dibuat ulang
+	maxReconnectDelay = 30 * time.Second
+)
+
+// PostgresBroker - Broker antar replica memakai Postgres LISTEN/NOTIFY. Publish lewat pool GORM,
+// setiap replica menjaga satu koneksi khusus yang LISTEN ke notifyChannel.
+type PostgresBroker struct {
+	db  *gorm.DB
+	dsn string
+
+	mu       sync.Mutex
+	handlers []func(Message)
+}
+
+// NewPostgresBroker - Buat PostgresBroker, dsn dipakai untuk koneksi LISTEN
+func NewPostgresBroker(db *gorm.DB, dsn string) *PostgresBroker {
+	return &PostgresBroker{db: db, dsn: dsn}
+}
+
+// Publish - pg_notify ke semua replica (termasuk replica ini lewat koneksi LISTEN-nya)
+func (b *PostgresBroker) Publish(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > maxNotifyPayload {
+		return fmt.Errorf("realtime: message %s too large for NOTIFY (%d bytes)", msg.Event, len(payload))
+	}
+	return b.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", notifyChannel, string(payload)).Error
+}
+
+// Subscribe - Daftarkan penerima
+func (b *PostgresBroker) Subscribe(deliver func(Message)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, deliver)
+}
+
+// Run - LISTEN sampai ctx selesai, koneksi dibuat ulang dengan backoff jika terputus
+// (message selama koneksi terputus hilang; client akan menerima event reset saat resume)
+func (b *PostgresBroker) Run(ctx context.Context) error {
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := b.listen(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if time.Since(started) > maxReconnectDelay {
+			delay = time.Second
+		}
+		slog.Warn("realtime LISTEN connection lost", "error", err, "retry_in", delay.String())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxReconnectDelay {
+			delay = maxReconnectDelay
+		}
+	}
+}
+
+func (b *PostgresBroker) listen(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, b.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+notifyChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var msg Message
+		if err := json.Unmarshal([]byte(notification.Payload), &msg); err != nil {
+			slog.Warn("invalid realtime payload", "error", err)
+			continue
+		}
+
+		b.mu.Lock()
+		handlers := b.handlers
+		b.mu.Unlock()
+		for _, deliver := range handlers {
+			deliver(msg)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+	"blog-api/internal/webhook"
+
+	"gorm.io/gorm"
+)
+
+// gormStore - Store di atas koneksi GORM (atau transaksi yang sedang berjalan)
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore - Store untuk database produksi (PostgreSQL) dan test (SQLite)
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+func (s *gormStore) Posts() PostRepository               { return &gormPostRepository{db: s.db} }
+func (s *gormStore) Comments() CommentRepository         { return &gormCommentRepository{db: s.db} }
+func (s *gormStore) Users() UserRepository               { return &gormUserRepository{db: s.db} }
+func (s *gormStore) Mentions() MentionRepository         { return &gormMentionRepository{db: s.db} }
+func (s *gormStore) Outbox() OutboxRepository            { return &gormOutboxRepository{db: s.db} }
+func (s *gormStore) Reactions() ReactionRepository       { return &gormReactionRepository{db: s.db} }
+func (s *gormStore) Bookmarks() BookmarkRepository       { return &gormBookmarkRepository{db: s.db} }
+func (s *gormStore) ReadingLists() ReadingListRepository { return &gormReadingListRepository{db: s.db} }
+func (s *gormStore) Follows() FollowRepository           { return &gormFollowRepository{db: s.db} }
+func (s *gormStore) Feed() FeedRepository                { return &gormFeedRepository{db: s.db} }
+func (s *gormStore) Notifications() NotificationRepository {
+	return &gormNotificationRepository{db: s.db}
+}
+func (s *gormStore) Media() MediaRepository       { return &gormMediaRepository{db: s.db} }
+func (s *gormStore) Webhooks() WebhookRepository  { return &gormWebhookRepository{db: s.db} }
+func (s *gormStore) Sitemap() SitemapRepository   { return &gormSitemapRepository{db: s.db} }
+func (s *gormStore) Presence() PresenceRepository { return &gormPresenceRepository{db: s.db} }
+
+func (s *gormStore) Transaction(ctx context.Context, fn func(tx Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&gormStore{db: tx})
+	})
+}
+
+// notFound - Samakan error "tidak ada baris" GORM dengan ErrNotFound
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// duplicate - Samakan pelanggaran unique index (kode error berbeda per dialect) dengan ErrDuplicate
+func duplicate(db *gorm.DB, err error) error {
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		err = translator.Translate(err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicate
+	}
+	return err
+}
+
+// orderPosts - Urutan dan posisi cursor list post (newest atau top)
+func orderPosts(query *gorm.DB, page Page) *gorm.DB {
+	if page.Sort == "top" {
+		if page.After != nil {
+			query = query.Where("score < ? OR (score = ? AND id < ?)", page.After.Value, page.After.Value, page.After.ID)
+		}
+		return query.Order("score DESC, id DESC")
+	}
+	if page.After != nil {
+		query = query.Where("id < ?", page.After.ID)
+	}
+	return query.Order("id DESC")
+}
+
+// orderComments - Urutan dan posisi cursor list comment (oldest, newest atau top)
+func orderComments(query *gorm.DB, page Page) *gorm.DB {
+	switch page.Sort {
+	case "newest":
+		if page.After != nil {
+			query = query.Where("id < ?", page.After.ID)
+		}
+		return query.Order("id DESC")
+	case "top":
+		if page.After != nil {
+			query = query.Where("score < ? OR (score = ? AND id < ?)", page.After.Value, page.After.Value, page.After.ID)
+		}
+		return query.Order("score DESC, id DESC")
+	default:
+		if page.After != nil {
+			query = query.Where("id > ?", page.After.ID)
+		}
+		return query.Order("id ASC")
+	}
+}
+
+// windowOrder - Urutan ROW_NUMBER untuk tiap sort, sama dengan orderPosts/orderComments
+var windowOrder = map[string]string{
+	"oldest": "id ASC",
+	"newest": "id DESC",
+	"top":    "score DESC, id DESC",
+	"":       "id DESC",
+}
+
+// pagedByParent - Ambil limit+1 baris pertama per parent dalam satu query memakai ROW_NUMBER,
+// query sudah berisi filter parent, cursor dan soft delete
+func pagedByParent(db, query *gorm.DB, table, parentColumn, order string, limit int, dest interface{}) error {
+	inner := query.Select(table + ".*, ROW_NUMBER() OVER (PARTITION BY " + parentColumn + " ORDER BY " + order + ") AS page_row")
+	return db.Unscoped().
+		Table("(?) AS paged", inner).
+		Where("page_row <= ?", limit+1).
+		Order(parentColumn + " ASC, page_row ASC").
+		Find(dest).Error
+}
+
+type gormOutboxRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormOutboxRepository) Notify(ctx context.Context, event notifier.Event) error {
+	return notifier.Notify(r.db.WithContext(ctx), event)
+}
+
+func (r *gormOutboxRepository) EmitWebhook(ctx context.Context, userID uint, eventType string, data interface{}) error {
+	return webhook.Emit(r.db.WithContext(ctx), userID, eventType, data)
+}
+
+type gormMentionRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormMentionRepository) ForTarget(ctx context.Context, targetType string, targetID uint) ([]models.Mention, error) {
+	var mentions []models.Mention
+	err := r.db.WithContext(ctx).Where("target_type = ? AND target_id = ?", targetType, targetID).Find(&mentions).Error
+	return mentions, err
+}
+
+func (r *gormMentionRepository) Create(ctx context.Context, mention *models.Mention) error {
+	return r.db.WithContext(ctx).Create(mention).Error
+}
+
+func (r *gormMentionRepository) Delete(ctx context.Context, mention *models.Mention) error {
+	return r.db.WithContext(ctx).Delete(mention).Error
+}
+
+func (r *gormMentionRepository) Usernames(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]bool, error) {
+	result := make(map[uint]map[string]bool)
+	if len(targetIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		TargetID uint
+		Username string
+	}
+	err := r.db.WithContext(ctx).Model(&models.Mention{}).
+		Select("mentions.target_id, users.username").
+		Joins("JOIN users ON users.id = mentions.user_id").
+		Where("mentions.target_type = ? AND mentions.target_id IN ?", targetType, targetIDs).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if result[row.TargetID] == nil {
+			result[row.TargetID] = make(map[string]bool)
+		}
+		result[row.TargetID][row.Username] = true
+	}
+	return result, nil
+}
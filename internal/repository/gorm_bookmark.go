@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormBookmarkRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormBookmarkRepository) Add(ctx context.Context, userID, postID uint) error {
+	bookmark := models.Bookmark{UserID: userID, PostID: postID}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&bookmark).Error
+}
+
+func (r *gormBookmarkRepository) Remove(ctx context.Context, userID, postID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ? AND post_id = ?", userID, postID).Delete(&models.Bookmark{}).Error
+}
+
+func (r *gormBookmarkRepository) List(ctx context.Context, userID uint, page Page) ([]models.Bookmark, error) {
+	// Post yang sudah dihapus tidak ikut ditampilkan
+	query := r.db.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = bookmarks.post_id AND posts.deleted_at IS NULL").
+		Preload("Post.User").
+		Where("bookmarks.user_id = ?", userID)
+	if page.After != nil {
+		query = query.Where("bookmarks.id < ?", page.After.ID)
+	}
+
+	var bookmarks []models.Bookmark
+	err := query.Order("bookmarks.id DESC").Limit(page.Limit + 1).Find(&bookmarks).Error
+	return bookmarks, err
+}
+
+func (r *gormBookmarkRepository) Bookmarked(ctx context.Context, userID uint, postIDs []uint) (map[uint]bool, error) {
+	bookmarked := make(map[uint]bool)
+	if len(postIDs) == 0 {
+		return bookmarked, nil
+	}
+
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.Bookmark{}).
+		Where("user_id = ? AND post_id IN ?", userID, postIDs).
+		Pluck("post_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		bookmarked[id] = true
+	}
+	return bookmarked, nil
+}
+
+type gormReadingListRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormReadingListRepository) Find(ctx context.Context, id uint) (models.ReadingList, error) {
+	var list models.ReadingList
+	err := r.db.WithContext(ctx).First(&list, id).Error
+	return list, notFound(err)
+}
+
+func (r *gormReadingListRepository) FindByShareToken(ctx context.Context, token string) (models.ReadingList, error) {
+	var list models.ReadingList
+	err := r.db.WithContext(ctx).Preload("User").Where("share_token = ?", token).First(&list).Error
+	return list, notFound(err)
+}
+
+func (r *gormReadingListRepository) ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error) {
+	var lists []models.ReadingList
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&lists).Error
+	return lists, err
+}
+
+func (r *gormReadingListRepository) Create(ctx context.Context, list *models.ReadingList) error {
+	return r.db.WithContext(ctx).Create(list).Error
+}
+
+func (r *gormReadingListRepository) Update(ctx context.Context, list *models.ReadingList) error {
+	return r.db.WithContext(ctx).Save(list).Error
+}
+
+func (r *gormReadingListRepository) Delete(ctx context.Context, list *models.ReadingList) error {
+	return r.db.WithContext(ctx).Delete(list).Error
+}
+
+func (r *gormReadingListRepository) Items(ctx context.Context, listID uint) ([]models.ReadingListItem, error) {
+	var items []models.ReadingListItem
+	err := r.db.WithContext(ctx).
+		Joins("JOIN posts ON posts.id = reading_list_items.post_id AND posts.deleted_at IS NULL").
+		Preload("Post.User").
+		Where("reading_list_items.reading_list_id = ?", listID).
+		Order("reading_list_items.id ASC").
+		Find(&items).Error
+	return items, err
+}
+
+func (r *gormReadingListRepository) AddItem(ctx context.Context, listID, postID uint) error {
+	item := models.ReadingListItem{ReadingListID: listID, PostID: postID}
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&item).Error
+}
+
+func (r *gormReadingListRepository) RemoveItem(ctx context.Context, listID, postID uint) error {
+	return r.db.WithContext(ctx).
+		Where("reading_list_id = ? AND post_id = ?", listID, postID).
+		Delete(&models.ReadingListItem{}).Error
+}
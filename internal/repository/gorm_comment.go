@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type gormCommentRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormCommentRepository) Find(ctx context.Context, postID, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).Where("id = ? AND post_id = ?", id, postID).First(&comment).Error
+	return comment, notFound(err)
+}
+
+func (r *gormCommentRepository) FindDeleted(ctx context.Context, postID, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("id = ? AND post_id = ? AND deleted_at IS NOT NULL", id, postID).
+		First(&comment).Error
+	return comment, notFound(err)
+}
+
+func (r *gormCommentRepository) Get(ctx context.Context, id uint) (models.Comment, error) {
+	var comment models.Comment
+	err := r.db.WithContext(ctx).Preload("User").First(&comment, id).Error
+	return comment, notFound(err)
+}
+
+func (r *gormCommentRepository) List(ctx context.Context, postID uint, page Page) ([]models.Comment, error) {
+	query := orderComments(r.db.WithContext(ctx).Preload("User").Where("post_id = ?", postID), page)
+
+	var comments []models.Comment
+	err := query.Limit(page.Limit + 1).Find(&comments).Error
+	return comments, err
+}
+
+func (r *gormCommentRepository) ListByPosts(ctx context.Context, postIDs []uint, page Page) ([]models.Comment, error) {
+	db := r.db.WithContext(ctx)
+	query := orderComments(db.Model(&models.Comment{}).Where("post_id IN ?", postIDs), page)
+
+	order := windowOrder[page.Sort]
+	if page.Sort == "" {
+		order = windowOrder["oldest"]
+	}
+
+	var comments []models.Comment
+	err := pagedByParent(db, query, "comments", "post_id", order, page.Limit, &comments)
+	return comments, err
+}
+
+func (r *gormCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+func (r *gormCommentRepository) Delete(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Delete(comment).Error
+}
+
+func (r *gormCommentRepository) Restore(ctx context.Context, comment *models.Comment) error {
+	return r.db.WithContext(ctx).Unscoped().Model(comment).Update("deleted_at", nil).Error
+}
+
+func (r *gormCommentRepository) AdjustCount(ctx context.Context, postID uint, delta int) error {
+	query := r.db.WithContext(ctx).Model(&models.Post{}).Where("id = ?", postID)
+	if delta < 0 {
+		// Jaga agar counter tidak negatif
+		query = query.Where("comment_count >= ?", -delta)
+	}
+	return query.UpdateColumn("comment_count", gorm.Expr("comment_count + ?", delta)).Error
+}
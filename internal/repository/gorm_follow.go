@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormFollowRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormFollowRepository) Add(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	follow := models.Follow{FollowerID: followerID, FolloweeID: followeeID}
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&follow)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormFollowRepository) Remove(ctx context.Context, followerID, followeeID uint) error {
+	return r.db.WithContext(ctx).
+		Where("follower_id = ? AND followee_id = ?", followerID, followeeID).
+		Delete(&models.Follow{}).Error
+}
+
+func (r *gormFollowRepository) Followers(ctx context.Context, userID uint, page Page) ([]models.Follow, error) {
+	return r.list(ctx, "followee_id", "Follower", userID, page)
+}
+
+func (r *gormFollowRepository) Following(ctx context.Context, userID uint, page Page) ([]models.Follow, error) {
+	return r.list(ctx, "follower_id", "Followee", userID, page)
+}
+
+// list - Logic bersama Followers/Following, terbaru dulu
+func (r *gormFollowRepository) list(ctx context.Context, column, relation string, userID uint, page Page) ([]models.Follow, error) {
+	query := r.db.WithContext(ctx).Preload(relation).Where(column+" = ?", userID)
+	if page.After != nil {
+		query = query.Where("id < ?", page.After.ID)
+	}
+
+	var follows []models.Follow
+	err := query.Order("id DESC").Limit(page.Limit + 1).Find(&follows).Error
+	return follows, err
+}
+
+type gormFeedRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormFeedRepository) List(ctx context.Context, userID uint, fanout bool, page Page) ([]models.Post, error) {
+	query := r.db.WithContext(ctx).Preload("User").Preload("FeaturedImage.Variants")
+	if fanout {
+		// Feed sudah dihitung saat post dibuat (primary key feed_entries: user_id, post_id)
+		query = query.Joins("JOIN feed_entries ON feed_entries.post_id = posts.id").
+			Where("feed_entries.user_id = ?", userID)
+	} else {
+		// Dihitung langsung memakai index follows (follower_id, followee_id) dan posts (user_id, id)
+		query = query.Joins("JOIN follows ON follows.followee_id = posts.user_id").
+			Where("follows.follower_id = ?", userID)
+	}
+	if page.After != nil {
+		query = query.Where("posts.id < ?", page.After.ID)
+	}
+
+	var posts []models.Post
+	err := query.Order("posts.id DESC").Limit(page.Limit + 1).Find(&posts).Error
+	return posts, err
+}
+
+func (r *gormFeedRepository) Backfill(ctx context.Context, followerID, authorID uint, limit int) error {
+	return r.db.WithContext(ctx).Exec(`INSERT INTO feed_entries (user_id, post_id, author_id, created_at)
+		SELECT ?, id, user_id, created_at FROM posts
+		WHERE user_id = ? AND deleted_at IS NULL
+		ORDER BY id DESC LIMIT ?
+		ON CONFLICT DO NOTHING`,
+		followerID, authorID, limit).Error
+}
+
+func (r *gormFeedRepository) RemoveAuthor(ctx context.Context, followerID, authorID uint) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND author_id = ?", followerID, authorID).
+		Delete(&models.FeedEntry{}).Error
+}
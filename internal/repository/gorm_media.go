@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormMediaRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormMediaRepository) Get(ctx context.Context, id uint) (models.Media, error) {
+	var media models.Media
+	err := r.db.WithContext(ctx).Preload("Variants").First(&media, id).Error
+	return media, notFound(err)
+}
+
+func (r *gormMediaRepository) FindByKey(ctx context.Context, key string) (models.Media, error) {
+	var media models.Media
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&media).Error
+	return media, notFound(err)
+}
+
+func (r *gormMediaRepository) FindVariantByKey(ctx context.Context, key string) (models.MediaVariant, error) {
+	var variant models.MediaVariant
+	err := r.db.WithContext(ctx).Where("key = ?", key).First(&variant).Error
+	return variant, notFound(err)
+}
+
+func (r *gormMediaRepository) ListByUser(ctx context.Context, userID uint, page Page) ([]models.Media, error) {
+	query := r.db.WithContext(ctx).Preload("Variants").Where("user_id = ?", userID)
+	if page.After != nil {
+		query = query.Where("id < ?", page.After.ID)
+	}
+
+	var media []models.Media
+	err := query.Order("id DESC").Limit(page.Limit + 1).Find(&media).Error
+	return media, err
+}
+
+func (r *gormMediaRepository) UsedBytes(ctx context.Context, userID uint) (int64, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, userID).Error; err != nil {
+		return 0, notFound(err)
+	}
+
+	var used int64
+	err := r.db.WithContext(ctx).Model(&models.Media{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&used).Error
+	return used, err
+}
+
+func (r *gormMediaRepository) Create(ctx context.Context, media *models.Media) error {
+	return r.db.WithContext(ctx).Create(media).Error
+}
+
+func (r *gormMediaRepository) Delete(ctx context.Context, media *models.Media) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Model(&models.Post{}).Where("featured_image_id = ?", media.ID).Update("featured_image_id", nil).Error; err != nil {
+		return err
+	}
+	if err := db.Where("media_id = ?", media.ID).Delete(&models.MediaVariant{}).Error; err != nil {
+		return err
+	}
+	return db.Delete(media).Error
+}
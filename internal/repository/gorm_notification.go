@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormNotificationRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormNotificationRepository) List(ctx context.Context, userID uint, unreadOnly bool, page Page) ([]models.Notification, error) {
+	query := r.db.WithContext(ctx).Preload("Actor").Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if page.After != nil {
+		latestAt := time.UnixMicro(page.After.Value).UTC()
+		query = query.Where("latest_at < ? OR (latest_at = ? AND id < ?)", latestAt, latestAt, page.After.ID)
+	}
+
+	var notifications []models.Notification
+	err := query.Order("latest_at DESC, id DESC").Limit(page.Limit + 1).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *gormNotificationRepository) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *gormNotificationRepository) Find(ctx context.Context, userID, id uint) (models.Notification, error) {
+	var notification models.Notification
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&notification).Error
+	return notification, notFound(err)
+}
+
+func (r *gormNotificationRepository) MarkRead(ctx context.Context, notification *models.Notification, at time.Time) error {
+	if err := r.db.WithContext(ctx).Model(notification).UpdateColumn("read_at", at).Error; err != nil {
+		return err
+	}
+	notification.ReadAt = &at
+	return nil
+}
+
+func (r *gormNotificationRepository) MarkAllRead(ctx context.Context, userID uint, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		UpdateColumn("read_at", at)
+	return result.RowsAffected, result.Error
+}
+
+func (r *gormNotificationRepository) Preference(ctx context.Context, userID uint) (models.NotificationPreference, error) {
+	return notifier.LoadPreference(r.db.WithContext(ctx), userID)
+}
+
+func (r *gormNotificationRepository) SavePreference(ctx context.Context, pref *models.NotificationPreference) error {
+	// Select("*") agar nilai false tetap ditulis untuk kolom yang punya default
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Select("*").Create(pref).Error
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormPostRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormPostRepository) Find(ctx context.Context, id uint) (models.Post, error) {
+	var post models.Post
+	err := r.db.WithContext(ctx).First(&post, id).Error
+	return post, notFound(err)
+}
+
+func (r *gormPostRepository) FindWithDeleted(ctx context.Context, id uint) (models.Post, error) {
+	var post models.Post
+	err := r.db.WithContext(ctx).Unscoped().First(&post, id).Error
+	return post, notFound(err)
+}
+
+func (r *gormPostRepository) Get(ctx context.Context, id uint) (models.Post, error) {
+	var post models.Post
+	err := r.db.WithContext(ctx).
+		Preload("User").
+		Preload("Tags").
+		Preload("FeaturedImage.Variants").
+		First(&post, id).Error
+	return post, notFound(err)
+}
+
+func (r *gormPostRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	var posts []models.Post
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&posts).Error
+	return posts, err
+}
+
+func (r *gormPostRepository) All(ctx context.Context, sort string) ([]models.Post, error) {
+	query := r.db.WithContext(ctx).Preload("User").Preload("Tags").Preload("FeaturedImage.Variants")
+	if sort == "top" {
+		query = query.Order("score DESC, id DESC")
+	}
+
+	var posts []models.Post
+	err := query.Find(&posts).Error
+	return posts, err
+}
+
+func (r *gormPostRepository) List(ctx context.Context, page Page) ([]models.Post, error) {
+	query := orderPosts(r.db.WithContext(ctx), page)
+
+	var posts []models.Post
+	err := query.Limit(page.Limit + 1).Find(&posts).Error
+	return posts, err
+}
+
+func (r *gormPostRepository) ListByUsers(ctx context.Context, userIDs []uint, page Page) ([]models.Post, error) {
+	db := r.db.WithContext(ctx)
+	query := orderPosts(db.Model(&models.Post{}).Where("user_id IN ?", userIDs), page)
+
+	var posts []models.Post
+	err := pagedByParent(db, query, "posts", "user_id", windowOrder[page.Sort], page.Limit, &posts)
+	return posts, err
+}
+
+func (r *gormPostRepository) Tags(ctx context.Context, postIDs []uint) (map[uint][]models.Tag, error) {
+	var rows []struct {
+		PostID uint
+		models.Tag
+	}
+	err := r.db.WithContext(ctx).Table("post_tags").
+		Select("post_tags.post_id, tags.id, tags.name").
+		Joins("JOIN tags ON tags.id = post_tags.tag_id").
+		Where("post_tags.post_id IN ?", postIDs).
+		Order("tags.name ASC").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint][]models.Tag, len(postIDs))
+	for _, id := range postIDs {
+		result[id] = []models.Tag{}
+	}
+	for _, row := range rows {
+		result[row.PostID] = append(result[row.PostID], row.Tag)
+	}
+	return result, nil
+}
+
+func (r *gormPostRepository) Create(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Create(post).Error
+}
+
+func (r *gormPostRepository) Update(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Save(post).Error
+}
+
+func (r *gormPostRepository) Delete(ctx context.Context, post *models.Post) error {
+	return r.db.WithContext(ctx).Delete(post).Error
+}
+
+func (r *gormPostRepository) ReplaceTags(ctx context.Context, post *models.Post, names []string) error {
+	db := r.db.WithContext(ctx)
+	tags := make([]models.Tag, 0, len(names))
+	if len(names) > 0 {
+		for _, name := range names {
+			tags = append(tags, models.Tag{Name: name})
+		}
+		if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error; err != nil {
+			return err
+		}
+
+		// ID tag yang sudah ada tidak terisi oleh DO NOTHING, ambil ulang
+		tags = tags[:0]
+		if err := db.Where("name IN ?", names).Find(&tags).Error; err != nil {
+			return err
+		}
+	}
+
+	return db.Model(post).Association("Tags").Replace(tags)
+}
+
+func (r *gormPostRepository) FanOut(ctx context.Context, post models.Post) error {
+	return r.db.WithContext(ctx).Exec(`INSERT INTO feed_entries (user_id, post_id, author_id, created_at)
+		SELECT follower_id, ?, ?, ? FROM follows WHERE followee_id = ?`,
+		post.ID, post.UserID, post.CreatedAt, post.UserID).Error
+}
+
+func (r *gormPostRepository) MediaOwner(ctx context.Context, mediaID uint) (uint, error) {
+	var media models.Media
+	err := r.db.WithContext(ctx).Select("id", "user_id").First(&media, mediaID).Error
+	return media.UserID, notFound(err)
+}
+
+func (r *gormPostRepository) FindTag(ctx context.Context, name string) (models.Tag, error) {
+	var tag models.Tag
+	err := r.db.WithContext(ctx).Where("name = ?", name).First(&tag).Error
+	return tag, notFound(err)
+}
+
+func (r *gormPostRepository) Latest(ctx context.Context, userID, tagID uint, limit int) ([]models.Post, error) {
+	query := r.db.WithContext(ctx).Preload("User").Preload("Tags")
+	if userID != 0 {
+		query = query.Where("posts.user_id = ?", userID)
+	}
+	if tagID != 0 {
+		query = query.Joins("JOIN post_tags ON post_tags.post_id = posts.id").
+			Where("post_tags.tag_id = ?", tagID)
+	}
+
+	var posts []models.Post
+	err := query.Order("posts.id DESC").Limit(limit).Find(&posts).Error
+	return posts, err
+}
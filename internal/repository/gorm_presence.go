@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+	"blog-api/internal/presence"
+
+	"gorm.io/gorm"
+)
+
+// gormPresenceRepository - Presence di tabel editor_sessions dan post_edit_locks (package presence)
+type gormPresenceRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormPresenceRepository) Join(ctx context.Context, postID, userID uint) (string, error) {
+	return presence.Join(r.db.WithContext(ctx), postID, userID)
+}
+
+func (r *gormPresenceRepository) Touch(ctx context.Context, sessionID string) error {
+	return presence.Touch(r.db.WithContext(ctx), sessionID)
+}
+
+func (r *gormPresenceRepository) SetState(ctx context.Context, sessionID, state string) error {
+	return presence.SetState(r.db.WithContext(ctx), sessionID, state)
+}
+
+func (r *gormPresenceRepository) Leave(ctx context.Context, sessionID string) error {
+	return presence.Leave(r.db.WithContext(ctx), sessionID)
+}
+
+func (r *gormPresenceRepository) AcquireLock(ctx context.Context, postID, userID uint, sessionID string) (models.PostEditLock, bool, error) {
+	return presence.AcquireLock(r.db.WithContext(ctx), postID, userID, sessionID)
+}
+
+func (r *gormPresenceRepository) ReleaseLock(ctx context.Context, postID uint, sessionID string) (bool, error) {
+	return presence.ReleaseLock(r.db.WithContext(ctx), postID, sessionID)
+}
+
+func (r *gormPresenceRepository) Load(ctx context.Context, postID uint) (presence.Snapshot, error) {
+	return presence.Load(r.db.WithContext(ctx), postID)
+}
+
+func (r *gormPresenceRepository) Cleanup(ctx context.Context) error {
+	return presence.Cleanup(r.db.WithContext(ctx))
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormReactionRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormReactionRepository) Add(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormReactionRepository) Remove(ctx context.Context, reaction models.Reaction) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("user_id = ? AND target_type = ? AND target_id = ? AND kind = ?",
+			reaction.UserID, reaction.TargetType, reaction.TargetID, reaction.Kind).
+		Delete(&models.Reaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *gormReactionRepository) AdjustScore(ctx context.Context, targetType string, targetID uint, delta int) error {
+	table := "posts"
+	if targetType == models.ReactionTargetComment {
+		table = "comments"
+	}
+	return r.db.WithContext(ctx).Table(table).Where("id = ?", targetID).
+		UpdateColumn("score", gorm.Expr("score + ?", delta)).Error
+}
+
+func (r *gormReactionRepository) Counts(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]int64, error) {
+	counts := make(map[uint]map[string]int64)
+	if len(targetIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		TargetID uint
+		Kind     string
+		Count    int64
+	}
+	err := r.db.WithContext(ctx).Model(&models.Reaction{}).
+		Select("target_id, kind, COUNT(*) AS count").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Group("target_id, kind").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if counts[row.TargetID] == nil {
+			counts[row.TargetID] = make(map[string]int64)
+		}
+		counts[row.TargetID][row.Kind] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *gormReactionRepository) ByUser(ctx context.Context, targetType string, targetIDs []uint, userID uint) (map[uint][]string, error) {
+	kinds := make(map[uint][]string)
+	if len(targetIDs) == 0 || userID == 0 {
+		return kinds, nil
+	}
+
+	var reactions []models.Reaction
+	err := r.db.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ? AND user_id = ?", targetType, targetIDs, userID).
+		Order("id ASC").
+		Find(&reactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		kinds[reaction.TargetID] = append(kinds[reaction.TargetID], reaction.Kind)
+	}
+	return kinds, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// sitemapTime - Hasil MAX(updated_at): time.Time di Postgres, string di SQLite
+type sitemapTime struct {
+	time.Time
+}
+
+// sqliteTimeLayouts - Format waktu yang ditulis driver SQLite
+var sqliteTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+}
+
+// Value - Implementasi driver.Valuer (dibutuhkan GORM untuk mengenali field ini)
+func (t sitemapTime) Value() (driver.Value, error) {
+	return t.Time, nil
+}
+
+// Scan - Implementasi sql.Scanner
+func (t *sitemapTime) Scan(value interface{}) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case []byte:
+		raw = string(v)
+	case string:
+		raw = v
+	default:
+		return fmt.Errorf("unsupported time value %T", value)
+	}
+
+	for _, layout := range sqliteTimeLayouts {
+		if parsed, err := time.Parse(layout, raw); err == nil {
+			t.Time = parsed
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid time value %q", raw)
+}
+
+type gormSitemapRepository struct {
+	db *gorm.DB
+}
+
+// query - Query per section, hanya post yang tidak dihapus
+// (author dan tag hanya dimasukkan jika punya minimal satu post)
+func (r *gormSitemapRepository) query(db *gorm.DB, section string) *gorm.DB {
+	switch section {
+	case SitemapAuthors:
+		return db.Table("users").
+			Joins("JOIN posts ON posts.user_id = users.id AND posts.deleted_at IS NULL").
+			Where("users.deleted_at IS NULL").
+			Group("users.id, users.username")
+	case SitemapTags:
+		return db.Table("tags").
+			Joins("JOIN post_tags ON post_tags.tag_id = tags.id").
+			Joins("JOIN posts ON posts.id = post_tags.post_id AND posts.deleted_at IS NULL").
+			Group("tags.id, tags.name")
+	default:
+		return db.Model(&models.Post{})
+	}
+}
+
+func (r *gormSitemapRepository) Count(ctx context.Context, section string) (int64, error) {
+	db := r.db.WithContext(ctx)
+
+	// Subquery agar hasil GROUP BY (author/tag) dihitung per baris
+	var count int64
+	err := db.Table("(?) AS sitemap_rows", r.query(db, section).Select("1")).Count(&count).Error
+	return count, err
+}
+
+func (r *gormSitemapRepository) Entries(ctx context.Context, section string, offset, limit int) ([]SitemapEntry, error) {
+	query := r.query(r.db.WithContext(ctx), section).Limit(limit).Offset(offset)
+	switch section {
+	case SitemapPosts:
+		query = query.Select("CAST(id AS TEXT) AS slug, updated_at").Order("id ASC")
+	case SitemapAuthors:
+		query = query.Select("users.username AS slug, MAX(posts.updated_at) AS updated_at").Order("users.id ASC")
+	case SitemapTags:
+		query = query.Select("tags.name AS slug, MAX(posts.updated_at) AS updated_at").Order("tags.id ASC")
+	default:
+		return nil, nil
+	}
+
+	var rows []struct {
+		Slug      string
+		UpdatedAt sitemapTime
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]SitemapEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = SitemapEntry{Slug: row.Slug, UpdatedAt: row.UpdatedAt.Time}
+	}
+	return entries, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type gormUserRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormUserRepository) Find(ctx context.Context, id uint) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	return user, notFound(err)
+}
+
+func (r *gormUserRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) FindByEmail(ctx context.Context, email string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	return user, notFound(err)
+}
+
+func (r *gormUserRepository) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Where("username = ?", username).First(&user).Error
+	return user, notFound(err)
+}
+
+func (r *gormUserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	var users []models.User
+	err := r.db.WithContext(ctx).Where("username IN ?", usernames).Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Unscoped().Model(&models.User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *gormUserRepository) Search(ctx context.Context, prefix string, limit int) ([]models.User, error) {
+	// Escape wildcard LIKE karena underscore valid di username
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+
+	var users []models.User
+	err := r.db.WithContext(ctx).
+		Select("id", "username", "name").
+		Where(`username LIKE ? ESCAPE '\'`, escaped+"%").
+		Order("username ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+func (r *gormUserRepository) Create(ctx context.Context, user *models.User) error {
+	return duplicate(r.db, r.db.WithContext(ctx).Create(user).Error)
+}
+
+func (r *gormUserRepository) UpdateUsername(ctx context.Context, user *models.User, username string) error {
+	return duplicate(r.db, r.db.WithContext(ctx).Model(user).Update("username", username).Error)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type gormWebhookRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormWebhookRepository) Find(ctx context.Context, userID, id uint) (models.Webhook, error) {
+	var hook models.Webhook
+	err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&hook).Error
+	return hook, notFound(err)
+}
+
+func (r *gormWebhookRepository) ListByUser(ctx context.Context, userID uint) ([]models.Webhook, error) {
+	webhooks := []models.Webhook{}
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&webhooks).Error
+	return webhooks, err
+}
+
+func (r *gormWebhookRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.Webhook{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+func (r *gormWebhookRepository) Create(ctx context.Context, hook *models.Webhook) error {
+	// Select("*") agar active=false tetap ditulis
+	return r.db.WithContext(ctx).Select("*").Create(hook).Error
+}
+
+func (r *gormWebhookRepository) Update(ctx context.Context, hook *models.Webhook) error {
+	return r.db.WithContext(ctx).Save(hook).Error
+}
+
+func (r *gormWebhookRepository) Delete(ctx context.Context, hook *models.Webhook) error {
+	if err := r.db.WithContext(ctx).Where("webhook_id = ?", hook.ID).Delete(&models.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Delete(hook).Error
+}
+
+func (r *gormWebhookRepository) Deliveries(ctx context.Context, webhookID uint, status string, page Page) ([]models.WebhookDelivery, error) {
+	query := r.db.WithContext(ctx).Where("webhook_id = ?", webhookID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if page.After != nil {
+		query = query.Where("id < ?", page.After.ID)
+	}
+
+	deliveries := []models.WebhookDelivery{}
+	err := query.Order("id DESC").Limit(page.Limit + 1).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *gormWebhookRepository) FindDelivery(ctx context.Context, webhookID, id uint) (models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	err := r.db.WithContext(ctx).Where("id = ? AND webhook_id = ?", id, webhookID).First(&delivery).Error
+	return delivery, notFound(err)
+}
+
+func (r *gormWebhookRepository) FindEvent(ctx context.Context, id uint) (models.WebhookEvent, error) {
+	var event models.WebhookEvent
+	err := r.db.WithContext(ctx).First(&event, id).Error
+	return event, notFound(err)
+}
+
+func (r *gormWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
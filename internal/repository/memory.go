@@ -0,0 +1,1001 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+
+	"gorm.io/gorm"
+)
+
+// EmittedWebhook - Event webhook yang dicatat MemoryStore
+type EmittedWebhook struct {
+	UserID    uint
+	EventType string
+	Data      interface{}
+}
+
+// memoryData - Isi MemoryStore, di-clone saat transaksi agar bisa rollback
+type memoryData struct {
+	nextID           uint
+	posts            map[uint]models.Post
+	comments         map[uint]models.Comment
+	users            map[uint]models.User
+	mentions         map[uint]models.Mention
+	reactions        map[uint]models.Reaction
+	tags             map[string]uint   // nama -> ID tag
+	postTags         map[uint][]string // post -> nama tag
+	media            map[uint]models.Media
+	bookmarks        map[uint]models.Bookmark
+	readingLists     map[uint]models.ReadingList
+	readingListItems map[uint]models.ReadingListItem
+	follows          map[uint]models.Follow
+	inbox            map[uint]models.Notification
+	preferences      map[uint]models.NotificationPreference
+	webhooks         map[uint]models.Webhook
+	webhookEvents    map[uint]models.WebhookEvent
+	deliveries       map[uint]models.WebhookDelivery
+	sessions         map[string]models.EditorSession
+	locks            map[uint]models.PostEditLock // post -> lock
+	events           []notifier.Event
+	emitted          []EmittedWebhook
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		posts:            make(map[uint]models.Post),
+		comments:         make(map[uint]models.Comment),
+		users:            make(map[uint]models.User),
+		mentions:         make(map[uint]models.Mention),
+		reactions:        make(map[uint]models.Reaction),
+		tags:             make(map[string]uint),
+		postTags:         make(map[uint][]string),
+		media:            make(map[uint]models.Media),
+		bookmarks:        make(map[uint]models.Bookmark),
+		readingLists:     make(map[uint]models.ReadingList),
+		readingListItems: make(map[uint]models.ReadingListItem),
+		follows:          make(map[uint]models.Follow),
+		inbox:            make(map[uint]models.Notification),
+		preferences:      make(map[uint]models.NotificationPreference),
+		webhooks:         make(map[uint]models.Webhook),
+		webhookEvents:    make(map[uint]models.WebhookEvent),
+		deliveries:       make(map[uint]models.WebhookDelivery),
+		sessions:         make(map[string]models.EditorSession),
+		locks:            make(map[uint]models.PostEditLock),
+	}
+}
+
+func (d *memoryData) id() uint {
+	d.nextID++
+	return d.nextID
+}
+
+// clone - Salinan data untuk transaksi. Nilai di map tidak pernah diubah di tempat
+// (selalu diganti), kecuali slice postTags yang ikut disalin.
+func (d *memoryData) clone() *memoryData {
+	c := &memoryData{
+		nextID:           d.nextID,
+		posts:            maps.Clone(d.posts),
+		comments:         maps.Clone(d.comments),
+		users:            maps.Clone(d.users),
+		mentions:         maps.Clone(d.mentions),
+		reactions:        maps.Clone(d.reactions),
+		tags:             maps.Clone(d.tags),
+		postTags:         make(map[uint][]string, len(d.postTags)),
+		media:            maps.Clone(d.media),
+		bookmarks:        maps.Clone(d.bookmarks),
+		readingLists:     maps.Clone(d.readingLists),
+		readingListItems: maps.Clone(d.readingListItems),
+		follows:          maps.Clone(d.follows),
+		inbox:            maps.Clone(d.inbox),
+		preferences:      maps.Clone(d.preferences),
+		webhooks:         maps.Clone(d.webhooks),
+		webhookEvents:    maps.Clone(d.webhookEvents),
+		deliveries:       maps.Clone(d.deliveries),
+		sessions:         maps.Clone(d.sessions),
+		locks:            maps.Clone(d.locks),
+		events:           append([]notifier.Event(nil), d.events...),
+		emitted:          append([]EmittedWebhook(nil), d.emitted...),
+	}
+	for k, v := range d.postTags {
+		c.postTags[k] = append([]string(nil), v...)
+	}
+	return c
+}
+
+// memoryState - Data yang sudah di-commit, mu juga menserialkan transaksi
+type memoryState struct {
+	mu   sync.Mutex
+	data *memoryData
+}
+
+// MemoryStore - Store in-memory untuk test dan wiring tanpa database. Transaksi dijalankan
+// satu per satu pada salinan data dan baru menggantikan data asli setelah fn berhasil.
+// Feed selalu dihitung dari follow (tanpa tabel fan-out), notifikasi tidak digabung dan event webhook
+// tidak dikirim; semuanya juga dicatat untuk diperiksa test.
+type MemoryStore struct {
+	state *memoryState
+	tx    *memoryData // Diisi di dalam Transaction
+}
+
+// NewMemoryStore - MemoryStore kosong
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: &memoryState{data: newMemoryData()}}
+}
+
+// view - Data yang dipakai satu operasi, di luar transaksi dikunci sampai release dipanggil
+func (s *MemoryStore) view() (data *memoryData, release func()) {
+	if s.tx != nil {
+		return s.tx, func() {}
+	}
+	s.state.mu.Lock()
+	return s.state.data, s.state.mu.Unlock
+}
+
+func (s *MemoryStore) Posts() PostRepository                 { return &memoryPostRepository{s} }
+func (s *MemoryStore) Comments() CommentRepository           { return &memoryCommentRepository{s} }
+func (s *MemoryStore) Users() UserRepository                 { return &memoryUserRepository{s} }
+func (s *MemoryStore) Mentions() MentionRepository           { return &memoryMentionRepository{s} }
+func (s *MemoryStore) Outbox() OutboxRepository              { return &memoryOutboxRepository{s} }
+func (s *MemoryStore) Reactions() ReactionRepository         { return &memoryReactionRepository{s} }
+func (s *MemoryStore) Bookmarks() BookmarkRepository         { return &memoryBookmarkRepository{s} }
+func (s *MemoryStore) ReadingLists() ReadingListRepository   { return &memoryReadingListRepository{s} }
+func (s *MemoryStore) Follows() FollowRepository             { return &memoryFollowRepository{s} }
+func (s *MemoryStore) Feed() FeedRepository                  { return &memoryFeedRepository{s} }
+func (s *MemoryStore) Notifications() NotificationRepository { return &memoryNotificationRepository{s} }
+func (s *MemoryStore) Media() MediaRepository                { return &memoryMediaRepository{s} }
+func (s *MemoryStore) Webhooks() WebhookRepository           { return &memoryWebhookRepository{s} }
+func (s *MemoryStore) Sitemap() SitemapRepository            { return &memorySitemapRepository{s} }
+func (s *MemoryStore) Presence() PresenceRepository          { return &memoryPresenceRepository{s} }
+
+func (s *MemoryStore) Transaction(ctx context.Context, fn func(tx Store) error) error {
+	if s.tx != nil {
+		return fn(s)
+	}
+
+	s.state.mu.Lock()
+	defer s.state.mu.Unlock()
+
+	tx := &MemoryStore{state: s.state, tx: s.state.data.clone()}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	s.state.data = tx.tx
+	return nil
+}
+
+// AddMedia - Daftarkan media milik user (untuk featured image), mengembalikan ID media
+func (s *MemoryStore) AddMedia(userID uint) uint {
+	data, release := s.view()
+	defer release()
+
+	id := data.id()
+	data.media[id] = models.Media{ID: id, UserID: userID, CreatedAt: now()}
+	return id
+}
+
+// SetScore - Ubah score post (total reaction) untuk menguji sort=top
+func (s *MemoryStore) SetScore(postID uint, score int64) {
+	data, release := s.view()
+	defer release()
+
+	if post, ok := data.posts[postID]; ok {
+		post.Score = score
+		data.posts[postID] = post
+	}
+}
+
+// NotificationEvents - Event notifikasi yang sudah di-commit
+func (s *MemoryStore) NotificationEvents() []notifier.Event {
+	data, release := s.view()
+	defer release()
+	return append([]notifier.Event(nil), data.events...)
+}
+
+// EmittedWebhooks - Event webhook yang sudah di-commit
+func (s *MemoryStore) EmittedWebhooks() []EmittedWebhook {
+	data, release := s.view()
+	defer release()
+	return append([]EmittedWebhook(nil), data.emitted...)
+}
+
+// now - Timestamp dengan precision yang sama seperti database
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// afterCursor - Item berada setelah cursor, sama dengan orderPosts/orderComments
+func afterCursor(sort string, score int64, id uint, after *Cursor) bool {
+	if after == nil {
+		return true
+	}
+	switch sort {
+	case "oldest":
+		return id > after.ID
+	case "top":
+		return score < after.Value || (score == after.Value && id < after.ID)
+	default:
+		return id < after.ID
+	}
+}
+
+// sortedBefore - Urutan item untuk sort, "" berarti newest
+func sortedBefore(sort string, aScore int64, aID uint, bScore int64, bID uint) bool {
+	switch sort {
+	case "oldest":
+		return aID < bID
+	case "top":
+		if aScore != bScore {
+			return aScore > bScore
+		}
+		return aID > bID
+	default:
+		return aID > bID
+	}
+}
+
+// limitPerParent - Ambil limit+1 item pertama per parent, urut per parent seperti pagedByParent
+func limitPerParent[T any](items []T, parent func(T) uint, limit int) []T {
+	sort.SliceStable(items, func(i, j int) bool { return parent(items[i]) < parent(items[j]) })
+
+	result := items[:0]
+	counts := make(map[uint]int)
+	for _, item := range items {
+		if counts[parent(item)] <= limit {
+			counts[parent(item)]++
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// newestFirst - Satu halaman item terbaru dulu (ID terbesar) setelah cursor, limit+1 item
+// seperti query "id < cursor ORDER BY id DESC"
+func newestFirst[T any](items []T, id func(T) uint, page Page) []T {
+	sort.Slice(items, func(i, j int) bool { return id(items[i]) > id(items[j]) })
+
+	result := items[:0]
+	for _, item := range items {
+		if page.After == nil || id(item) < page.After.ID {
+			result = append(result, item)
+		}
+	}
+	if len(result) > page.Limit+1 {
+		result = result[:page.Limit+1]
+	}
+	return result
+}
+
+// livePost - Post yang belum dihapus beserta author-nya
+func livePost(data *memoryData, postID uint) (models.Post, bool) {
+	post, ok := data.posts[postID]
+	if !ok || post.DeletedAt.Valid {
+		return models.Post{}, false
+	}
+	post.User = data.users[post.UserID]
+	return post, true
+}
+
+type memoryPostRepository struct {
+	s *MemoryStore
+}
+
+// load - Post beserta relasi yang diminta (author, tag, featured image)
+func (r *memoryPostRepository) load(data *memoryData, post models.Post, relations bool) models.Post {
+	if !relations {
+		return post
+	}
+
+	post.User = data.users[post.UserID]
+	post.Tags = []models.Tag{}
+	for _, name := range data.postTags[post.ID] {
+		post.Tags = append(post.Tags, models.Tag{ID: data.tags[name], Name: name})
+	}
+	sort.Slice(post.Tags, func(i, j int) bool { return post.Tags[i].ID < post.Tags[j].ID })
+	if post.FeaturedImageID != nil {
+		if media, ok := data.media[*post.FeaturedImageID]; ok {
+			post.FeaturedImage = &media
+		}
+	}
+	return post
+}
+
+func (r *memoryPostRepository) find(id uint, withDeleted, relations bool) (models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	post, ok := data.posts[id]
+	if !ok || (post.DeletedAt.Valid && !withDeleted) {
+		return models.Post{}, ErrNotFound
+	}
+	return r.load(data, post, relations), nil
+}
+
+func (r *memoryPostRepository) Find(ctx context.Context, id uint) (models.Post, error) {
+	return r.find(id, false, false)
+}
+
+func (r *memoryPostRepository) FindWithDeleted(ctx context.Context, id uint) (models.Post, error) {
+	return r.find(id, true, false)
+}
+
+func (r *memoryPostRepository) Get(ctx context.Context, id uint) (models.Post, error) {
+	return r.find(id, false, true)
+}
+
+// filter - Post yang belum dihapus dan lolos match, urut sesuai sort
+func (r *memoryPostRepository) filter(data *memoryData, sortBy string, match func(models.Post) bool) []models.Post {
+	posts := []models.Post{}
+	for _, post := range data.posts {
+		if !post.DeletedAt.Valid && match(post) {
+			posts = append(posts, post)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool {
+		return sortedBefore(sortBy, posts[i].Score, posts[i].ID, posts[j].Score, posts[j].ID)
+	})
+	return posts
+}
+
+func (r *memoryPostRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(ids)
+	return r.filter(data, "", func(post models.Post) bool { return wanted[post.ID] }), nil
+}
+
+func (r *memoryPostRepository) All(ctx context.Context, sortBy string) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	if sortBy != "top" {
+		sortBy = "oldest"
+	}
+	posts := r.filter(data, sortBy, func(models.Post) bool { return true })
+	for i := range posts {
+		posts[i] = r.load(data, posts[i], true)
+	}
+	return posts, nil
+}
+
+func (r *memoryPostRepository) List(ctx context.Context, page Page) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	posts := r.filter(data, page.Sort, func(post models.Post) bool {
+		return afterCursor(page.Sort, post.Score, post.ID, page.After)
+	})
+	if len(posts) > page.Limit+1 {
+		posts = posts[:page.Limit+1]
+	}
+	return posts, nil
+}
+
+func (r *memoryPostRepository) ListByUsers(ctx context.Context, userIDs []uint, page Page) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(userIDs)
+	posts := r.filter(data, page.Sort, func(post models.Post) bool {
+		return wanted[post.UserID] && afterCursor(page.Sort, post.Score, post.ID, page.After)
+	})
+	return limitPerParent(posts, func(post models.Post) uint { return post.UserID }, page.Limit), nil
+}
+
+func (r *memoryPostRepository) Tags(ctx context.Context, postIDs []uint) (map[uint][]models.Tag, error) {
+	data, release := r.s.view()
+	defer release()
+
+	result := make(map[uint][]models.Tag, len(postIDs))
+	for _, id := range postIDs {
+		tags := r.load(data, data.posts[id], true).Tags
+		sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+		result[id] = tags
+	}
+	return result, nil
+}
+
+// stripPost - Simpan kolom post saja, relasi diisi ulang oleh load
+func stripPost(post models.Post) models.Post {
+	post.User = models.User{}
+	post.Tags = nil
+	post.Comments = nil
+	post.FeaturedImage = nil
+	post.ContentHTML = ""
+	return post
+}
+
+func (r *memoryPostRepository) Create(ctx context.Context, post *models.Post) error {
+	data, release := r.s.view()
+	defer release()
+
+	post.ID = data.id()
+	post.CreatedAt = now()
+	post.UpdatedAt = post.CreatedAt
+	data.posts[post.ID] = stripPost(*post)
+	return nil
+}
+
+func (r *memoryPostRepository) Update(ctx context.Context, post *models.Post) error {
+	data, release := r.s.view()
+	defer release()
+
+	post.UpdatedAt = now()
+	data.posts[post.ID] = stripPost(*post)
+	return nil
+}
+
+func (r *memoryPostRepository) Delete(ctx context.Context, post *models.Post) error {
+	data, release := r.s.view()
+	defer release()
+
+	stored, ok := data.posts[post.ID]
+	if !ok {
+		return nil
+	}
+	post.DeletedAt = gorm.DeletedAt{Time: now(), Valid: true}
+	stored.DeletedAt = post.DeletedAt
+	data.posts[post.ID] = stored
+	return nil
+}
+
+func (r *memoryPostRepository) ReplaceTags(ctx context.Context, post *models.Post, names []string) error {
+	data, release := r.s.view()
+	defer release()
+
+	for _, name := range names {
+		if _, ok := data.tags[name]; !ok {
+			data.tags[name] = data.id()
+		}
+	}
+	data.postTags[post.ID] = append([]string(nil), names...)
+	return nil
+}
+
+func (r *memoryPostRepository) FanOut(ctx context.Context, post models.Post) error {
+	return nil
+}
+
+func (r *memoryPostRepository) MediaOwner(ctx context.Context, mediaID uint) (uint, error) {
+	data, release := r.s.view()
+	defer release()
+
+	media, ok := data.media[mediaID]
+	if !ok {
+		return 0, ErrNotFound
+	}
+	return media.UserID, nil
+}
+
+func (r *memoryPostRepository) FindTag(ctx context.Context, name string) (models.Tag, error) {
+	data, release := r.s.view()
+	defer release()
+
+	id, ok := data.tags[name]
+	if !ok {
+		return models.Tag{}, ErrNotFound
+	}
+	return models.Tag{ID: id, Name: name}, nil
+}
+
+func (r *memoryPostRepository) Latest(ctx context.Context, userID, tagID uint, limit int) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	posts := r.filter(data, "newest", func(post models.Post) bool {
+		if userID != 0 && post.UserID != userID {
+			return false
+		}
+		if tagID == 0 {
+			return true
+		}
+		for _, name := range data.postTags[post.ID] {
+			if data.tags[name] == tagID {
+				return true
+			}
+		}
+		return false
+	})
+	if len(posts) > limit {
+		posts = posts[:limit]
+	}
+	for i := range posts {
+		posts[i] = r.load(data, posts[i], true)
+	}
+	return posts, nil
+}
+
+type memoryCommentRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryCommentRepository) Find(ctx context.Context, postID, id uint) (models.Comment, error) {
+	data, release := r.s.view()
+	defer release()
+
+	comment, ok := data.comments[id]
+	if !ok || comment.PostID != postID || comment.DeletedAt.Valid {
+		return models.Comment{}, ErrNotFound
+	}
+	return comment, nil
+}
+
+func (r *memoryCommentRepository) FindDeleted(ctx context.Context, postID, id uint) (models.Comment, error) {
+	data, release := r.s.view()
+	defer release()
+
+	comment, ok := data.comments[id]
+	if !ok || comment.PostID != postID || !comment.DeletedAt.Valid {
+		return models.Comment{}, ErrNotFound
+	}
+	return comment, nil
+}
+
+func (r *memoryCommentRepository) Get(ctx context.Context, id uint) (models.Comment, error) {
+	data, release := r.s.view()
+	defer release()
+
+	comment, ok := data.comments[id]
+	if !ok || comment.DeletedAt.Valid {
+		return models.Comment{}, ErrNotFound
+	}
+	comment.User = data.users[comment.UserID]
+	return comment, nil
+}
+
+// filter - Comment yang belum dihapus dan lolos match, urut sesuai sort (default oldest)
+func (r *memoryCommentRepository) filter(data *memoryData, page Page, match func(models.Comment) bool) []models.Comment {
+	sortBy := page.Sort
+	if sortBy == "" {
+		sortBy = "oldest"
+	}
+
+	comments := []models.Comment{}
+	for _, comment := range data.comments {
+		if !comment.DeletedAt.Valid && match(comment) && afterCursor(sortBy, comment.Score, comment.ID, page.After) {
+			comments = append(comments, comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		return sortedBefore(sortBy, comments[i].Score, comments[i].ID, comments[j].Score, comments[j].ID)
+	})
+	return comments
+}
+
+func (r *memoryCommentRepository) List(ctx context.Context, postID uint, page Page) ([]models.Comment, error) {
+	data, release := r.s.view()
+	defer release()
+
+	comments := r.filter(data, page, func(comment models.Comment) bool { return comment.PostID == postID })
+	if len(comments) > page.Limit+1 {
+		comments = comments[:page.Limit+1]
+	}
+	for i := range comments {
+		comments[i].User = data.users[comments[i].UserID]
+	}
+	return comments, nil
+}
+
+func (r *memoryCommentRepository) ListByPosts(ctx context.Context, postIDs []uint, page Page) ([]models.Comment, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(postIDs)
+	comments := r.filter(data, page, func(comment models.Comment) bool { return wanted[comment.PostID] })
+	return limitPerParent(comments, func(comment models.Comment) uint { return comment.PostID }, page.Limit), nil
+}
+
+func (r *memoryCommentRepository) Create(ctx context.Context, comment *models.Comment) error {
+	data, release := r.s.view()
+	defer release()
+
+	comment.ID = data.id()
+	comment.CreatedAt = now()
+	comment.UpdatedAt = comment.CreatedAt
+
+	stored := *comment
+	stored.User = models.User{}
+	stored.Post = models.Post{}
+	stored.ContentHTML = ""
+	data.comments[comment.ID] = stored
+	return nil
+}
+
+func (r *memoryCommentRepository) setDeleted(comment *models.Comment, deletedAt gorm.DeletedAt) {
+	data, release := r.s.view()
+	defer release()
+
+	comment.DeletedAt = deletedAt
+	if stored, ok := data.comments[comment.ID]; ok {
+		stored.DeletedAt = deletedAt
+		data.comments[comment.ID] = stored
+	}
+}
+
+func (r *memoryCommentRepository) Delete(ctx context.Context, comment *models.Comment) error {
+	r.setDeleted(comment, gorm.DeletedAt{Time: now(), Valid: true})
+	return nil
+}
+
+func (r *memoryCommentRepository) Restore(ctx context.Context, comment *models.Comment) error {
+	r.setDeleted(comment, gorm.DeletedAt{})
+	return nil
+}
+
+func (r *memoryCommentRepository) AdjustCount(ctx context.Context, postID uint, delta int) error {
+	data, release := r.s.view()
+	defer release()
+
+	post, ok := data.posts[postID]
+	if !ok || post.CommentCount+int64(delta) < 0 {
+		return nil
+	}
+	post.CommentCount += int64(delta)
+	data.posts[postID] = post
+	return nil
+}
+
+type memoryUserRepository struct {
+	s *MemoryStore
+}
+
+// active - User yang belum di-soft delete dan lolos match, urut ID
+func (r *memoryUserRepository) active(data *memoryData, match func(models.User) bool) []models.User {
+	users := []models.User{}
+	for _, user := range data.users {
+		if !user.DeletedAt.Valid && match(user) {
+			users = append(users, user)
+		}
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+func (r *memoryUserRepository) first(match func(models.User) bool) (models.User, error) {
+	data, release := r.s.view()
+	defer release()
+
+	users := r.active(data, match)
+	if len(users) == 0 {
+		return models.User{}, ErrNotFound
+	}
+	return users[0], nil
+}
+
+func (r *memoryUserRepository) Find(ctx context.Context, id uint) (models.User, error) {
+	return r.first(func(user models.User) bool { return user.ID == id })
+}
+
+func (r *memoryUserRepository) FindByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(ids)
+	return r.active(data, func(user models.User) bool { return wanted[user.ID] }), nil
+}
+
+func (r *memoryUserRepository) FindByEmail(ctx context.Context, email string) (models.User, error) {
+	return r.first(func(user models.User) bool { return user.Email == email })
+}
+
+func (r *memoryUserRepository) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	return r.first(func(user models.User) bool { return user.Username == username })
+}
+
+func (r *memoryUserRepository) FindByUsernames(ctx context.Context, usernames []string) ([]models.User, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := make(map[string]bool, len(usernames))
+	for _, username := range usernames {
+		wanted[username] = true
+	}
+	return r.active(data, func(user models.User) bool { return wanted[user.Username] }), nil
+}
+
+func (r *memoryUserRepository) UsernameTaken(ctx context.Context, username string) (bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	for _, user := range data.users {
+		if user.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryUserRepository) Search(ctx context.Context, prefix string, limit int) ([]models.User, error) {
+	data, release := r.s.view()
+	defer release()
+
+	users := r.active(data, func(user models.User) bool { return strings.HasPrefix(user.Username, prefix) })
+	sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	for i, user := range users {
+		users[i] = models.User{ID: user.ID, Username: user.Username, Name: user.Name}
+	}
+	return users, nil
+}
+
+// unique - Email dan username belum dipakai user lain (termasuk yang sudah di-soft delete)
+func (r *memoryUserRepository) unique(data *memoryData, candidate models.User) error {
+	for _, user := range data.users {
+		if user.ID == candidate.ID {
+			continue
+		}
+		if user.Email == candidate.Email || (candidate.Username != "" && user.Username == candidate.Username) {
+			return ErrDuplicate
+		}
+	}
+	return nil
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *models.User) error {
+	data, release := r.s.view()
+	defer release()
+
+	if err := r.unique(data, *user); err != nil {
+		return err
+	}
+	if user.Role == "" {
+		user.Role = models.RoleUser
+	}
+	user.ID = data.id()
+	user.CreatedAt = now()
+	user.UpdatedAt = user.CreatedAt
+	data.users[user.ID] = *user
+	return nil
+}
+
+func (r *memoryUserRepository) UpdateUsername(ctx context.Context, user *models.User, username string) error {
+	data, release := r.s.view()
+	defer release()
+
+	stored, ok := data.users[user.ID]
+	if !ok {
+		return ErrNotFound
+	}
+	stored.Username = username
+	if err := r.unique(data, stored); err != nil {
+		return err
+	}
+	stored.UpdatedAt = now()
+	data.users[user.ID] = stored
+	user.Username = stored.Username
+	user.UpdatedAt = stored.UpdatedAt
+	return nil
+}
+
+type memoryMentionRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryMentionRepository) ForTarget(ctx context.Context, targetType string, targetID uint) ([]models.Mention, error) {
+	data, release := r.s.view()
+	defer release()
+
+	mentions := []models.Mention{}
+	for _, m := range data.mentions {
+		if m.TargetType == targetType && m.TargetID == targetID {
+			mentions = append(mentions, m)
+		}
+	}
+	sort.Slice(mentions, func(i, j int) bool { return mentions[i].ID < mentions[j].ID })
+	return mentions, nil
+}
+
+func (r *memoryMentionRepository) Create(ctx context.Context, mention *models.Mention) error {
+	data, release := r.s.view()
+	defer release()
+
+	mention.ID = data.id()
+	mention.CreatedAt = now()
+	data.mentions[mention.ID] = *mention
+	return nil
+}
+
+func (r *memoryMentionRepository) Delete(ctx context.Context, mention *models.Mention) error {
+	data, release := r.s.view()
+	defer release()
+
+	delete(data.mentions, mention.ID)
+	return nil
+}
+
+func (r *memoryMentionRepository) Usernames(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(targetIDs)
+	result := make(map[uint]map[string]bool)
+	for _, m := range data.mentions {
+		user, ok := data.users[m.UserID]
+		if m.TargetType != targetType || !wanted[m.TargetID] || !ok {
+			continue
+		}
+		if result[m.TargetID] == nil {
+			result[m.TargetID] = make(map[string]bool)
+		}
+		result[m.TargetID][user.Username] = true
+	}
+	return result, nil
+}
+
+type memoryReactionRepository struct {
+	s *MemoryStore
+}
+
+// find - ID reaction yang sama (user, target, kind), 0 jika belum ada
+func (r *memoryReactionRepository) find(data *memoryData, reaction models.Reaction) uint {
+	for id, stored := range data.reactions {
+		if stored.UserID == reaction.UserID && stored.TargetType == reaction.TargetType &&
+			stored.TargetID == reaction.TargetID && stored.Kind == reaction.Kind {
+			return id
+		}
+	}
+	return 0
+}
+
+func (r *memoryReactionRepository) Add(ctx context.Context, reaction *models.Reaction) (bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	if r.find(data, *reaction) != 0 {
+		return false, nil
+	}
+	reaction.ID = data.id()
+	reaction.CreatedAt = now()
+	data.reactions[reaction.ID] = *reaction
+	return true, nil
+}
+
+func (r *memoryReactionRepository) Remove(ctx context.Context, reaction models.Reaction) (bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	id := r.find(data, reaction)
+	if id == 0 {
+		return false, nil
+	}
+	delete(data.reactions, id)
+	return true, nil
+}
+
+func (r *memoryReactionRepository) AdjustScore(ctx context.Context, targetType string, targetID uint, delta int) error {
+	data, release := r.s.view()
+	defer release()
+
+	if targetType == models.ReactionTargetComment {
+		if comment, ok := data.comments[targetID]; ok {
+			comment.Score += int64(delta)
+			data.comments[targetID] = comment
+		}
+		return nil
+	}
+	if post, ok := data.posts[targetID]; ok {
+		post.Score += int64(delta)
+		data.posts[targetID] = post
+	}
+	return nil
+}
+
+// matching - Reaction pada target yang diminta (dan milik userID jika bukan 0), urut ID
+func (r *memoryReactionRepository) matching(data *memoryData, targetType string, targetIDs []uint, userID uint) []models.Reaction {
+	wanted := idSet(targetIDs)
+	reactions := []models.Reaction{}
+	for _, reaction := range data.reactions {
+		if reaction.TargetType == targetType && wanted[reaction.TargetID] && (userID == 0 || reaction.UserID == userID) {
+			reactions = append(reactions, reaction)
+		}
+	}
+	sort.Slice(reactions, func(i, j int) bool { return reactions[i].ID < reactions[j].ID })
+	return reactions
+}
+
+func (r *memoryReactionRepository) Counts(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]int64, error) {
+	data, release := r.s.view()
+	defer release()
+
+	counts := make(map[uint]map[string]int64)
+	for _, reaction := range r.matching(data, targetType, targetIDs, 0) {
+		if counts[reaction.TargetID] == nil {
+			counts[reaction.TargetID] = make(map[string]int64)
+		}
+		counts[reaction.TargetID][reaction.Kind]++
+	}
+	return counts, nil
+}
+
+func (r *memoryReactionRepository) ByUser(ctx context.Context, targetType string, targetIDs []uint, userID uint) (map[uint][]string, error) {
+	kinds := make(map[uint][]string)
+	if userID == 0 {
+		return kinds, nil
+	}
+
+	data, release := r.s.view()
+	defer release()
+
+	for _, reaction := range r.matching(data, targetType, targetIDs, userID) {
+		kinds[reaction.TargetID] = append(kinds[reaction.TargetID], reaction.Kind)
+	}
+	return kinds, nil
+}
+
+type memoryOutboxRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryOutboxRepository) Notify(ctx context.Context, event notifier.Event) error {
+	// Sama dengan notifier.Notify: tidak ada notifikasi untuk aktivitas sendiri
+	if event.RecipientID == 0 || event.RecipientID == event.ActorID {
+		return nil
+	}
+
+	data, release := r.s.view()
+	defer release()
+
+	pref, ok := data.preferences[event.RecipientID]
+	if !ok {
+		pref = models.DefaultNotificationPreference(event.RecipientID)
+	}
+	if !pref.Enabled(event.Type) {
+		return nil
+	}
+	data.events = append(data.events, event)
+
+	// Satu notifikasi per event, tanpa penggabungan seperti notifier.Notify
+	postID := event.PostID
+	notification := models.Notification{
+		ID:         data.id(),
+		UserID:     event.RecipientID,
+		Type:       event.Type,
+		ActorID:    event.ActorID,
+		ActorCount: 1,
+		PostID:     &postID,
+		CommentID:  event.CommentID,
+		LatestAt:   now(),
+	}
+	notification.CreatedAt = notification.LatestAt
+	notification.Message = notifier.Message(notification, data.users[event.ActorID].Name, data.posts[postID].Title)
+	data.inbox[notification.ID] = notification
+	return nil
+}
+
+func (r *memoryOutboxRepository) EmitWebhook(ctx context.Context, userID uint, eventType string, payload interface{}) error {
+	data, release := r.s.view()
+	defer release()
+	data.emitted = append(data.emitted, EmittedWebhook{UserID: userID, EventType: eventType, Data: payload})
+
+	// Sama dengan webhook.Emit: event outbox hanya ditulis jika ada subscription aktif yang cocok
+	for _, hook := range data.webhooks {
+		if hook.UserID == userID && hook.Active && hook.Subscribed(eventType) {
+			body, err := json.Marshal(payload)
+			if err != nil {
+				return err
+			}
+			event := models.WebhookEvent{ID: data.id(), UserID: userID, Type: eventType, Payload: string(body), CreatedAt: now()}
+			data.webhookEvents[event.ID] = event
+			return nil
+		}
+	}
+	return nil
+}
+
+func idSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
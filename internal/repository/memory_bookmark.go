@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type memoryBookmarkRepository struct {
+	s *MemoryStore
+}
+
+// find - ID bookmark user untuk post, 0 jika belum ada
+func (r *memoryBookmarkRepository) find(data *memoryData, userID, postID uint) uint {
+	for id, bookmark := range data.bookmarks {
+		if bookmark.UserID == userID && bookmark.PostID == postID {
+			return id
+		}
+	}
+	return 0
+}
+
+func (r *memoryBookmarkRepository) Add(ctx context.Context, userID, postID uint) error {
+	data, release := r.s.view()
+	defer release()
+
+	if r.find(data, userID, postID) == 0 {
+		id := data.id()
+		data.bookmarks[id] = models.Bookmark{ID: id, UserID: userID, PostID: postID, CreatedAt: now()}
+	}
+	return nil
+}
+
+func (r *memoryBookmarkRepository) Remove(ctx context.Context, userID, postID uint) error {
+	data, release := r.s.view()
+	defer release()
+
+	delete(data.bookmarks, r.find(data, userID, postID))
+	return nil
+}
+
+func (r *memoryBookmarkRepository) List(ctx context.Context, userID uint, page Page) ([]models.Bookmark, error) {
+	data, release := r.s.view()
+	defer release()
+
+	bookmarks := []models.Bookmark{}
+	for _, bookmark := range data.bookmarks {
+		post, ok := livePost(data, bookmark.PostID)
+		if bookmark.UserID == userID && ok {
+			bookmark.Post = post
+			bookmarks = append(bookmarks, bookmark)
+		}
+	}
+	return newestFirst(bookmarks, func(b models.Bookmark) uint { return b.ID }, page), nil
+}
+
+func (r *memoryBookmarkRepository) Bookmarked(ctx context.Context, userID uint, postIDs []uint) (map[uint]bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	wanted := idSet(postIDs)
+	bookmarked := make(map[uint]bool)
+	for _, bookmark := range data.bookmarks {
+		if bookmark.UserID == userID && wanted[bookmark.PostID] {
+			bookmarked[bookmark.PostID] = true
+		}
+	}
+	return bookmarked, nil
+}
+
+type memoryReadingListRepository struct {
+	s *MemoryStore
+}
+
+// find - Reading list yang belum dihapus dan lolos match, withUser mengisi pemiliknya
+func (r *memoryReadingListRepository) find(match func(models.ReadingList) bool, withUser bool) (models.ReadingList, error) {
+	data, release := r.s.view()
+	defer release()
+
+	for _, list := range data.readingLists {
+		if !list.DeletedAt.Valid && match(list) {
+			if withUser {
+				list.User = data.users[list.UserID]
+			}
+			return list, nil
+		}
+	}
+	return models.ReadingList{}, ErrNotFound
+}
+
+func (r *memoryReadingListRepository) Find(ctx context.Context, id uint) (models.ReadingList, error) {
+	return r.find(func(list models.ReadingList) bool { return list.ID == id }, false)
+}
+
+func (r *memoryReadingListRepository) FindByShareToken(ctx context.Context, token string) (models.ReadingList, error) {
+	return r.find(func(list models.ReadingList) bool { return list.ShareToken == token }, true)
+}
+
+func (r *memoryReadingListRepository) ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error) {
+	data, release := r.s.view()
+	defer release()
+
+	lists := []models.ReadingList{}
+	for _, list := range data.readingLists {
+		if !list.DeletedAt.Valid && list.UserID == userID {
+			lists = append(lists, list)
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool { return lists[i].ID < lists[j].ID })
+	return lists, nil
+}
+
+// save - Simpan kolom reading list saja, relasi diisi saat dibaca
+func (r *memoryReadingListRepository) save(data *memoryData, list models.ReadingList) {
+	list.User = models.User{}
+	list.Items = nil
+	data.readingLists[list.ID] = list
+}
+
+func (r *memoryReadingListRepository) Create(ctx context.Context, list *models.ReadingList) error {
+	data, release := r.s.view()
+	defer release()
+
+	for _, stored := range data.readingLists {
+		if stored.ShareToken == list.ShareToken {
+			return ErrDuplicate
+		}
+	}
+	list.ID = data.id()
+	list.CreatedAt = now()
+	list.UpdatedAt = list.CreatedAt
+	r.save(data, *list)
+	return nil
+}
+
+func (r *memoryReadingListRepository) Update(ctx context.Context, list *models.ReadingList) error {
+	data, release := r.s.view()
+	defer release()
+
+	list.UpdatedAt = now()
+	r.save(data, *list)
+	return nil
+}
+
+func (r *memoryReadingListRepository) Delete(ctx context.Context, list *models.ReadingList) error {
+	data, release := r.s.view()
+	defer release()
+
+	list.DeletedAt = gorm.DeletedAt{Time: now(), Valid: true}
+	r.save(data, *list)
+	return nil
+}
+
+func (r *memoryReadingListRepository) Items(ctx context.Context, listID uint) ([]models.ReadingListItem, error) {
+	data, release := r.s.view()
+	defer release()
+
+	items := []models.ReadingListItem{}
+	for _, item := range data.readingListItems {
+		post, ok := livePost(data, item.PostID)
+		if item.ReadingListID == listID && ok {
+			item.Post = post
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return items, nil
+}
+
+// findItem - ID item post di reading list, 0 jika belum ada
+func (r *memoryReadingListRepository) findItem(data *memoryData, listID, postID uint) uint {
+	for id, item := range data.readingListItems {
+		if item.ReadingListID == listID && item.PostID == postID {
+			return id
+		}
+	}
+	return 0
+}
+
+func (r *memoryReadingListRepository) AddItem(ctx context.Context, listID, postID uint) error {
+	data, release := r.s.view()
+	defer release()
+
+	if r.findItem(data, listID, postID) == 0 {
+		id := data.id()
+		data.readingListItems[id] = models.ReadingListItem{ID: id, ReadingListID: listID, PostID: postID, CreatedAt: now()}
+	}
+	return nil
+}
+
+func (r *memoryReadingListRepository) RemoveItem(ctx context.Context, listID, postID uint) error {
+	data, release := r.s.view()
+	defer release()
+
+	delete(data.readingListItems, r.findItem(data, listID, postID))
+	return nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+)
+
+type memoryFollowRepository struct {
+	s *MemoryStore
+}
+
+// find - ID follow, 0 jika belum follow
+func (r *memoryFollowRepository) find(data *memoryData, followerID, followeeID uint) uint {
+	for id, follow := range data.follows {
+		if follow.FollowerID == followerID && follow.FolloweeID == followeeID {
+			return id
+		}
+	}
+	return 0
+}
+
+func (r *memoryFollowRepository) Add(ctx context.Context, followerID, followeeID uint) (bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	if r.find(data, followerID, followeeID) != 0 {
+		return false, nil
+	}
+	id := data.id()
+	data.follows[id] = models.Follow{ID: id, FollowerID: followerID, FolloweeID: followeeID, CreatedAt: now()}
+	return true, nil
+}
+
+func (r *memoryFollowRepository) Remove(ctx context.Context, followerID, followeeID uint) error {
+	data, release := r.s.view()
+	defer release()
+
+	delete(data.follows, r.find(data, followerID, followeeID))
+	return nil
+}
+
+func (r *memoryFollowRepository) Followers(ctx context.Context, userID uint, page Page) ([]models.Follow, error) {
+	data, release := r.s.view()
+	defer release()
+
+	follows := []models.Follow{}
+	for _, follow := range data.follows {
+		if follow.FolloweeID == userID {
+			follow.Follower = data.users[follow.FollowerID]
+			follows = append(follows, follow)
+		}
+	}
+	return newestFirst(follows, func(f models.Follow) uint { return f.ID }, page), nil
+}
+
+func (r *memoryFollowRepository) Following(ctx context.Context, userID uint, page Page) ([]models.Follow, error) {
+	data, release := r.s.view()
+	defer release()
+
+	follows := []models.Follow{}
+	for _, follow := range data.follows {
+		if follow.FollowerID == userID {
+			follow.Followee = data.users[follow.FolloweeID]
+			follows = append(follows, follow)
+		}
+	}
+	return newestFirst(follows, func(f models.Follow) uint { return f.ID }, page), nil
+}
+
+// memoryFeedRepository - Feed selalu dihitung dari follow, Backfill dan RemoveAuthor tidak perlu melakukan apa-apa
+type memoryFeedRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryFeedRepository) List(ctx context.Context, userID uint, fanout bool, page Page) ([]models.Post, error) {
+	data, release := r.s.view()
+	defer release()
+
+	authors := make(map[uint]bool)
+	for _, follow := range data.follows {
+		if follow.FollowerID == userID {
+			authors[follow.FolloweeID] = true
+		}
+	}
+
+	posts := []models.Post{}
+	for _, post := range data.posts {
+		if !post.DeletedAt.Valid && authors[post.UserID] {
+			posts = append(posts, post)
+		}
+	}
+	posts = newestFirst(posts, func(p models.Post) uint { return p.ID }, page)
+
+	loader := memoryPostRepository{r.s}
+	for i := range posts {
+		posts[i] = loader.load(data, posts[i], true)
+	}
+	return posts, nil
+}
+
+func (r *memoryFeedRepository) Backfill(ctx context.Context, followerID, authorID uint, limit int) error {
+	return nil
+}
+
+func (r *memoryFeedRepository) RemoveAuthor(ctx context.Context, followerID, authorID uint) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+
+	"blog-api/internal/models"
+)
+
+type memoryMediaRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryMediaRepository) Get(ctx context.Context, id uint) (models.Media, error) {
+	data, release := r.s.view()
+	defer release()
+
+	media, ok := data.media[id]
+	if !ok {
+		return models.Media{}, ErrNotFound
+	}
+	return media, nil
+}
+
+func (r *memoryMediaRepository) FindByKey(ctx context.Context, key string) (models.Media, error) {
+	data, release := r.s.view()
+	defer release()
+
+	for _, media := range data.media {
+		if media.Key == key {
+			media.Variants = nil
+			return media, nil
+		}
+	}
+	return models.Media{}, ErrNotFound
+}
+
+func (r *memoryMediaRepository) FindVariantByKey(ctx context.Context, key string) (models.MediaVariant, error) {
+	data, release := r.s.view()
+	defer release()
+
+	for _, media := range data.media {
+		for _, variant := range media.Variants {
+			if variant.Key == key {
+				return variant, nil
+			}
+		}
+	}
+	return models.MediaVariant{}, ErrNotFound
+}
+
+func (r *memoryMediaRepository) ListByUser(ctx context.Context, userID uint, page Page) ([]models.Media, error) {
+	data, release := r.s.view()
+	defer release()
+
+	media := []models.Media{}
+	for _, m := range data.media {
+		if m.UserID == userID {
+			media = append(media, m)
+		}
+	}
+	return newestFirst(media, func(m models.Media) uint { return m.ID }, page), nil
+}
+
+func (r *memoryMediaRepository) UsedBytes(ctx context.Context, userID uint) (int64, error) {
+	data, release := r.s.view()
+	defer release()
+
+	if _, ok := data.users[userID]; !ok {
+		return 0, ErrNotFound
+	}
+
+	var used int64
+	for _, media := range data.media {
+		if media.UserID == userID {
+			used += media.Size
+		}
+	}
+	return used, nil
+}
+
+func (r *memoryMediaRepository) Create(ctx context.Context, media *models.Media) error {
+	data, release := r.s.view()
+	defer release()
+
+	for _, stored := range data.media {
+		if stored.Key == media.Key {
+			return ErrDuplicate
+		}
+	}
+	media.ID = data.id()
+	media.CreatedAt = now()
+	media.UpdatedAt = media.CreatedAt
+	data.media[media.ID] = *media
+	return nil
+}
+
+func (r *memoryMediaRepository) Delete(ctx context.Context, media *models.Media) error {
+	data, release := r.s.view()
+	defer release()
+
+	for id, post := range data.posts {
+		if post.FeaturedImageID != nil && *post.FeaturedImageID == media.ID {
+			post.FeaturedImageID = nil
+			data.posts[id] = post
+		}
+	}
+	delete(data.media, media.ID)
+	return nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"blog-api/internal/models"
+)
+
+type memoryNotificationRepository struct {
+	s *MemoryStore
+}
+
+// newer - Urutan inbox: latest_at terbaru dulu, ID sebagai tie-breaker
+func newer(a, b models.Notification) bool {
+	if !a.LatestAt.Equal(b.LatestAt) {
+		return a.LatestAt.After(b.LatestAt)
+	}
+	return a.ID > b.ID
+}
+
+func (r *memoryNotificationRepository) List(ctx context.Context, userID uint, unreadOnly bool, page Page) ([]models.Notification, error) {
+	data, release := r.s.view()
+	defer release()
+
+	var after *models.Notification
+	if page.After != nil {
+		after = &models.Notification{ID: page.After.ID, LatestAt: time.UnixMicro(page.After.Value).UTC()}
+	}
+
+	notifications := []models.Notification{}
+	for _, notification := range data.inbox {
+		if notification.UserID != userID || (unreadOnly && notification.ReadAt != nil) {
+			continue
+		}
+		if after != nil && !newer(*after, notification) {
+			continue
+		}
+		notification.Actor = data.users[notification.ActorID]
+		notifications = append(notifications, notification)
+	}
+	sort.Slice(notifications, func(i, j int) bool { return newer(notifications[i], notifications[j]) })
+	if len(notifications) > page.Limit+1 {
+		notifications = notifications[:page.Limit+1]
+	}
+	return notifications, nil
+}
+
+func (r *memoryNotificationRepository) UnreadCount(ctx context.Context, userID uint) (int64, error) {
+	data, release := r.s.view()
+	defer release()
+
+	var count int64
+	for _, notification := range data.inbox {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryNotificationRepository) Find(ctx context.Context, userID, id uint) (models.Notification, error) {
+	data, release := r.s.view()
+	defer release()
+
+	notification, ok := data.inbox[id]
+	if !ok || notification.UserID != userID {
+		return models.Notification{}, ErrNotFound
+	}
+	return notification, nil
+}
+
+func (r *memoryNotificationRepository) MarkRead(ctx context.Context, notification *models.Notification, at time.Time) error {
+	data, release := r.s.view()
+	defer release()
+
+	if stored, ok := data.inbox[notification.ID]; ok {
+		stored.ReadAt = &at
+		data.inbox[notification.ID] = stored
+	}
+	notification.ReadAt = &at
+	return nil
+}
+
+func (r *memoryNotificationRepository) MarkAllRead(ctx context.Context, userID uint, at time.Time) (int64, error) {
+	data, release := r.s.view()
+	defer release()
+
+	var updated int64
+	for id, notification := range data.inbox {
+		if notification.UserID == userID && notification.ReadAt == nil {
+			notification.ReadAt = &at
+			data.inbox[id] = notification
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (r *memoryNotificationRepository) Preference(ctx context.Context, userID uint) (models.NotificationPreference, error) {
+	data, release := r.s.view()
+	defer release()
+
+	pref, ok := data.preferences[userID]
+	if !ok {
+		return models.DefaultNotificationPreference(userID), nil
+	}
+	return pref, nil
+}
+
+func (r *memoryNotificationRepository) SavePreference(ctx context.Context, pref *models.NotificationPreference) error {
+	data, release := r.s.view()
+	defer release()
+
+	pref.UpdatedAt = now()
+	data.preferences[pref.UserID] = *pref
+	return nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/presence"
+)
+
+type memoryPresenceRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryPresenceRepository) Join(ctx context.Context, postID, userID uint) (string, error) {
+	id, err := presence.NewSessionID()
+	if err != nil {
+		return "", err
+	}
+
+	data, release := r.s.view()
+	defer release()
+
+	now := time.Now()
+	data.sessions[id] = models.EditorSession{
+		ID:         id,
+		PostID:     postID,
+		UserID:     userID,
+		State:      models.EditorStateViewing,
+		LastSeenAt: now,
+		CreatedAt:  now,
+	}
+	return id, nil
+}
+
+func (r *memoryPresenceRepository) Touch(ctx context.Context, sessionID string) error {
+	data, release := r.s.view()
+	defer release()
+
+	now := time.Now()
+	if session, ok := data.sessions[sessionID]; ok {
+		session.LastSeenAt = now
+		data.sessions[sessionID] = session
+	}
+	for postID, lock := range data.locks {
+		if lock.SessionID == sessionID && lock.ExpiresAt.After(now) {
+			lock.ExpiresAt = now.Add(presence.LockTimeout)
+			data.locks[postID] = lock
+		}
+	}
+	return nil
+}
+
+func (r *memoryPresenceRepository) SetState(ctx context.Context, sessionID, state string) error {
+	data, release := r.s.view()
+	defer release()
+
+	if session, ok := data.sessions[sessionID]; ok {
+		session.State = state
+		session.LastSeenAt = time.Now()
+		data.sessions[sessionID] = session
+	}
+	return nil
+}
+
+func (r *memoryPresenceRepository) Leave(ctx context.Context, sessionID string) error {
+	data, release := r.s.view()
+	defer release()
+
+	for postID, lock := range data.locks {
+		if lock.SessionID == sessionID {
+			delete(data.locks, postID)
+		}
+	}
+	delete(data.sessions, sessionID)
+	return nil
+}
+
+func (r *memoryPresenceRepository) AcquireLock(ctx context.Context, postID, userID uint, sessionID string) (models.PostEditLock, bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	now := time.Now()
+	lock, ok := data.locks[postID]
+	if ok && lock.ExpiresAt.After(now) && lock.SessionID != sessionID {
+		return lock, false, nil
+	}
+	if !ok || !lock.ExpiresAt.After(now) {
+		lock = models.PostEditLock{PostID: postID, SessionID: sessionID, UserID: userID, AcquiredAt: now}
+	}
+	lock.ExpiresAt = now.Add(presence.LockTimeout)
+	data.locks[postID] = lock
+	return lock, true, nil
+}
+
+func (r *memoryPresenceRepository) ReleaseLock(ctx context.Context, postID uint, sessionID string) (bool, error) {
+	data, release := r.s.view()
+	defer release()
+
+	lock, ok := data.locks[postID]
+	if !ok || lock.SessionID != sessionID {
+		return false, nil
+	}
+	delete(data.locks, postID)
+	return true, nil
+}
+
+func (r *memoryPresenceRepository) Load(ctx context.Context, postID uint) (presence.Snapshot, error) {
+	data, release := r.s.view()
+	defer release()
+
+	now := time.Now()
+	snapshot := presence.Snapshot{Participants: []presence.Participant{}}
+
+	sessions := []models.EditorSession{}
+	for _, session := range data.sessions {
+		if session.PostID == postID && session.LastSeenAt.After(now.Add(-presence.SessionTimeout)) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if !sessions[i].CreatedAt.Equal(sessions[j].CreatedAt) {
+			return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+		}
+		return sessions[i].ID < sessions[j].ID
+	})
+	for _, session := range sessions {
+		user := data.users[session.UserID]
+		snapshot.Participants = append(snapshot.Participants, presence.Participant{
+			SessionID: session.ID,
+			UserID:    session.UserID,
+			Name:      user.Name,
+			Username:  user.Username,
+			State:     session.State,
+			JoinedAt:  session.CreatedAt,
+		})
+	}
+
+	if lock, ok := data.locks[postID]; ok && lock.ExpiresAt.After(now) {
+		snapshot.Lock = &lock
+	}
+	return snapshot, nil
+}
+
+func (r *memoryPresenceRepository) Cleanup(ctx context.Context) error {
+	data, release := r.s.view()
+	defer release()
+
+	now := time.Now()
+	for id, session := range data.sessions {
+		if !session.LastSeenAt.After(now.Add(-presence.SessionTimeout)) {
+			delete(data.sessions, id)
+		}
+	}
+	for postID, lock := range data.locks {
+		if !lock.ExpiresAt.After(now) {
+			delete(data.locks, postID)
+		}
+	}
+	return nil
+}
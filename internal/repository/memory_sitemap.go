@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	"blog-api/internal/models"
+)
+
+type memorySitemapRepository struct {
+	s *MemoryStore
+}
+
+// entries - Semua entry section urut ID, sama dengan gormSitemapRepository.query
+func (r *memorySitemapRepository) entries(section string) []SitemapEntry {
+	data, release := r.s.view()
+	defer release()
+
+	posts := []models.Post{}
+	for _, post := range data.posts {
+		if !post.DeletedAt.Valid {
+			posts = append(posts, post)
+		}
+	}
+	sort.Slice(posts, func(i, j int) bool { return posts[i].ID < posts[j].ID })
+
+	type group struct {
+		id        uint
+		slug      string
+		updatedAt time.Time
+	}
+	groups := make(map[uint]*group)
+	touch := func(id uint, slug string, updatedAt time.Time) {
+		g, ok := groups[id]
+		if !ok {
+			g = &group{id: id, slug: slug}
+			groups[id] = g
+		}
+		if updatedAt.After(g.updatedAt) {
+			g.updatedAt = updatedAt
+		}
+	}
+
+	entries := []SitemapEntry{}
+	switch section {
+	case SitemapPosts:
+		for _, post := range posts {
+			entries = append(entries, SitemapEntry{Slug: strconv.FormatUint(uint64(post.ID), 10), UpdatedAt: post.UpdatedAt})
+		}
+		return entries
+	case SitemapAuthors:
+		for _, post := range posts {
+			if user, ok := data.users[post.UserID]; ok {
+				touch(user.ID, user.Username, post.UpdatedAt)
+			}
+		}
+	case SitemapTags:
+		for _, post := range posts {
+			for _, name := range data.postTags[post.ID] {
+				touch(data.tags[name], name, post.UpdatedAt)
+			}
+		}
+	default:
+		return entries
+	}
+
+	sorted := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].id < sorted[j].id })
+	for _, g := range sorted {
+		entries = append(entries, SitemapEntry{Slug: g.slug, UpdatedAt: g.updatedAt})
+	}
+	return entries
+}
+
+func (r *memorySitemapRepository) Count(ctx context.Context, section string) (int64, error) {
+	return int64(len(r.entries(section))), nil
+}
+
+func (r *memorySitemapRepository) Entries(ctx context.Context, section string, offset, limit int) ([]SitemapEntry, error) {
+	entries := r.entries(section)
+	if offset >= len(entries) {
+		return []SitemapEntry{}, nil
+	}
+	entries = entries[offset:]
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
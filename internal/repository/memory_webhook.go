@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"blog-api/internal/models"
+)
+
+type memoryWebhookRepository struct {
+	s *MemoryStore
+}
+
+func (r *memoryWebhookRepository) Find(ctx context.Context, userID, id uint) (models.Webhook, error) {
+	data, release := r.s.view()
+	defer release()
+
+	hook, ok := data.webhooks[id]
+	if !ok || hook.UserID != userID {
+		return models.Webhook{}, ErrNotFound
+	}
+	return hook, nil
+}
+
+func (r *memoryWebhookRepository) ListByUser(ctx context.Context, userID uint) ([]models.Webhook, error) {
+	data, release := r.s.view()
+	defer release()
+
+	webhooks := []models.Webhook{}
+	for _, hook := range data.webhooks {
+		if hook.UserID == userID {
+			webhooks = append(webhooks, hook)
+		}
+	}
+	sort.Slice(webhooks, func(i, j int) bool { return webhooks[i].ID < webhooks[j].ID })
+	return webhooks, nil
+}
+
+func (r *memoryWebhookRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	webhooks, err := r.ListByUser(ctx, userID)
+	return int64(len(webhooks)), err
+}
+
+func (r *memoryWebhookRepository) Create(ctx context.Context, hook *models.Webhook) error {
+	data, release := r.s.view()
+	defer release()
+
+	hook.ID = data.id()
+	hook.CreatedAt = now()
+	hook.UpdatedAt = hook.CreatedAt
+	data.webhooks[hook.ID] = *hook
+	return nil
+}
+
+func (r *memoryWebhookRepository) Update(ctx context.Context, hook *models.Webhook) error {
+	data, release := r.s.view()
+	defer release()
+
+	hook.UpdatedAt = now()
+	data.webhooks[hook.ID] = *hook
+	return nil
+}
+
+func (r *memoryWebhookRepository) Delete(ctx context.Context, hook *models.Webhook) error {
+	data, release := r.s.view()
+	defer release()
+
+	for id, delivery := range data.deliveries {
+		if delivery.WebhookID == hook.ID {
+			delete(data.deliveries, id)
+		}
+	}
+	delete(data.webhooks, hook.ID)
+	return nil
+}
+
+func (r *memoryWebhookRepository) Deliveries(ctx context.Context, webhookID uint, status string, page Page) ([]models.WebhookDelivery, error) {
+	data, release := r.s.view()
+	defer release()
+
+	deliveries := []models.WebhookDelivery{}
+	for _, delivery := range data.deliveries {
+		if delivery.WebhookID == webhookID && (status == "" || delivery.Status == status) {
+			deliveries = append(deliveries, delivery)
+		}
+	}
+	return newestFirst(deliveries, func(d models.WebhookDelivery) uint { return d.ID }, page), nil
+}
+
+func (r *memoryWebhookRepository) FindDelivery(ctx context.Context, webhookID, id uint) (models.WebhookDelivery, error) {
+	data, release := r.s.view()
+	defer release()
+
+	delivery, ok := data.deliveries[id]
+	if !ok || delivery.WebhookID != webhookID {
+		return models.WebhookDelivery{}, ErrNotFound
+	}
+	return delivery, nil
+}
+
+func (r *memoryWebhookRepository) FindEvent(ctx context.Context, id uint) (models.WebhookEvent, error) {
+	data, release := r.s.view()
+	defer release()
+
+	event, ok := data.webhookEvents[id]
+	if !ok {
+		return models.WebhookEvent{}, ErrNotFound
+	}
+	return event, nil
+}
+
+func (r *memoryWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	data, release := r.s.view()
+	defer release()
+
+	delivery.ID = data.id()
+	delivery.CreatedAt = now()
+	delivery.UpdatedAt = delivery.CreatedAt
+	data.deliveries[delivery.ID] = *delivery
+	return nil
+}
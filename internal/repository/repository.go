@@ -0,0 +1,306 @@
+// Package repository berisi semua akses data aplikasi (post, comment, user, reaction, bookmark,
+// reading list, follow, feed, notifikasi, media, webhook, sitemap dan presence) di balik interface,
+// dengan implementasi GORM (dipakai server) dan in-memory (untuk test dan wiring tanpa database).
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+	"blog-api/internal/presence"
+)
+
+// ErrNotFound - Data tidak ditemukan (atau sudah di-soft delete)
+var ErrNotFound = errors.New("record not found")
+
+// ErrDuplicate - Pelanggaran unique index (email atau username sudah dipakai)
+var ErrDuplicate = errors.New("duplicate key value violates unique constraint")
+
+// Cursor - Posisi terakhir pada list (nilai sort + ID sebagai tie-breaker)
+type Cursor struct {
+	Value int64
+	ID    uint
+}
+
+// Page - Argumen satu halaman list. Sort sudah divalidasi service:
+// post newest|top, comment oldest|newest|top. Repository mengambil Limit+1 item
+// agar service tahu masih ada halaman berikutnya.
+type Page struct {
+	Sort  string
+	After *Cursor // nil untuk halaman pertama
+	Limit int
+}
+
+// Store - Sumber semua repository. Transaction menjalankan fn dengan Store yang terikat
+// ke satu transaksi; error dari fn membatalkan semua perubahan.
+type Store interface {
+	Posts() PostRepository
+	Comments() CommentRepository
+	Users() UserRepository
+	Mentions() MentionRepository
+	Outbox() OutboxRepository
+	Reactions() ReactionRepository
+	Bookmarks() BookmarkRepository
+	ReadingLists() ReadingListRepository
+	Follows() FollowRepository
+	Feed() FeedRepository
+	Notifications() NotificationRepository
+	Media() MediaRepository
+	Webhooks() WebhookRepository
+	Sitemap() SitemapRepository
+	Presence() PresenceRepository
+	Transaction(ctx context.Context, fn func(tx Store) error) error
+}
+
+type PostRepository interface {
+	// Find - Post tanpa relasi
+	Find(ctx context.Context, id uint) (models.Post, error)
+	// FindWithDeleted - Seperti Find, termasuk post yang sudah di-soft delete
+	FindWithDeleted(ctx context.Context, id uint) (models.Post, error)
+	// Get - Post beserta author, tag dan featured image
+	Get(ctx context.Context, id uint) (models.Post, error)
+	// FindByIDs - Banyak post sekaligus tanpa relasi, urutan tidak dijamin
+	FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error)
+	// All - Semua post beserta author, tag dan featured image (sort kosong atau top)
+	All(ctx context.Context, sort string) ([]models.Post, error)
+	// List - Satu halaman post tanpa relasi
+	List(ctx context.Context, page Page) ([]models.Post, error)
+	// ListByUsers - Satu halaman post (tanpa relasi) untuk tiap author, urut per author
+	ListByUsers(ctx context.Context, userIDs []uint, page Page) ([]models.Post, error)
+	// Tags - Tag (urut nama) per post, post tanpa tag mendapat list kosong
+	Tags(ctx context.Context, postIDs []uint) (map[uint][]models.Tag, error)
+	Create(ctx context.Context, post *models.Post) error
+	Update(ctx context.Context, post *models.Post) error
+	Delete(ctx context.Context, post *models.Post) error
+	// ReplaceTags - Ganti tag post dengan daftar baru, tag yang belum ada dibuat
+	ReplaceTags(ctx context.Context, post *models.Post, names []string) error
+	// FanOut - Masukkan post ke feed semua follower author
+	FanOut(ctx context.Context, post models.Post) error
+	// MediaOwner - User pemilik media, dipakai untuk validasi featured image
+	MediaOwner(ctx context.Context, mediaID uint) (uint, error)
+	// FindTag - Tag dengan nama (lowercase)
+	FindTag(ctx context.Context, name string) (models.Tag, error)
+	// Latest - Post terbaru beserta author dan tag, hanya milik userID dan/atau dengan tagID jika bukan 0
+	Latest(ctx context.Context, userID, tagID uint, limit int) ([]models.Post, error)
+}
+
+type CommentRepository interface {
+	// Find - Comment pada post tanpa relasi
+	Find(ctx context.Context, postID, id uint) (models.Comment, error)
+	// FindDeleted - Comment pada post yang sudah di-soft delete
+	FindDeleted(ctx context.Context, postID, id uint) (models.Comment, error)
+	// Get - Comment beserta author
+	Get(ctx context.Context, id uint) (models.Comment, error)
+	// List - Satu halaman comment pada post beserta author
+	List(ctx context.Context, postID uint, page Page) ([]models.Comment, error)
+	// ListByPosts - Satu halaman comment (tanpa relasi) untuk tiap post, urut per post
+	ListByPosts(ctx context.Context, postIDs []uint, page Page) ([]models.Comment, error)
+	Create(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, comment *models.Comment) error
+	Restore(ctx context.Context, comment *models.Comment) error
+	// AdjustCount - Ubah comment_count post, tidak pernah menjadi negatif
+	AdjustCount(ctx context.Context, postID uint, delta int) error
+}
+
+type UserRepository interface {
+	Find(ctx context.Context, id uint) (models.User, error)
+	FindByIDs(ctx context.Context, ids []uint) ([]models.User, error)
+	FindByEmail(ctx context.Context, email string) (models.User, error)
+	FindByUsername(ctx context.Context, username string) (models.User, error)
+	FindByUsernames(ctx context.Context, usernames []string) ([]models.User, error)
+	// UsernameTaken - Termasuk user yang sudah di-soft delete (tetap terpakai di unique index)
+	UsernameTaken(ctx context.Context, username string) (bool, error)
+	// Search - User dengan prefix username, urut username
+	Search(ctx context.Context, prefix string, limit int) ([]models.User, error)
+	// Create - ErrDuplicate jika email atau username sudah dipakai
+	Create(ctx context.Context, user *models.User) error
+	// UpdateUsername - ErrDuplicate jika username sudah dipakai
+	UpdateUsername(ctx context.Context, user *models.User, username string) error
+}
+
+type MentionRepository interface {
+	// ForTarget - Mention yang tersimpan untuk satu post atau comment
+	ForTarget(ctx context.Context, targetType string, targetID uint) ([]models.Mention, error)
+	Create(ctx context.Context, mention *models.Mention) error
+	Delete(ctx context.Context, mention *models.Mention) error
+	// Usernames - Username yang di-mention per target untuk banyak target sekaligus
+	Usernames(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]bool, error)
+}
+
+// ReactionRepository - Reaction user pada post atau comment (target_type + target_id)
+type ReactionRepository interface {
+	// Add - Simpan reaction, false jika user sudah memberi reaction yang sama
+	Add(ctx context.Context, reaction *models.Reaction) (bool, error)
+	// Remove - Hapus reaction, false jika reaction tidak ada
+	Remove(ctx context.Context, reaction models.Reaction) (bool, error)
+	// AdjustScore - Ubah score post atau comment target (dipakai sort top)
+	AdjustScore(ctx context.Context, targetType string, targetID uint, delta int) error
+	// Counts - Jumlah reaction per kind untuk banyak target sekaligus
+	Counts(ctx context.Context, targetType string, targetIDs []uint) (map[uint]map[string]int64, error)
+	// ByUser - Kind reaction milik user per target, urut waktu reaction
+	ByUser(ctx context.Context, targetType string, targetIDs []uint, userID uint) (map[uint][]string, error)
+}
+
+// BookmarkRepository - Post yang disimpan user
+type BookmarkRepository interface {
+	// Add - Simpan bookmark, idempotent
+	Add(ctx context.Context, userID, postID uint) error
+	// Remove - Hapus bookmark, idempotent
+	Remove(ctx context.Context, userID, postID uint) error
+	// List - Satu halaman bookmark user (terbaru dulu) beserta post dan author-nya,
+	// bookmark untuk post yang sudah dihapus dilewati
+	List(ctx context.Context, userID uint, page Page) ([]models.Bookmark, error)
+	// Bookmarked - Post mana saja dari postIDs yang di-bookmark user
+	Bookmarked(ctx context.Context, userID uint, postIDs []uint) (map[uint]bool, error)
+}
+
+// ReadingListRepository - Reading list milik user beserta itemnya
+type ReadingListRepository interface {
+	Find(ctx context.Context, id uint) (models.ReadingList, error)
+	// FindByShareToken - Reading list beserta pemiliknya
+	FindByShareToken(ctx context.Context, token string) (models.ReadingList, error)
+	// ListByUser - Semua reading list milik user, urut ID
+	ListByUser(ctx context.Context, userID uint) ([]models.ReadingList, error)
+	Create(ctx context.Context, list *models.ReadingList) error
+	Update(ctx context.Context, list *models.ReadingList) error
+	Delete(ctx context.Context, list *models.ReadingList) error
+	// Items - Item reading list (urut waktu ditambahkan) beserta post dan author-nya,
+	// item untuk post yang sudah dihapus dilewati
+	Items(ctx context.Context, listID uint) ([]models.ReadingListItem, error)
+	// AddItem - Tambah post ke reading list, idempotent
+	AddItem(ctx context.Context, listID, postID uint) error
+	// RemoveItem - Hapus post dari reading list, idempotent
+	RemoveItem(ctx context.Context, listID, postID uint) error
+}
+
+// FollowRepository - Relasi follower -> followee antar user
+type FollowRepository interface {
+	// Add - Simpan follow, false jika sudah follow
+	Add(ctx context.Context, followerID, followeeID uint) (bool, error)
+	// Remove - Hapus follow, idempotent
+	Remove(ctx context.Context, followerID, followeeID uint) error
+	// Followers - Satu halaman follow ke user (terbaru dulu) beserta Follower
+	Followers(ctx context.Context, userID uint, page Page) ([]models.Follow, error)
+	// Following - Satu halaman follow oleh user (terbaru dulu) beserta Followee
+	Following(ctx context.Context, userID uint, page Page) ([]models.Follow, error)
+}
+
+// FeedRepository - Timeline post dari author yang di-follow
+type FeedRepository interface {
+	// List - Satu halaman feed user (terbaru dulu) beserta author dan featured image. fanout true
+	// membaca tabel fan-out (diisi PostRepository.FanOut dan Backfill), false menghitung dari follow.
+	List(ctx context.Context, userID uint, fanout bool, page Page) ([]models.Post, error)
+	// Backfill - Masukkan sampai limit post terbaru author ke feed follower baru
+	Backfill(ctx context.Context, followerID, authorID uint, limit int) error
+	// RemoveAuthor - Hapus semua post author dari feed follower
+	RemoveAuthor(ctx context.Context, followerID, authorID uint) error
+}
+
+// NotificationRepository - Inbox notifikasi (ditulis lewat OutboxRepository.Notify) dan preferensinya
+type NotificationRepository interface {
+	// List - Satu halaman notifikasi user beserta actor, aktivitas terbaru dulu
+	// (Cursor.Value berisi latest_at dalam microsecond)
+	List(ctx context.Context, userID uint, unreadOnly bool, page Page) ([]models.Notification, error)
+	UnreadCount(ctx context.Context, userID uint) (int64, error)
+	// Find - Notifikasi milik user
+	Find(ctx context.Context, userID, id uint) (models.Notification, error)
+	// MarkRead - Isi read_at notifikasi
+	MarkRead(ctx context.Context, notification *models.Notification, at time.Time) error
+	// MarkAllRead - Tandai semua notifikasi user yang belum dibaca, mengembalikan jumlahnya
+	MarkAllRead(ctx context.Context, userID uint, at time.Time) (int64, error)
+	// Preference - Preferensi notifikasi user, default jika belum diatur
+	Preference(ctx context.Context, userID uint) (models.NotificationPreference, error)
+	SavePreference(ctx context.Context, pref *models.NotificationPreference) error
+}
+
+// MediaRepository - Metadata media upload dan variant-nya (isi file ada di storage)
+type MediaRepository interface {
+	// Get - Media beserta variant
+	Get(ctx context.Context, id uint) (models.Media, error)
+	// FindByKey - Media dengan key file asli
+	FindByKey(ctx context.Context, key string) (models.Media, error)
+	// FindVariantByKey - Variant dengan key file
+	FindVariantByKey(ctx context.Context, key string) (models.MediaVariant, error)
+	// ListByUser - Satu halaman media milik user (terbaru dulu) beserta variant
+	ListByUser(ctx context.Context, userID uint, page Page) ([]models.Media, error)
+	// UsedBytes - Total ukuran media milik user. Di dalam transaksi baris user dikunci sampai commit
+	// agar upload bersamaan dari user yang sama tidak melewati kuota.
+	UsedBytes(ctx context.Context, userID uint) (int64, error)
+	Create(ctx context.Context, media *models.Media) error
+	// Delete - Hapus media beserta variant, post yang memakainya sebagai featured image dikosongkan
+	Delete(ctx context.Context, media *models.Media) error
+}
+
+// WebhookRepository - Subscription webhook milik user dan log delivery-nya
+type WebhookRepository interface {
+	// Find - Webhook milik user
+	Find(ctx context.Context, userID, id uint) (models.Webhook, error)
+	// ListByUser - Semua webhook milik user, urut ID
+	ListByUser(ctx context.Context, userID uint) ([]models.Webhook, error)
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+	Create(ctx context.Context, hook *models.Webhook) error
+	Update(ctx context.Context, hook *models.Webhook) error
+	// Delete - Hapus webhook beserta log delivery-nya
+	Delete(ctx context.Context, hook *models.Webhook) error
+	// Deliveries - Satu halaman delivery webhook (terbaru dulu), status kosong berarti semua status
+	Deliveries(ctx context.Context, webhookID uint, status string, page Page) ([]models.WebhookDelivery, error)
+	// FindDelivery - Delivery milik webhook
+	FindDelivery(ctx context.Context, webhookID, id uint) (models.WebhookDelivery, error)
+	// FindEvent - Event outbox yang dikirim sebuah delivery
+	FindEvent(ctx context.Context, id uint) (models.WebhookEvent, error)
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+}
+
+// Section sitemap
+const (
+	SitemapPosts   = "posts"
+	SitemapAuthors = "authors"
+	SitemapTags    = "tags"
+)
+
+// SitemapEntry - Satu halaman publik di sitemap: slug (ID post, username atau nama tag)
+// dan waktu perubahan terakhir
+type SitemapEntry struct {
+	Slug      string
+	UpdatedAt time.Time
+}
+
+// SitemapRepository - Halaman publik untuk sitemap. Hanya post yang tidak dihapus yang dihitung,
+// author dan tag hanya dimasukkan jika punya minimal satu post.
+type SitemapRepository interface {
+	// Count - Jumlah entry section
+	Count(ctx context.Context, section string) (int64, error)
+	// Entries - Entry section urut ID, UpdatedAt author dan tag dari post terbaru yang terkait
+	Entries(ctx context.Context, section string, offset, limit int) ([]SitemapEntry, error)
+}
+
+// PresenceRepository - Session editor dan advisory edit lock per post
+type PresenceRepository interface {
+	// Join - Catat session baru (state viewing) dan kembalikan ID-nya
+	Join(ctx context.Context, postID, userID uint) (string, error)
+	// Touch - Heartbeat: perpanjang session dan lock yang dipegang session
+	Touch(ctx context.Context, sessionID string) error
+	// SetState - Ubah state session (viewing / editing)
+	SetState(ctx context.Context, sessionID, state string) error
+	// Leave - Hapus session beserta lock yang dipegangnya
+	Leave(ctx context.Context, sessionID string) error
+	// AcquireLock - Ambil lock edit post untuk session. Jika lock dipegang session lain yang masih aktif,
+	// ok false dan lock pemegang saat ini dikembalikan.
+	AcquireLock(ctx context.Context, postID, userID uint, sessionID string) (lock models.PostEditLock, ok bool, err error)
+	// ReleaseLock - Lepas lock jika dipegang session, false jika session tidak memegang lock
+	ReleaseLock(ctx context.Context, postID uint, sessionID string) (bool, error)
+	// Load - Session yang masih aktif (urut waktu join) dan lock yang belum expired
+	Load(ctx context.Context, postID uint) (presence.Snapshot, error)
+	// Cleanup - Hapus session tanpa heartbeat dan lock yang expired
+	Cleanup(ctx context.Context) error
+}
+
+// OutboxRepository - Efek samping yang ditulis di transaksi yang sama dengan perubahan data
+type OutboxRepository interface {
+	Notify(ctx context.Context, event notifier.Event) error
+	// EmitWebhook - Antre event ke semua webhook aktif milik user
+	EmitWebhook(ctx context.Context, userID uint, eventType string, data interface{}) error
+}
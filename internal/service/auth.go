@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"blog-api/internal/tracing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenService - Terbitkan dan validasi JWT user dengan JWT_SECRET
+type TokenService struct {
+	secret []byte
+}
+
+func NewTokenService(secret string) *TokenService {
+	return &TokenService{secret: []byte(secret)}
+}
+
+// Generate - Buat JWT untuk user, berlaku 24 jam
+func (s *TokenService) Generate(ctx context.Context, userID uint) (string, error) {
+	_, span := tracing.Tracer().Start(ctx, "jwt.Sign")
+	defer span.End()
+
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Token berlaku 24 jam
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(s.secret)
+}
+
+// Validate - Validasi JWT (tanpa prefix "Bearer") dan ambil user_id
+func (s *TokenService) Validate(tokenString string) (uint, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		// Validasi signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return s.secret, nil
+	})
+
+	if err != nil || !token.Valid {
+		return 0, newError(CodeUnauthenticated, "Invalid or expired token")
+	}
+
+	// Ekstrak user_id dari claims
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return 0, newError(CodeUnauthenticated, "Invalid token claims")
+	}
+
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return 0, newError(CodeUnauthenticated, "Invalid user_id in token")
+	}
+
+	return uint(userID), nil
+}
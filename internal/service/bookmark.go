@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// BookmarkService - Bookmark post milik user dan flag bookmarked untuk list post
+type BookmarkService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewBookmarkService(cfg *config.Config, store repository.Store) *BookmarkService {
+	return &BookmarkService{cfg: cfg, store: store}
+}
+
+// Add - Simpan post ke bookmark user (idempotent)
+func (s *BookmarkService) Add(ctx context.Context, userID, postID uint) error {
+	// Cek apakah post exists
+	if _, err := s.store.Posts().Find(ctx, postID); err != nil {
+		return newError(CodeNotFound, "Post not found")
+	}
+
+	if err := s.store.Bookmarks().Add(ctx, userID, postID); err != nil {
+		return newError(CodeInternal, "Failed to bookmark post")
+	}
+	return nil
+}
+
+// Remove - Hapus post dari bookmark user (idempotent)
+func (s *BookmarkService) Remove(ctx context.Context, userID, postID uint) error {
+	if err := s.store.Bookmarks().Remove(ctx, userID, postID); err != nil {
+		return newError(CodeInternal, "Failed to remove bookmark")
+	}
+	return nil
+}
+
+// List - Satu halaman bookmark user (terbaru dulu), post yang sudah dihapus tidak ikut
+func (s *BookmarkService) List(ctx context.Context, userID uint, opts PageOptions) ([]models.Bookmark, string, error) {
+	page, err := opts.toPage("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	bookmarks, err := s.store.Bookmarks().List(ctx, userID, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch bookmarks")
+	}
+	bookmarks, nextCursor := trimPage(bookmarks, page.Limit, func(b models.Bookmark) Cursor {
+		return Cursor{ID: b.ID}
+	})
+
+	bookmarked := true
+	for i := range bookmarks {
+		bookmarks[i].Post.Bookmarked = &bookmarked
+	}
+	return bookmarks, nextCursor, nil
+}
+
+// AttachToPosts - Isi flag bookmarked pada list post; viewerID 0 (anonymous) membiarkan flag kosong
+func (s *BookmarkService) AttachToPosts(ctx context.Context, viewerID uint, posts []models.Post) error {
+	if viewerID == 0 || len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+
+	bookmarked, err := s.store.Bookmarks().Bookmarked(ctx, viewerID, ids)
+	if err != nil {
+		return newError(CodeInternal, "Failed to fetch bookmarks")
+	}
+	for i := range posts {
+		value := bookmarked[posts[i].ID]
+		posts[i].Bookmarked = &value
+	}
+	return nil
+}
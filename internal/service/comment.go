@@ -0,0 +1,410 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/metrics"
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+	"blog-api/internal/ratelimit"
+	"blog-api/internal/repository"
+)
+
+// Event realtime pada topic comment sebuah post
+const (
+	CommentEventCreated = "comment.created"
+	CommentEventDeleted = "comment.deleted"
+)
+
+// webhookCommentData - Payload event comment.*
+type webhookCommentData struct {
+	ID        uint      `json:"id"`
+	PostID    uint      `json:"post_id"`
+	UserID    uint      `json:"user_id"`
+	Content   string    `json:"content,omitempty"`
+	PostURL   string    `json:"post_url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommentTopic - Topic realtime untuk comment sebuah post
+func CommentTopic(postID uint) string {
+	return fmt.Sprintf("post:%d:comments", postID)
+}
+
+// CommentPage - Satu halaman comment dengan cursor halaman berikutnya (kosong jika habis)
+type CommentPage struct {
+	Comments   []models.Comment
+	NextCursor string
+}
+
+// CommentService - Aturan bisnis comment: validasi, ownership, counter, mention, notifikasi,
+// event webhook dan event realtime
+type CommentService struct {
+	store    repository.Store
+	limits   ratelimit.Store
+	hub      Publisher
+	webhooks Waker
+	policy   config.RateLimitPolicy
+	now      func() time.Time
+}
+
+// NewCommentService - Buat CommentService; nil limits atau policy "comment" yang dimatikan
+// berarti Create tanpa rate limit
+func NewCommentService(cfg *config.Config, store repository.Store, limits ratelimit.Store, hub Publisher, webhooks Waker) *CommentService {
+	policy, ok := cfg.RateLimitPolicies["comment"]
+	if !ok {
+		limits = nil
+	}
+	return &CommentService{store: store, limits: limits, hub: hub, webhooks: webhooks, policy: policy, now: time.Now}
+}
+
+// takeCommentToken - Rate limit comment per user, berlaku sama untuk REST, GraphQL dan gRPC.
+// Status bucket dikembalikan untuk header (nil jika limit mati atau store gagal); gangguan store
+// tidak memblokir comment (fail open seperti middleware.RateLimiter).
+func (s *CommentService) takeCommentToken(ctx context.Context, userID uint) (*RateLimit, error) {
+	if s.limits == nil {
+		return nil, nil
+	}
+	key := "comment:user:" + strconv.FormatUint(uint64(userID), 10)
+	res, err := s.limits.Take(ctx, key, s.policy, s.now())
+	if err != nil {
+		logging.FromContext(ctx).Error("rate limiter unavailable", "policy", "comment", "error", err)
+		return nil, nil
+	}
+	limit := &RateLimit{Policy: s.policy, Result: res}
+	if !res.Allowed {
+		metrics.RateLimited.WithLabelValues("comment").Inc()
+		return limit, &Error{
+			Code:      CodeRateLimited,
+			Message:   "Too many requests, please try again later",
+			RateLimit: limit,
+		}
+	}
+	return limit, nil
+}
+
+// commentSort - Sort list comment yang valid, kosong berarti oldest
+func commentSort(sort string) (string, error) {
+	switch sort {
+	case "":
+		return "oldest", nil
+	case "oldest", "newest", "top":
+		return sort, nil
+	default:
+		return "", newError(CodeInvalid, "Invalid sort, must be one of: oldest, newest, top")
+	}
+}
+
+// Create - Validasi dan simpan comment baru pada post (dengan transaksi). Token rate limit
+// baru diambil setelah input valid dan post ada; status bucket-nya ikut dikembalikan.
+func (s *CommentService) Create(ctx context.Context, origin Origin, userID, postID uint, content string) (models.Comment, *RateLimit, error) {
+	// Validasi input
+	valid, errMsg := ValidateRequired(map[string]string{
+		"content": content,
+	})
+	if !valid {
+		return models.Comment{}, nil, newError(CodeInvalid, errMsg)
+	}
+
+	if !ValidateStringLength(content, 1, 1000) {
+		return models.Comment{}, nil, newError(CodeInvalid, "Content must be between 1 and 1000 characters")
+	}
+
+	// Comment ke post yang tidak ada tidak menghabiskan kuota
+	if _, err := s.store.Posts().Find(ctx, postID); err != nil {
+		return models.Comment{}, nil, newError(CodeNotFound, "Post not found")
+	}
+
+	limit, err := s.takeCommentToken(ctx, userID)
+	if err != nil {
+		return models.Comment{}, limit, err
+	}
+
+	comment := models.Comment{
+		Content: content,
+		UserID:  userID,
+		PostID:  postID,
+	}
+
+	err = s.store.Transaction(ctx, func(tx repository.Store) error {
+		// Cek apakah post exists
+		post, err := tx.Posts().Find(ctx, postID)
+		if err != nil {
+			return newError(CodeNotFound, "Post not found")
+		}
+
+		if err := tx.Comments().Create(ctx, &comment); err != nil {
+			return err
+		}
+
+		if err := tx.Comments().AdjustCount(ctx, post.ID, 1); err != nil {
+			return err
+		}
+
+		// Notifikasi ke author post
+		err = tx.Outbox().Notify(ctx, notifier.Event{
+			Type:        models.NotificationTypeComment,
+			RecipientID: post.UserID,
+			ActorID:     userID,
+			PostID:      post.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := syncMentions(ctx, tx, models.ReactionTargetComment, comment.ID, post.ID, userID, comment.Content); err != nil {
+			return err
+		}
+
+		// Preload user data
+		loaded, err := tx.Comments().Get(ctx, comment.ID)
+		if err != nil {
+			return newError(CodeInternal, "Failed to load comment data")
+		}
+		comment = loaded
+
+		return emitCommentEvent(ctx, tx, origin, models.WebhookEventCommentCreated, post, comment)
+	})
+	if err != nil {
+		return comment, limit, orInternal(err, "Failed to create comment")
+	}
+
+	metrics.CommentsCreated.Inc()
+	wake(s.webhooks)
+	comment = s.rendered(ctx, comment)
+	s.publishEvent(CommentEventCreated, comment)
+	return comment, limit, nil
+}
+
+// List - Comment pada post dengan cursor pagination, sort oldest (default), newest atau top.
+// Author ikut dimuat.
+func (s *CommentService) List(ctx context.Context, postID uint, opts PageOptions) ([]models.Comment, string, error) {
+	sort, err := commentSort(opts.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := opts.toPage(sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Cek apakah post exists
+	if _, err := s.store.Posts().Find(ctx, postID); err != nil {
+		return nil, "", newError(CodeNotFound, "Post not found")
+	}
+
+	comments, err := s.store.Comments().List(ctx, postID, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch comments")
+	}
+	comments, nextCursor := trimPage(comments, page.Limit, commentCursor)
+
+	if err := renderComments(ctx, s.store, comments); err != nil {
+		return nil, "", newError(CodeInternal, "Failed to render comments")
+	}
+	return comments, nextCursor, nil
+}
+
+// ListByPosts - Satu halaman comment (tanpa relasi) untuk tiap post dengan satu query,
+// dipakai dataloader GraphQL
+func (s *CommentService) ListByPosts(ctx context.Context, postIDs []uint, opts PageOptions) (map[uint]CommentPage, error) {
+	sort, err := commentSort(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+	page, err := opts.toPage(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.store.Comments().ListByPosts(ctx, postIDs, page)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch comments")
+	}
+	if err := renderComments(ctx, s.store, comments); err != nil {
+		return nil, newError(CodeInternal, "Failed to render comments")
+	}
+
+	byPost := make(map[uint][]models.Comment)
+	for _, comment := range comments {
+		byPost[comment.PostID] = append(byPost[comment.PostID], comment)
+	}
+
+	result := make(map[uint]CommentPage, len(postIDs))
+	for _, postID := range postIDs {
+		var postPage CommentPage
+		postPage.Comments, postPage.NextCursor = trimPage(byPost[postID], page.Limit, commentCursor)
+		result[postID] = postPage
+	}
+	return result, nil
+}
+
+// Previews - Beberapa comment pertama (oldest) beserta author untuk tiap post, dipakai list
+// post REST agar response tidak memuat semua comment
+func (s *CommentService) Previews(ctx context.Context, postIDs []uint, limit int) (map[uint][]models.Comment, error) {
+	pages, err := s.ListByPosts(ctx, postIDs, PageOptions{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	var userIDs []uint
+	for _, page := range pages {
+		for _, comment := range page.Comments {
+			userIDs = append(userIDs, comment.UserID)
+		}
+	}
+	users, err := s.store.Users().FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch comments")
+	}
+	byID := make(map[uint]models.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+
+	previews := make(map[uint][]models.Comment, len(pages))
+	for postID, page := range pages {
+		for i := range page.Comments {
+			page.Comments[i].User = byID[page.Comments[i].UserID]
+		}
+		previews[postID] = page.Comments
+	}
+	return previews, nil
+}
+
+// Delete - Soft delete comment milik user (dengan transaksi)
+func (s *CommentService) Delete(ctx context.Context, origin Origin, userID, postID, commentID uint) error {
+	var comment models.Comment
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		if comment, err = tx.Comments().Find(ctx, postID, commentID); err != nil {
+			return newError(CodeNotFound, "Comment not found")
+		}
+
+		// Cek ownership
+		if comment.UserID != userID {
+			return newError(CodeForbidden, "You can only delete your own comments")
+		}
+
+		// Soft delete comment
+		if err := tx.Comments().Delete(ctx, &comment); err != nil {
+			return err
+		}
+
+		if err := tx.Comments().AdjustCount(ctx, comment.PostID, -1); err != nil {
+			return err
+		}
+
+		// Event dikirim ke webhook author post
+		post, err := tx.Posts().FindWithDeleted(ctx, comment.PostID)
+		if err != nil {
+			return err
+		}
+
+		return emitCommentEvent(ctx, tx, origin, models.WebhookEventCommentDeleted, post, comment)
+	})
+	if err != nil {
+		return orInternal(err, "Failed to delete comment")
+	}
+
+	wake(s.webhooks)
+	s.publishEvent(CommentEventDeleted, comment)
+	return nil
+}
+
+// Restore - Kembalikan comment milik user yang sudah di-soft delete (dengan transaksi)
+func (s *CommentService) Restore(ctx context.Context, userID, postID, commentID uint) (models.Comment, error) {
+	var comment models.Comment
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		// Post harus masih ada agar comment bisa dikembalikan
+		post, err := tx.Posts().Find(ctx, postID)
+		if err != nil {
+			return newError(CodeNotFound, "Post not found")
+		}
+
+		if comment, err = tx.Comments().FindDeleted(ctx, postID, commentID); err != nil {
+			return newError(CodeNotFound, "Deleted comment not found")
+		}
+
+		// Cek ownership
+		if comment.UserID != userID {
+			return newError(CodeForbidden, "You can only restore your own comments")
+		}
+
+		if err := tx.Comments().Restore(ctx, &comment); err != nil {
+			return err
+		}
+
+		if err := tx.Comments().AdjustCount(ctx, post.ID, 1); err != nil {
+			return err
+		}
+
+		// Preload user data
+		loaded, err := tx.Comments().Get(ctx, comment.ID)
+		if err != nil {
+			return newError(CodeInternal, "Failed to load comment data")
+		}
+		comment = loaded
+		return nil
+	})
+	if err != nil {
+		return comment, orInternal(err, "Failed to restore comment")
+	}
+
+	// Comment yang dikembalikan muncul lagi di stream sebagai comment baru
+	comment = s.rendered(ctx, comment)
+	s.publishEvent(CommentEventCreated, comment)
+	return comment, nil
+}
+
+// rendered - Comment hasil create/restore dengan content_html; gagal render hanya di-log
+// karena perubahan sudah di-commit
+func (s *CommentService) rendered(ctx context.Context, comment models.Comment) models.Comment {
+	comments := []models.Comment{comment}
+	if err := renderComments(ctx, s.store, comments); err != nil {
+		logging.FromContext(ctx).Error("failed to render comment", "comment_id", comment.ID, "error", err)
+	}
+	return comments[0]
+}
+
+// commentCursor - Cursor dari comment terakhir pada halaman
+func commentCursor(comment models.Comment) Cursor {
+	return Cursor{Value: comment.Score, ID: comment.ID}
+}
+
+// emitCommentEvent - Tulis event comment ke outbox webhook milik author post
+func emitCommentEvent(ctx context.Context, tx repository.Store, origin Origin, eventType string, post models.Post, comment models.Comment) error {
+	data := webhookCommentData{
+		ID:        comment.ID,
+		PostID:    post.ID,
+		UserID:    comment.UserID,
+		PostURL:   PostPermalink(origin.BaseURL, post),
+		CreatedAt: comment.CreatedAt,
+	}
+	if eventType != models.WebhookEventCommentDeleted {
+		data.Content = comment.Content
+	}
+	return tx.Outbox().EmitWebhook(ctx, post.UserID, eventType, data)
+}
+
+// publishEvent - Kirim event comment ke stream setelah transaksi di-commit, comment
+// sudah di-render. Gagal publish hanya di-log, comment sudah tersimpan dan tetap bisa diambil lewat List.
+func (s *CommentService) publishEvent(event string, comment models.Comment) {
+	if s.hub == nil {
+		return
+	}
+	var data interface{} = map[string]uint{"id": comment.ID, "post_id": comment.PostID}
+	if event == CommentEventCreated {
+		data = comment
+	}
+
+	if err := s.hub.Publish(context.Background(), CommentTopic(comment.PostID), event, data); err != nil {
+		slog.Warn("failed to publish realtime event", "event", event, "comment_id", comment.ID, "error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// feedBackfillLimit - Jumlah post lama author yang dimasukkan ke feed saat mulai follow
+const feedBackfillLimit = 50
+
+// FollowService - Follow/unfollow author, list followers/following dan isi feed fan-out
+type FollowService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewFollowService(cfg *config.Config, store repository.Store) *FollowService {
+	return &FollowService{cfg: cfg, store: store}
+}
+
+// Follow - Follow author (idempotent, dengan transaksi)
+func (s *FollowService) Follow(ctx context.Context, userID, followeeID uint) error {
+	if followeeID == userID {
+		return newError(CodeInvalid, "You cannot follow yourself")
+	}
+
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		if _, err := tx.Users().Find(ctx, followeeID); err != nil {
+			return newError(CodeNotFound, "User not found")
+		}
+
+		added, err := tx.Follows().Add(ctx, userID, followeeID)
+		if err != nil {
+			return err
+		}
+
+		// Isi feed dengan post terbaru author agar timeline tidak kosong
+		if added && s.cfg.FeedFanout {
+			return tx.Feed().Backfill(ctx, userID, followeeID, feedBackfillLimit)
+		}
+		return nil
+	})
+	if err != nil {
+		return orInternal(err, "Failed to follow user")
+	}
+	return nil
+}
+
+// Unfollow - Berhenti follow author (idempotent, dengan transaksi)
+func (s *FollowService) Unfollow(ctx context.Context, userID, followeeID uint) error {
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Follows().Remove(ctx, userID, followeeID); err != nil {
+			return err
+		}
+		// Entry fan-out selalu dibersihkan, walaupun FEED_FANOUT sedang non-aktif
+		return tx.Feed().RemoveAuthor(ctx, userID, followeeID)
+	})
+	if err != nil {
+		return newError(CodeInternal, "Failed to unfollow user")
+	}
+	return nil
+}
+
+// Followers - Satu halaman user yang mem-follow userID (terbaru dulu)
+func (s *FollowService) Followers(ctx context.Context, userID uint, opts PageOptions) ([]models.User, string, error) {
+	return s.list(ctx, userID, opts, s.store.Follows().Followers, func(f models.Follow) models.User {
+		return f.Follower
+	})
+}
+
+// Following - Satu halaman user yang di-follow oleh userID (terbaru dulu)
+func (s *FollowService) Following(ctx context.Context, userID uint, opts PageOptions) ([]models.User, string, error) {
+	return s.list(ctx, userID, opts, s.store.Follows().Following, func(f models.Follow) models.User {
+		return f.Followee
+	})
+}
+
+// list - Logic bersama untuk list followers/following
+func (s *FollowService) list(ctx context.Context, userID uint, opts PageOptions,
+	fetch func(ctx context.Context, userID uint, page repository.Page) ([]models.Follow, error),
+	user func(models.Follow) models.User) ([]models.User, string, error) {
+	page, err := opts.toPage("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := s.store.Users().Find(ctx, userID); err != nil {
+		return nil, "", newError(CodeNotFound, "User not found")
+	}
+
+	follows, err := fetch(ctx, userID, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch follows")
+	}
+	follows, nextCursor := trimPage(follows, page.Limit, func(f models.Follow) Cursor {
+		return Cursor{ID: f.ID}
+	})
+
+	users := make([]models.User, len(follows))
+	for i, follow := range follows {
+		users[i] = user(follow)
+	}
+	return users, nextCursor, nil
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"blog-api/internal/config"
+	"blog-api/internal/imaging"
+	"blog-api/internal/logging"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+	"blog-api/internal/storage"
+)
+
+// MediaUpload - Gambar yang sudah divalidasi transport (content type hasil sniffing,
+// metadata sudah dibuang, dimensi sesuai tampilan)
+type MediaUpload struct {
+	Filename    string
+	ContentType string
+	Extension   string
+	Data        []byte
+	Width       int
+	Height      int
+}
+
+// MediaFile - Info untuk menyajikan file asli atau variant lewat key-nya
+type MediaFile struct {
+	ContentType string
+	ETag        string
+}
+
+// VariantQueue - Antrian pembuatan variant ukuran gambar (imaging.Workers), nil jika tidak ada worker
+type VariantQueue interface {
+	Enqueue(mediaID uint)
+}
+
+// MediaService - Upload dengan kuota per user, list dan hapus media beserta file-nya di storage
+type MediaService struct {
+	cfg      *config.Config
+	store    repository.Store
+	files    storage.Storage
+	variants VariantQueue
+}
+
+func NewMediaService(cfg *config.Config, store repository.Store, files storage.Storage, variants VariantQueue) *MediaService {
+	return &MediaService{cfg: cfg, store: store, files: files, variants: variants}
+}
+
+// Upload - Simpan file ke storage dan catat media milik user; variant ukuran dibuat di background worker
+func (s *MediaService) Upload(ctx context.Context, userID uint, upload MediaUpload) (models.Media, error) {
+	token, err := randomToken()
+	if err != nil {
+		return models.Media{}, newError(CodeInternal, "Failed to upload media")
+	}
+	key := fmt.Sprintf("%d/%s%s", userID, token, upload.Extension)
+	size := int64(len(upload.Data))
+
+	if err := s.files.Put(ctx, key, bytes.NewReader(upload.Data), size, upload.ContentType); err != nil {
+		return models.Media{}, newError(CodeInternal, "Failed to store media")
+	}
+
+	media := models.Media{
+		UserID:        userID,
+		Key:           key,
+		Filename:      upload.Filename,
+		ContentType:   upload.ContentType,
+		Size:          size,
+		Width:         upload.Width,
+		Height:        upload.Height,
+		VariantStatus: models.MediaVariantPending,
+	}
+	// Kuota dihitung dan record dibuat dalam satu transaksi dengan baris user dikunci,
+	// agar upload bersamaan dari user yang sama tidak melewati kuota
+	err = s.store.Transaction(ctx, func(tx repository.Store) error {
+		used, err := tx.Media().UsedBytes(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if used+size > s.cfg.MediaQuotaBytes {
+			return newError(CodeForbidden, "Media quota exceeded")
+		}
+		return tx.Media().Create(ctx, &media)
+	})
+	if err != nil {
+		// Jangan tinggalkan file tanpa record
+		s.files.Delete(ctx, key)
+		return models.Media{}, orInternal(err, "Failed to save media")
+	}
+
+	if s.variants != nil {
+		s.variants.Enqueue(media.ID)
+	}
+	return media, nil
+}
+
+// Get - Metadata media beserta variant
+func (s *MediaService) Get(ctx context.Context, mediaID uint) (models.Media, error) {
+	media, err := s.store.Media().Get(ctx, mediaID)
+	if err != nil {
+		return media, newError(CodeNotFound, "Media not found")
+	}
+	return media, nil
+}
+
+// List - Satu halaman media milik user (terbaru dulu)
+func (s *MediaService) List(ctx context.Context, userID uint, opts PageOptions) ([]models.Media, string, error) {
+	page, err := opts.toPage("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	media, err := s.store.Media().ListByUser(ctx, userID, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch media")
+	}
+	media, nextCursor := trimPage(media, page.Limit, func(m models.Media) Cursor {
+		return Cursor{ID: m.ID}
+	})
+	return media, nextCursor, nil
+}
+
+// Delete - Hapus media milik sendiri (record dan file di storage)
+func (s *MediaService) Delete(ctx context.Context, userID, mediaID uint) error {
+	media, err := s.Get(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	// Cek ownership
+	if media.UserID != userID {
+		return newError(CodeForbidden, "You can only delete your own media")
+	}
+
+	err = s.store.Transaction(ctx, func(tx repository.Store) error {
+		return tx.Media().Delete(ctx, &media)
+	})
+	if err != nil {
+		return newError(CodeInternal, "Failed to delete media")
+	}
+
+	// File dihapus setelah commit: jika gagal hanya tersisa file yatim, bukan record tanpa file
+	keys := []string{media.Key}
+	for _, variant := range media.Variants {
+		keys = append(keys, variant.Key)
+	}
+	for _, key := range keys {
+		if err := s.files.Delete(ctx, key); err != nil {
+			logging.FromContext(ctx).Warn("failed to delete media file", "key", key, "error", err)
+		}
+	}
+	return nil
+}
+
+// File - Content type dan ETag untuk key file asli atau salah satu variant
+func (s *MediaService) File(ctx context.Context, key string) (MediaFile, error) {
+	if media, err := s.store.Media().FindByKey(ctx, key); err == nil {
+		return MediaFile{
+			ContentType: media.ContentType,
+			ETag:        fmt.Sprintf(`"%d-%d"`, media.ID, media.Size),
+		}, nil
+	}
+	if variant, err := s.store.Media().FindVariantByKey(ctx, key); err == nil {
+		return MediaFile{
+			ContentType: imaging.ContentType(variant.Format),
+			ETag:        fmt.Sprintf(`"%d-%d-%s"`, variant.MediaID, variant.Width, variant.Format),
+		}, nil
+	}
+	return MediaFile{}, newError(CodeNotFound, "Media not found")
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+
+	"blog-api/internal/mention"
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+	"blog-api/internal/repository"
+)
+
+// syncMentions - Parse @username di content, simpan ke tabel mentions dan
+// kirim notifikasi ke user yang baru di-mention (di dalam transaksi)
+func syncMentions(ctx context.Context, tx repository.Store, targetType string, targetID, postID, authorID uint, content string) error {
+	var users []models.User
+	if usernames := mention.Parse(content); len(usernames) > 0 {
+		found, err := tx.Users().FindByUsernames(ctx, usernames)
+		if err != nil {
+			return err
+		}
+		users = found
+	}
+
+	existing, err := tx.Mentions().ForTarget(ctx, targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[uint]bool, len(users))
+	for _, user := range users {
+		current[user.ID] = true
+	}
+
+	// Hapus mention yang sudah tidak ada di content (saat update)
+	previous := make(map[uint]bool, len(existing))
+	for _, m := range existing {
+		previous[m.UserID] = true
+		if !current[m.UserID] {
+			if err := tx.Mentions().Delete(ctx, &m); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, user := range users {
+		if previous[user.ID] {
+			continue
+		}
+
+		m := models.Mention{
+			UserID:     user.ID,
+			TargetType: targetType,
+			TargetID:   targetID,
+			PostID:     postID,
+			AuthorID:   authorID,
+		}
+		if err := tx.Mentions().Create(ctx, &m); err != nil {
+			return err
+		}
+
+		event := notifier.Event{
+			Type:        models.NotificationTypeMention,
+			RecipientID: user.ID,
+			ActorID:     authorID,
+			PostID:      postID,
+		}
+		if targetType == models.ReactionTargetComment {
+			event.CommentID = &targetID
+		}
+		if err := tx.Outbox().Notify(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// renderPosts - Isi content_html (dengan link mention) pada list post
+func renderPosts(ctx context.Context, store repository.Store, posts []models.Post) error {
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+
+	mentioned, err := store.Mentions().Usernames(ctx, models.ReactionTargetPost, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].ContentHTML = mention.Render(posts[i].Content, mentioned[posts[i].ID])
+	}
+	return nil
+}
+
+// renderComments - Isi content_html (dengan link mention) pada list comment
+func renderComments(ctx context.Context, store repository.Store, comments []models.Comment) error {
+	ids := make([]uint, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+	}
+
+	mentioned, err := store.Mentions().Usernames(ctx, models.ReactionTargetComment, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		comments[i].ContentHTML = mention.Render(comments[i].Content, mentioned[comments[i].ID])
+	}
+	return nil
+}
+
+// RenderPosts - Isi content_html pada list post dari Latest (syndication)
+func (s *PostService) RenderPosts(ctx context.Context, posts []models.Post) error {
+	return renderPosts(ctx, s.store, posts)
+}
+
+// RenderComments - Seperti RenderPosts untuk list comment
+func (s *CommentService) RenderComments(ctx context.Context, comments []models.Comment) error {
+	return renderComments(ctx, s.store, comments)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// NotificationPage - Satu halaman inbox beserta jumlah notifikasi yang belum dibaca
+type NotificationPage struct {
+	Notifications []models.Notification
+	NextCursor    string
+	UnreadCount   int64
+}
+
+// PreferenceInput - Perubahan preferensi notifikasi, field nil tidak diubah
+type PreferenceInput struct {
+	Comment  *bool
+	Reaction *bool
+	Mention  *bool
+}
+
+// NotificationService - Inbox notifikasi (ditulis lewat outbox service lain) dan preferensinya
+type NotificationService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewNotificationService(cfg *config.Config, store repository.Store) *NotificationService {
+	return &NotificationService{cfg: cfg, store: store}
+}
+
+// List - Inbox notifikasi user (aktivitas terbaru dulu), unreadOnly hanya yang belum dibaca
+func (s *NotificationService) List(ctx context.Context, userID uint, unreadOnly bool, opts PageOptions) (NotificationPage, error) {
+	var result NotificationPage
+	page, err := opts.toPage("")
+	if err != nil {
+		return result, err
+	}
+
+	notifications, err := s.store.Notifications().List(ctx, userID, unreadOnly, page)
+	if err != nil {
+		return result, newError(CodeInternal, "Failed to fetch notifications")
+	}
+	result.Notifications, result.NextCursor = trimPage(notifications, page.Limit, func(n models.Notification) Cursor {
+		return Cursor{Value: n.LatestAt.UnixMicro(), ID: n.ID}
+	})
+
+	result.UnreadCount, err = s.store.Notifications().UnreadCount(ctx, userID)
+	if err != nil {
+		return result, newError(CodeInternal, "Failed to count unread notifications")
+	}
+	return result, nil
+}
+
+// MarkRead - Tandai satu notifikasi sebagai sudah dibaca
+func (s *NotificationService) MarkRead(ctx context.Context, userID, notificationID uint) (models.Notification, error) {
+	notification, err := s.store.Notifications().Find(ctx, userID, notificationID)
+	if err != nil {
+		return notification, newError(CodeNotFound, "Notification not found")
+	}
+
+	if notification.ReadAt == nil {
+		if err := s.store.Notifications().MarkRead(ctx, &notification, time.Now()); err != nil {
+			return notification, newError(CodeInternal, "Failed to mark notification as read")
+		}
+	}
+	return notification, nil
+}
+
+// MarkAllRead - Tandai semua notifikasi user sebagai sudah dibaca, mengembalikan jumlah yang berubah
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uint) (int64, error) {
+	updated, err := s.store.Notifications().MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return 0, newError(CodeInternal, "Failed to mark notifications as read")
+	}
+	return updated, nil
+}
+
+// Preferences - Preferensi notifikasi user
+func (s *NotificationService) Preferences(ctx context.Context, userID uint) (models.NotificationPreference, error) {
+	pref, err := s.store.Notifications().Preference(ctx, userID)
+	if err != nil {
+		return pref, newError(CodeInternal, "Failed to fetch notification preferences")
+	}
+	return pref, nil
+}
+
+// UpdatePreferences - Update sebagian atau semua preferensi notifikasi
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uint, input PreferenceInput) (models.NotificationPreference, error) {
+	pref, err := s.Preferences(ctx, userID)
+	if err != nil {
+		return pref, err
+	}
+
+	if input.Comment != nil {
+		pref.Comment = *input.Comment
+	}
+	if input.Reaction != nil {
+		pref.Reaction = *input.Reaction
+	}
+	if input.Mention != nil {
+		pref.Mention = *input.Mention
+	}
+
+	if err := s.store.Notifications().SavePreference(ctx, &pref); err != nil {
+		return pref, newError(CodeInternal, "Failed to update notification preferences")
+	}
+	return pref, nil
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+
+	"blog-api/internal/repository"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// PageOptions - Argumen cursor pagination yang sama untuk semua transport
+type PageOptions struct {
+	Limit  int    // 0 berarti DefaultPageLimit, dibatasi MaxPageLimit
+	Cursor string // Cursor opaque dari halaman sebelumnya
+	Sort   string // Kosong berarti urutan default list
+}
+
+// Cursor - Posisi terakhir pada list (nilai sort + ID sebagai tie-breaker)
+type Cursor struct {
+	Value int64
+	ID    uint
+}
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageLimit - Limit dengan default dan batas maksimum
+func (o PageOptions) pageLimit() int {
+	if o.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if o.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return o.Limit
+}
+
+// toPage - Argumen repository untuk satu halaman, sort sudah divalidasi service
+func (o PageOptions) toPage(sort string) (repository.Page, error) {
+	cursor, err := DecodeCursor(o.Cursor)
+	if err != nil {
+		return repository.Page{}, newError(CodeInvalid, "Invalid cursor")
+	}
+
+	page := repository.Page{Sort: sort, Limit: o.pageLimit()}
+	if cursor != nil {
+		page.After = &repository.Cursor{Value: cursor.Value, ID: cursor.ID}
+	}
+	return page, nil
+}
+
+// trimPage - Potong hasil limit+1 dari repository menjadi satu halaman dan cursor berikutnya
+// (kosong jika tidak ada halaman lagi)
+func trimPage[T any](items []T, limit int, cursor func(T) Cursor) ([]T, string) {
+	if len(items) <= limit {
+		return items, ""
+	}
+	items = items[:limit]
+	return items, EncodeCursor(cursor(items[len(items)-1]))
+}
+
+// EncodeCursor - Encode cursor menjadi string opaque untuk client
+func EncodeCursor(c Cursor) string {
+	raw := strconv.FormatInt(c.Value, 10) + ":" + strconv.FormatUint(uint64(c.ID), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor - Decode cursor dari client, nil jika kosong
+func DecodeCursor(raw string) (*Cursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return nil, ErrInvalidCursor
+	}
+
+	value, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &Cursor{Value: value, ID: uint(id)}, nil
+}
@@ -0,0 +1,506 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/logging"
+	"blog-api/internal/metrics"
+	"blog-api/internal/models"
+	"blog-api/internal/presence"
+	"blog-api/internal/repository"
+)
+
+// PostInput - Data post dari client
+type PostInput struct {
+	Title   string
+	Content string
+	Tags    []string // Opsional, saat update nil berarti tag tidak diubah
+	// FeaturedImageID - Opsional, ID media milik sendiri; saat update nil berarti tidak diubah, 0 menghapus
+	FeaturedImageID *uint
+}
+
+// webhookPostData - Payload event post.*
+type webhookPostData struct {
+	ID              uint      `json:"id"`
+	UserID          uint      `json:"user_id"`
+	Title           string    `json:"title,omitempty"`
+	Content         string    `json:"content,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
+	FeaturedImageID *uint     `json:"featured_image_id,omitempty"`
+	URL             string    `json:"url"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// validatePostInput - Validasi input post dan normalize tag
+func validatePostInput(input *PostInput) error {
+	valid, errMsg := ValidateRequired(map[string]string{
+		"title":   input.Title,
+		"content": input.Content,
+	})
+	if !valid {
+		return newError(CodeInvalid, errMsg)
+	}
+
+	if !ValidateStringLength(input.Title, 3, 200) {
+		return newError(CodeInvalid, "Title must be between 3 and 200 characters")
+	}
+
+	if !ValidateStringLength(input.Content, 10, 10000) {
+		return newError(CodeInvalid, "Content must be between 10 and 10000 characters")
+	}
+
+	input.Tags = NormalizeTags(input.Tags)
+	if valid, errMsg := ValidateTags(input.Tags); !valid {
+		return newError(CodeInvalid, errMsg)
+	}
+	return nil
+}
+
+// PostPage - Satu halaman post dengan cursor halaman berikutnya (kosong jika habis)
+type PostPage struct {
+	Posts      []models.Post
+	NextCursor string
+}
+
+// PostService - Aturan bisnis post: validasi, ownership, tag, mention, feed dan event webhook
+type PostService struct {
+	cfg      *config.Config
+	store    repository.Store
+	hub      Publisher
+	webhooks Waker
+}
+
+func NewPostService(cfg *config.Config, store repository.Store, hub Publisher, webhooks Waker) *PostService {
+	return &PostService{cfg: cfg, store: store, hub: hub, webhooks: webhooks}
+}
+
+// postSort - Sort list post yang valid, kosong berarti newest
+func postSort(sort string) (string, error) {
+	switch sort {
+	case "":
+		return "newest", nil
+	case "newest", "top":
+		return sort, nil
+	default:
+		return "", newError(CodeInvalid, "Invalid sort, must be one of: newest, top")
+	}
+}
+
+// Create - Validasi dan simpan post baru (dengan transaksi)
+func (s *PostService) Create(ctx context.Context, origin Origin, userID uint, input PostInput) (models.Post, error) {
+	if err := validatePostInput(&input); err != nil {
+		return models.Post{}, err
+	}
+
+	post := models.Post{
+		Title:   input.Title,
+		Content: input.Content,
+		UserID:  userID,
+	}
+
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		if input.FeaturedImageID != nil && *input.FeaturedImageID != 0 {
+			if err := checkFeaturedImage(ctx, tx, *input.FeaturedImageID, userID); err != nil {
+				return err
+			}
+			post.FeaturedImageID = input.FeaturedImageID
+		}
+
+		if err := tx.Posts().Create(ctx, &post); err != nil {
+			return err
+		}
+
+		if err := tx.Posts().ReplaceTags(ctx, &post, input.Tags); err != nil {
+			return err
+		}
+
+		if err := s.fanOutPost(ctx, tx, post); err != nil {
+			return err
+		}
+
+		if err := syncMentions(ctx, tx, models.ReactionTargetPost, post.ID, post.ID, userID, post.Content); err != nil {
+			return err
+		}
+
+		// Preload user data
+		loaded, err := tx.Posts().Get(ctx, post.ID)
+		if err != nil {
+			return newError(CodeInternal, "Failed to load post data")
+		}
+		post = loaded
+
+		return emitPostEvent(ctx, tx, origin, models.WebhookEventPostCreated, post)
+	})
+	if err != nil {
+		return post, orInternal(err, "Failed to create post")
+	}
+
+	metrics.PostsCreated.Inc()
+	wake(s.webhooks)
+	return s.rendered(ctx, post), nil
+}
+
+// Update - Validasi dan update post milik user (dengan transaksi)
+func (s *PostService) Update(ctx context.Context, origin Origin, userID, postID uint, input PostInput) (models.Post, error) {
+	if err := validatePostInput(&input); err != nil {
+		return models.Post{}, err
+	}
+
+	var post models.Post
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		if post, err = tx.Posts().Find(ctx, postID); err != nil {
+			return newError(CodeNotFound, "Post not found")
+		}
+
+		// Cek ownership
+		if post.UserID != userID {
+			return newError(CodeForbidden, "You can only update your own posts")
+		}
+
+		// Update post
+		post.Title = input.Title
+		post.Content = input.Content
+
+		if input.FeaturedImageID != nil {
+			post.FeaturedImageID = nil
+			if *input.FeaturedImageID != 0 {
+				if err := checkFeaturedImage(ctx, tx, *input.FeaturedImageID, userID); err != nil {
+					return err
+				}
+				post.FeaturedImageID = input.FeaturedImageID
+			}
+		}
+
+		if err := tx.Posts().Update(ctx, &post); err != nil {
+			return err
+		}
+
+		if input.Tags != nil {
+			if err := tx.Posts().ReplaceTags(ctx, &post, input.Tags); err != nil {
+				return err
+			}
+		}
+
+		if err := syncMentions(ctx, tx, models.ReactionTargetPost, post.ID, post.ID, userID, post.Content); err != nil {
+			return err
+		}
+
+		// Preload user data
+		loaded, err := tx.Posts().Get(ctx, post.ID)
+		if err != nil {
+			return newError(CodeInternal, "Failed to load post data")
+		}
+		post = loaded
+
+		return emitPostEvent(ctx, tx, origin, models.WebhookEventPostUpdated, post)
+	})
+	if err != nil {
+		return post, orInternal(err, "Failed to update post")
+	}
+
+	wake(s.webhooks)
+	s.publishPostSaved(origin, userID, post)
+	return s.rendered(ctx, post), nil
+}
+
+// Delete - Soft delete post milik user (dengan transaksi)
+func (s *PostService) Delete(ctx context.Context, origin Origin, userID, postID uint) error {
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		post, err := tx.Posts().Find(ctx, postID)
+		if err != nil {
+			return newError(CodeNotFound, "Post not found")
+		}
+
+		// Cek ownership
+		if post.UserID != userID {
+			return newError(CodeForbidden, "You can only delete your own posts")
+		}
+
+		// Soft delete post (dan comments akan ikut ter-cascade karena foreign key)
+		if err := tx.Posts().Delete(ctx, &post); err != nil {
+			return err
+		}
+
+		return emitPostEvent(ctx, tx, origin, models.WebhookEventPostDeleted, post)
+	})
+	if err != nil {
+		return orInternal(err, "Failed to delete post")
+	}
+
+	wake(s.webhooks)
+	return nil
+}
+
+// Get - Ambil single post beserta author, tag dan featured image
+func (s *PostService) Get(ctx context.Context, postID uint) (models.Post, error) {
+	post, err := s.store.Posts().Get(ctx, postID)
+	if err != nil {
+		return post, newError(CodeNotFound, "Post not found")
+	}
+
+	posts := []models.Post{post}
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return post, newError(CodeInternal, "Failed to render post")
+	}
+	return posts[0], nil
+}
+
+// All - Semua post beserta author, tag dan featured image tanpa paginasi, sort kosong
+// (urutan insert) atau top. Comment diambil terpisah (CommentService.Previews).
+func (s *PostService) All(ctx context.Context, sort string) ([]models.Post, error) {
+	if sort != "" && sort != "top" {
+		return nil, newError(CodeInvalid, "Invalid sort, must be: top")
+	}
+
+	posts, err := s.store.Posts().All(ctx, sort)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch posts")
+	}
+
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return nil, newError(CodeInternal, "Failed to render posts")
+	}
+	return posts, nil
+}
+
+// List - List post dengan cursor pagination, sort newest (default) atau top (jumlah reaction).
+// Author dan tag ikut dimuat.
+func (s *PostService) List(ctx context.Context, opts PageOptions) ([]models.Post, string, error) {
+	sort, err := postSort(opts.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+	page, err := opts.toPage(sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	posts, err := s.store.Posts().List(ctx, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch posts")
+	}
+	posts, nextCursor := trimPage(posts, page.Limit, postCursor)
+
+	if err := s.loadAuthorsAndTags(ctx, posts); err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch posts")
+	}
+
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return nil, "", newError(CodeInternal, "Failed to render posts")
+	}
+	return posts, nextCursor, nil
+}
+
+// Feed - Timeline post dari author yang di-follow user (terbaru dulu) beserta author dan
+// featured image, dari tabel fan-out jika FeedFanout aktif
+func (s *PostService) Feed(ctx context.Context, userID uint, opts PageOptions) ([]models.Post, string, error) {
+	page, err := opts.toPage("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	posts, err := s.store.Feed().List(ctx, userID, s.cfg.FeedFanout, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch feed")
+	}
+	posts, nextCursor := trimPage(posts, page.Limit, func(post models.Post) Cursor {
+		return Cursor{ID: post.ID}
+	})
+
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return nil, "", newError(CodeInternal, "Failed to render posts")
+	}
+	return posts, nextCursor, nil
+}
+
+// ListByUsers - Satu halaman post (tanpa relasi) untuk tiap author dengan satu query,
+// dipakai dataloader GraphQL
+func (s *PostService) ListByUsers(ctx context.Context, userIDs []uint, opts PageOptions) (map[uint]PostPage, error) {
+	sort, err := postSort(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+	page, err := opts.toPage(sort)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := s.store.Posts().ListByUsers(ctx, userIDs, page)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch posts")
+	}
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return nil, newError(CodeInternal, "Failed to render posts")
+	}
+
+	byUser := make(map[uint][]models.Post)
+	for _, post := range posts {
+		byUser[post.UserID] = append(byUser[post.UserID], post)
+	}
+
+	result := make(map[uint]PostPage, len(userIDs))
+	for _, userID := range userIDs {
+		var userPage PostPage
+		userPage.Posts, userPage.NextCursor = trimPage(byUser[userID], page.Limit, postCursor)
+		result[userID] = userPage
+	}
+	return result, nil
+}
+
+// FindByIDs - Banyak post sekaligus (tanpa relasi), urutan tidak dijamin
+func (s *PostService) FindByIDs(ctx context.Context, ids []uint) ([]models.Post, error) {
+	posts, err := s.store.Posts().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch posts")
+	}
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		return nil, newError(CodeInternal, "Failed to render posts")
+	}
+	return posts, nil
+}
+
+// Tags - Tag per post (urut nama) untuk banyak post sekaligus
+func (s *PostService) Tags(ctx context.Context, postIDs []uint) (map[uint][]models.Tag, error) {
+	tags, err := s.store.Posts().Tags(ctx, postIDs)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch tags")
+	}
+	return tags, nil
+}
+
+// FindTag - Tag dengan nama (case-insensitive)
+func (s *PostService) FindTag(ctx context.Context, name string) (models.Tag, error) {
+	tag, err := s.store.Posts().FindTag(ctx, strings.ToLower(name))
+	if err != nil {
+		return tag, newError(CodeNotFound, "Tag not found")
+	}
+	return tag, nil
+}
+
+// Latest - Sampai limit post terbaru beserta author dan tag, hanya milik userID dan/atau dengan
+// tagID jika bukan 0. content_html belum diisi (RenderPosts) agar conditional GET tetap murah.
+func (s *PostService) Latest(ctx context.Context, userID, tagID uint, limit int) ([]models.Post, error) {
+	posts, err := s.store.Posts().Latest(ctx, userID, tagID, limit)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch posts")
+	}
+	return posts, nil
+}
+
+// loadAuthorsAndTags - Isi author dan tag pada list post dengan dua query batch
+func (s *PostService) loadAuthorsAndTags(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]uint, len(posts))
+	userIDs := make([]uint, len(posts))
+	for i, post := range posts {
+		postIDs[i] = post.ID
+		userIDs[i] = post.UserID
+	}
+
+	users, err := s.store.Users().FindByIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	authors := make(map[uint]models.User, len(users))
+	for _, user := range users {
+		authors[user.ID] = user
+	}
+
+	tags, err := s.store.Posts().Tags(ctx, postIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range posts {
+		posts[i].User = authors[posts[i].UserID]
+		posts[i].Tags = tags[posts[i].ID]
+	}
+	return nil
+}
+
+// rendered - Post hasil create/update dengan content_html; gagal render hanya di-log
+// karena perubahan sudah di-commit
+func (s *PostService) rendered(ctx context.Context, post models.Post) models.Post {
+	posts := []models.Post{post}
+	if err := renderPosts(ctx, s.store, posts); err != nil {
+		logging.FromContext(ctx).Error("failed to render post", "post_id", post.ID, "error", err)
+	}
+	return posts[0]
+}
+
+// postCursor - Cursor dari post terakhir pada halaman
+func postCursor(post models.Post) Cursor {
+	return Cursor{Value: post.Score, ID: post.ID}
+}
+
+// PostPermalink - URL halaman publik sebuah post
+func PostPermalink(base string, post models.Post) string {
+	return fmt.Sprintf("%s/posts/%d", base, post.ID)
+}
+
+// checkFeaturedImage - Media untuk featured image harus ada dan milik author post
+func checkFeaturedImage(ctx context.Context, tx repository.Store, mediaID, userID uint) error {
+	ownerID, err := tx.Posts().MediaOwner(ctx, mediaID)
+	if err != nil {
+		return newError(CodeInvalid, "Featured image not found")
+	}
+	if ownerID != userID {
+		return newError(CodeForbidden, "You can only use your own media as featured image")
+	}
+	return nil
+}
+
+// fanOutPost - Masukkan post baru ke feed semua follower author (di dalam transaksi)
+func (s *PostService) fanOutPost(ctx context.Context, tx repository.Store, post models.Post) error {
+	if !s.cfg.FeedFanout {
+		return nil
+	}
+	return tx.Posts().FanOut(ctx, post)
+}
+
+// emitPostEvent - Tulis event post ke outbox webhook milik author (di dalam transaksi)
+func emitPostEvent(ctx context.Context, tx repository.Store, origin Origin, eventType string, post models.Post) error {
+	data := webhookPostData{
+		ID:        post.ID,
+		UserID:    post.UserID,
+		URL:       PostPermalink(origin.BaseURL, post),
+		CreatedAt: post.CreatedAt,
+		UpdatedAt: post.UpdatedAt,
+	}
+	if eventType != models.WebhookEventPostDeleted {
+		data.Title = post.Title
+		data.Content = post.Content
+		data.FeaturedImageID = post.FeaturedImageID
+		data.Tags = []string{}
+		for _, tag := range post.Tags {
+			data.Tags = append(data.Tags, tag.Name)
+		}
+	}
+	return tx.Outbox().EmitWebhook(ctx, post.UserID, eventType, data)
+}
+
+// publishPostSaved - Kabari koneksi presence editor bahwa versi baru tersimpan (setelah commit)
+func (s *PostService) publishPostSaved(origin Origin, userID uint, post models.Post) {
+	if s.hub == nil {
+		return
+	}
+	saved := presence.PostSaved{
+		PostID:    post.ID,
+		UserID:    userID,
+		SessionID: origin.EditorSession,
+		UpdatedAt: post.UpdatedAt,
+	}
+	if err := s.hub.Publish(context.Background(), presence.Topic(post.ID), presence.EventSaved, saved); err != nil {
+		slog.Warn("failed to publish realtime event", "event", presence.EventSaved, "post_id", post.ID, "error", err)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// newTestUser - User langsung di store, tanpa bcrypt
+func newTestUser(t *testing.T, store repository.Store, username string) models.User {
+	user := models.User{Email: username + "@example.com", Password: "x", Name: username, Username: username}
+	if err := store.Users().Create(context.Background(), &user); err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return user
+}
+
+func TestPostServiceCreateAndList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	posts := NewPostService(&config.Config{}, store, nil, nil)
+
+	author := newTestUser(t, store, "author")
+	jane := newTestUser(t, store, "jane")
+
+	post, err := posts.Create(ctx, Origin{BaseURL: "https://blog.test"}, author.ID, PostInput{
+		Title:   "First post",
+		Content: "Thanks @jane for the review",
+		Tags:    []string{"Go", "go"},
+	})
+	if err != nil {
+		t.Fatalf("Expected post to be created, got %v", err)
+	}
+	if post.User.Username != "author" || len(post.Tags) != 1 || post.Tags[0].Name != "go" || post.ContentHTML == "" {
+		t.Fatalf("Expected author, normalized tag and rendered content, got %+v", post)
+	}
+
+	notifications := store.NotificationEvents()
+	if len(notifications) != 1 || notifications[0].RecipientID != jane.ID || notifications[0].Type != models.NotificationTypeMention {
+		t.Errorf("Expected mention notification for jane, got %+v", notifications)
+	}
+	webhooks := store.EmittedWebhooks()
+	if len(webhooks) != 1 || webhooks[0].EventType != models.WebhookEventPostCreated {
+		t.Errorf("Expected post.created webhook, got %+v", webhooks)
+	}
+
+	for _, title := range []string{"Second post", "Third post"} {
+		if _, err := posts.Create(ctx, Origin{}, author.ID, PostInput{Title: title, Content: "Some more content"}); err != nil {
+			t.Fatalf("Failed to create post: %v", err)
+		}
+	}
+
+	page, next, err := posts.List(ctx, PageOptions{Limit: 2})
+	if err != nil || len(page) != 2 || next == "" || page[0].Title != "Third post" || page[0].User.ID != author.ID {
+		t.Fatalf("Expected newest two posts with author and cursor, got %+v %q %v", page, next, err)
+	}
+	page, next, err = posts.List(ctx, PageOptions{Limit: 2, Cursor: next})
+	if err != nil || len(page) != 1 || next != "" || page[0].ID != post.ID || len(page[0].Tags) != 1 {
+		t.Fatalf("Expected last post with tags on second page, got %+v %q %v", page, next, err)
+	}
+
+	if _, _, err := posts.List(ctx, PageOptions{Sort: "random"}); ErrorCode(err) != CodeInvalid {
+		t.Errorf("Expected invalid sort, got %v", err)
+	}
+}
+
+func TestPostServiceOwnershipAndRollback(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	posts := NewPostService(&config.Config{}, store, nil, nil)
+
+	owner := newTestUser(t, store, "owner")
+	other := newTestUser(t, store, "other")
+	foreignMedia := store.AddMedia(other.ID)
+
+	// Featured image milik user lain ditolak, transaksi tidak meninggalkan post
+	_, err := posts.Create(ctx, Origin{}, owner.ID, PostInput{Title: "Foreign", Content: "Using someone else's image", FeaturedImageID: &foreignMedia})
+	if ErrorCode(err) != CodeForbidden {
+		t.Fatalf("Expected forbidden featured image, got %v", err)
+	}
+	if list, _, _ := posts.List(ctx, PageOptions{}); len(list) != 0 {
+		t.Fatalf("Expected rollback, got %d posts", len(list))
+	}
+
+	post, err := posts.Create(ctx, Origin{}, owner.ID, PostInput{Title: "Owned", Content: "Content of the owner"})
+	if err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	if _, err := posts.Update(ctx, Origin{}, other.ID, post.ID, PostInput{Title: "Hijacked", Content: "Content of someone else"}); ErrorCode(err) != CodeForbidden {
+		t.Errorf("Expected forbidden update, got %v", err)
+	}
+	if err := posts.Delete(ctx, Origin{}, other.ID, post.ID); ErrorCode(err) != CodeForbidden {
+		t.Errorf("Expected forbidden delete, got %v", err)
+	}
+	if err := posts.Delete(ctx, Origin{}, owner.ID, post.ID); err != nil {
+		t.Fatalf("Expected owner to delete post, got %v", err)
+	}
+	if _, err := posts.Get(ctx, post.ID); ErrorCode(err) != CodeNotFound {
+		t.Errorf("Expected deleted post to be hidden, got %v", err)
+	}
+}
+
+func TestCommentServiceCountAndRestore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	posts := NewPostService(&config.Config{}, store, nil, nil)
+	comments := NewCommentService(&config.Config{}, store, nil, nil, nil)
+
+	author := newTestUser(t, store, "author")
+	reader := newTestUser(t, store, "reader")
+	post, err := posts.Create(ctx, Origin{}, author.ID, PostInput{Title: "Discussed", Content: "Post with comments"})
+	if err != nil {
+		t.Fatalf("Failed to create post: %v", err)
+	}
+
+	comment, _, err := comments.Create(ctx, Origin{}, reader.ID, post.ID, "Nice post")
+	if err != nil || comment.User.ID != reader.ID {
+		t.Fatalf("Expected comment with author, got %+v %v", comment, err)
+	}
+	if stored, _ := store.Posts().Find(ctx, post.ID); stored.CommentCount != 1 {
+		t.Errorf("Expected comment_count 1, got %d", stored.CommentCount)
+	}
+	if notifications := store.NotificationEvents(); len(notifications) != 1 || notifications[0].RecipientID != author.ID {
+		t.Errorf("Expected comment notification for post author, got %+v", notifications)
+	}
+
+	if err := comments.Delete(ctx, Origin{}, author.ID, post.ID, comment.ID); ErrorCode(err) != CodeForbidden {
+		t.Errorf("Expected forbidden delete, got %v", err)
+	}
+	if err := comments.Delete(ctx, Origin{}, reader.ID, post.ID, comment.ID); err != nil {
+		t.Fatalf("Failed to delete comment: %v", err)
+	}
+	if list, _, _ := comments.List(ctx, post.ID, PageOptions{}); len(list) != 0 {
+		t.Errorf("Expected deleted comment to be hidden, got %d", len(list))
+	}
+
+	if _, err := comments.Restore(ctx, reader.ID, post.ID, comment.ID); err != nil {
+		t.Fatalf("Failed to restore comment: %v", err)
+	}
+	if stored, _ := store.Posts().Find(ctx, post.ID); stored.CommentCount != 1 {
+		t.Errorf("Expected comment_count 1 after restore, got %d", stored.CommentCount)
+	}
+	if _, err := comments.Restore(ctx, reader.ID, post.ID, comment.ID); ErrorCode(err) != CodeNotFound {
+		t.Errorf("Expected restored comment to be gone from trash, got %v", err)
+	}
+}
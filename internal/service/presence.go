@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/presence"
+	"blog-api/internal/repository"
+)
+
+// PresenceService - Presence editor per post: session, advisory edit lock dan snapshot
+type PresenceService struct {
+	cfg   *config.Config
+	store repository.Store
+	hub   Publisher
+}
+
+func NewPresenceService(cfg *config.Config, store repository.Store, hub Publisher) *PresenceService {
+	return &PresenceService{cfg: cfg, store: store, hub: hub}
+}
+
+// PresenceSession - Satu koneksi editor pada post, dibuat Open dan dicatat Join
+type PresenceSession struct {
+	ID      string // Kosong sampai Join
+	PostID  uint
+	UserID  uint
+	CanEdit bool // Hanya author yang boleh mengedit dan memegang lock
+
+	service *PresenceService
+}
+
+// Open - Siapkan session user untuk post, CodeNotFound jika post tidak ada
+func (s *PresenceService) Open(ctx context.Context, userID, postID uint) (*PresenceSession, error) {
+	post, err := s.store.Posts().Find(ctx, postID)
+	if err != nil {
+		return nil, newError(CodeNotFound, "Post not found")
+	}
+	return &PresenceSession{PostID: post.ID, UserID: userID, CanEdit: post.UserID == userID, service: s}, nil
+}
+
+// Join - Catat session (state viewing) dan kabari editor lain
+func (p *PresenceSession) Join(ctx context.Context) error {
+	repo := p.service.store.Presence()
+	// Session dan lock yang ditinggal server mati tanpa Leave ikut dibersihkan
+	repo.Cleanup(ctx)
+
+	id, err := repo.Join(ctx, p.PostID, p.UserID)
+	if err != nil {
+		return newError(CodeInternal, "Failed to join presence")
+	}
+	p.ID = id
+	p.notify()
+	return nil
+}
+
+// Leave - Hapus session beserta lock yang dipegangnya dan kabari editor lain
+func (p *PresenceSession) Leave(ctx context.Context) error {
+	err := p.service.store.Presence().Leave(ctx, p.ID)
+	p.notify()
+	return err
+}
+
+// Heartbeat - Perpanjang session dan lock yang dipegang
+func (p *PresenceSession) Heartbeat(ctx context.Context) error {
+	return p.service.store.Presence().Touch(ctx, p.ID)
+}
+
+// SetState - Ubah state session (viewing / editing), editing hanya untuk author
+func (p *PresenceSession) SetState(ctx context.Context, state string) error {
+	if state != models.EditorStateViewing && state != models.EditorStateEditing {
+		return newError(CodeInvalid, "Invalid state, must be one of: viewing, editing")
+	}
+	if state == models.EditorStateEditing && !p.CanEdit {
+		return newError(CodeForbidden, "You can only edit your own posts")
+	}
+	if err := p.service.store.Presence().SetState(ctx, p.ID, state); err != nil {
+		return err
+	}
+	p.notify()
+	return nil
+}
+
+// AcquireLock - Ambil lock edit post. Jika lock dipegang session lain, ok false dan
+// lock pemegang saat ini dikembalikan.
+func (p *PresenceSession) AcquireLock(ctx context.Context) (models.PostEditLock, bool, error) {
+	if !p.CanEdit {
+		return models.PostEditLock{}, false, newError(CodeForbidden, "You can only edit your own posts")
+	}
+	lock, ok, err := p.service.store.Presence().AcquireLock(ctx, p.PostID, p.UserID, p.ID)
+	if err != nil || !ok {
+		return lock, ok, err
+	}
+	p.notify()
+	return lock, true, nil
+}
+
+// ReleaseLock - Lepas lock jika dipegang session ini
+func (p *PresenceSession) ReleaseLock(ctx context.Context) error {
+	released, err := p.service.store.Presence().ReleaseLock(ctx, p.PostID, p.ID)
+	if err != nil {
+		return err
+	}
+	if released {
+		p.notify()
+	}
+	return nil
+}
+
+// Snapshot - Session yang masih aktif dan lock yang belum expired pada post
+func (p *PresenceSession) Snapshot(ctx context.Context) (presence.Snapshot, error) {
+	return p.service.store.Presence().Load(ctx, p.PostID)
+}
+
+// notify - Kabari semua koneksi presence post (di semua replica) lewat realtime hub
+func (p *PresenceSession) notify() {
+	if p.service.hub == nil {
+		return
+	}
+	data := map[string]string{"session_id": p.ID}
+	if err := p.service.hub.Publish(context.Background(), presence.Topic(p.PostID), presence.EventChanged, data); err != nil {
+		slog.Warn("failed to publish realtime event", "event", presence.EventChanged, "post_id", p.PostID, "error", err)
+	}
+}
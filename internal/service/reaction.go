@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/notifier"
+	"blog-api/internal/repository"
+)
+
+// ReactionSummary - Jumlah reaction per kind dan reaction milik viewer pada satu post atau comment
+type ReactionSummary struct {
+	Reactions       map[string]int64 `json:"reactions"`
+	ViewerReactions []string         `json:"viewer_reactions"`
+}
+
+// reactionTarget - Post atau comment yang diberi reaction
+type reactionTarget struct {
+	Type    string
+	ID      uint
+	PostID  uint
+	OwnerID uint
+}
+
+// ReactionService - Reaction pada post dan comment: validasi kind, score untuk sort top,
+// notifikasi ke pemilik target, serta jumlah reaction dan viewer_reactions untuk semua transport
+type ReactionService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewReactionService(cfg *config.Config, store repository.Store) *ReactionService {
+	return &ReactionService{cfg: cfg, store: store}
+}
+
+// SetPostReaction - Tambah (add true) atau hapus reaction user pada post, idempotent
+func (s *ReactionService) SetPostReaction(ctx context.Context, userID, postID uint, kind string, add bool) (ReactionSummary, error) {
+	return s.set(ctx, userID, kind, add, func(tx repository.Store) (reactionTarget, error) {
+		post, err := tx.Posts().Find(ctx, postID)
+		if err != nil {
+			return reactionTarget{}, newError(CodeNotFound, "Post not found")
+		}
+		return reactionTarget{Type: models.ReactionTargetPost, ID: post.ID, PostID: post.ID, OwnerID: post.UserID}, nil
+	})
+}
+
+// SetCommentReaction - Tambah (add true) atau hapus reaction user pada comment, idempotent
+func (s *ReactionService) SetCommentReaction(ctx context.Context, userID, postID, commentID uint, kind string, add bool) (ReactionSummary, error) {
+	return s.set(ctx, userID, kind, add, func(tx repository.Store) (reactionTarget, error) {
+		comment, err := tx.Comments().Find(ctx, postID, commentID)
+		if err != nil {
+			return reactionTarget{}, newError(CodeNotFound, "Comment not found")
+		}
+		return reactionTarget{Type: models.ReactionTargetComment, ID: comment.ID, PostID: comment.PostID, OwnerID: comment.UserID}, nil
+	})
+}
+
+// set - Logic bersama tambah/hapus reaction (dengan transaksi). Score dan notifikasi hanya
+// berubah jika reaction benar-benar berubah.
+func (s *ReactionService) set(ctx context.Context, userID uint, kind string, add bool, find func(tx repository.Store) (reactionTarget, error)) (ReactionSummary, error) {
+	if !s.validKind(kind) {
+		return ReactionSummary{}, newError(CodeInvalid, "Invalid reaction kind")
+	}
+
+	var summary ReactionSummary
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		target, err := find(tx)
+		if err != nil {
+			return err
+		}
+
+		reaction := models.Reaction{
+			UserID:     userID,
+			TargetType: target.Type,
+			TargetID:   target.ID,
+			Kind:       kind,
+		}
+
+		var changed bool
+		if add {
+			changed, err = tx.Reactions().Add(ctx, &reaction)
+		} else {
+			changed, err = tx.Reactions().Remove(ctx, reaction)
+		}
+		if err != nil {
+			return err
+		}
+
+		if changed {
+			delta := 1
+			if !add {
+				delta = -1
+			}
+			if err := tx.Reactions().AdjustScore(ctx, target.Type, target.ID, delta); err != nil {
+				return err
+			}
+		}
+
+		// Notifikasi ke pemilik post/comment untuk reaction baru
+		if add && changed {
+			event := notifier.Event{
+				Type:        models.NotificationTypeReaction,
+				RecipientID: target.OwnerID,
+				ActorID:     userID,
+				PostID:      target.PostID,
+			}
+			if target.Type == models.ReactionTargetComment {
+				event.CommentID = &target.ID
+			}
+			if err := tx.Outbox().Notify(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		summaries, err := loadReactionSummaries(ctx, tx, target.Type, []uint{target.ID}, userID)
+		if err != nil {
+			return newError(CodeInternal, "Failed to load reactions")
+		}
+		summary = summaries[target.ID]
+		return nil
+	})
+	if err != nil {
+		return summary, orInternal(err, "Failed to update reaction")
+	}
+	return summary, nil
+}
+
+// validKind - Kind reaction harus ada di daftar config
+func (s *ReactionService) validKind(kind string) bool {
+	for _, allowed := range s.cfg.ReactionKinds {
+		if kind == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Summaries - ReactionSummary per target untuk banyak target sekaligus; viewerID 0 (anonymous)
+// berarti viewer_reactions selalu kosong
+func (s *ReactionService) Summaries(ctx context.Context, targetType string, targetIDs []uint, viewerID uint) (map[uint]ReactionSummary, error) {
+	summaries, err := loadReactionSummaries(ctx, s.store, targetType, targetIDs, viewerID)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch reactions")
+	}
+	return summaries, nil
+}
+
+// AttachToPosts - Isi Reactions dan ViewerReactions pada list post
+func (s *ReactionService) AttachToPosts(ctx context.Context, viewerID uint, posts []models.Post) error {
+	ids := make([]uint, len(posts))
+	for i := range posts {
+		ids[i] = posts[i].ID
+	}
+
+	summaries, err := s.Summaries(ctx, models.ReactionTargetPost, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for i := range posts {
+		posts[i].Reactions = summaries[posts[i].ID].Reactions
+		posts[i].ViewerReactions = summaries[posts[i].ID].ViewerReactions
+	}
+	return nil
+}
+
+// AttachToComments - Isi Reactions dan ViewerReactions pada list comment
+func (s *ReactionService) AttachToComments(ctx context.Context, viewerID uint, comments []models.Comment) error {
+	ids := make([]uint, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].ID
+	}
+
+	summaries, err := s.Summaries(ctx, models.ReactionTargetComment, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for i := range comments {
+		comments[i].Reactions = summaries[comments[i].ID].Reactions
+		comments[i].ViewerReactions = summaries[comments[i].ID].ViewerReactions
+	}
+	return nil
+}
+
+// loadReactionSummaries - Jumlah reaction dan reaction viewer untuk tiap target,
+// map dan slice tidak pernah nil
+func loadReactionSummaries(ctx context.Context, store repository.Store, targetType string, targetIDs []uint, viewerID uint) (map[uint]ReactionSummary, error) {
+	counts, err := store.Reactions().Counts(ctx, targetType, targetIDs)
+	if err != nil {
+		return nil, err
+	}
+	viewer, err := store.Reactions().ByUser(ctx, targetType, targetIDs, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[uint]ReactionSummary, len(targetIDs))
+	for _, id := range targetIDs {
+		summary := ReactionSummary{Reactions: counts[id], ViewerReactions: viewer[id]}
+		if summary.Reactions == nil {
+			summary.Reactions = map[string]int64{}
+		}
+		if summary.ViewerReactions == nil {
+			summary.ViewerReactions = []string{}
+		}
+		summaries[id] = summary
+	}
+	return summaries, nil
+}
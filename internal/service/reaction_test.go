@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+func TestReactionServiceSetAndAttach(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	reactions := NewReactionService(&config.Config{ReactionKinds: []string{"like", "love"}}, store)
+
+	author := newTestUser(t, store, "author")
+	reader := newTestUser(t, store, "reader")
+	post := models.Post{Title: "Post", Content: "Content", UserID: author.ID}
+	store.Posts().Create(ctx, &post)
+
+	if _, err := reactions.SetPostReaction(ctx, reader.ID, post.ID, "wow", true); ErrorCode(err) != CodeInvalid {
+		t.Fatalf("Expected CodeInvalid for unknown kind, got %v", err)
+	}
+	if _, err := reactions.SetPostReaction(ctx, reader.ID, post.ID+100, "like", true); ErrorCode(err) != CodeNotFound {
+		t.Fatalf("Expected CodeNotFound for missing post, got %v", err)
+	}
+
+	// Reaction yang sama dua kali hanya dihitung (dan dinotifikasi) sekali
+	for i := 0; i < 2; i++ {
+		summary, err := reactions.SetPostReaction(ctx, reader.ID, post.ID, "like", true)
+		if err != nil || summary.Reactions["like"] != 1 || len(summary.ViewerReactions) != 1 {
+			t.Fatalf("Expected one like by viewer, got %+v %v", summary, err)
+		}
+	}
+	if stored, _ := store.Posts().Find(ctx, post.ID); stored.Score != 1 {
+		t.Errorf("Expected score 1, got %d", stored.Score)
+	}
+	if notifications := store.NotificationEvents(); len(notifications) != 1 || notifications[0].Type != models.NotificationTypeReaction {
+		t.Errorf("Expected one reaction notification, got %+v", notifications)
+	}
+
+	posts := []models.Post{post}
+	if err := reactions.AttachToPosts(ctx, 0, posts); err != nil || posts[0].Reactions["like"] != 1 || len(posts[0].ViewerReactions) != 0 {
+		t.Fatalf("Expected counts without viewer state for anonymous, got %+v %v", posts[0], err)
+	}
+
+	summary, err := reactions.SetPostReaction(ctx, reader.ID, post.ID, "like", false)
+	if err != nil || len(summary.Reactions) != 0 || len(summary.ViewerReactions) != 0 {
+		t.Fatalf("Expected reaction to be removed, got %+v %v", summary, err)
+	}
+	if stored, _ := store.Posts().Find(ctx, post.ID); stored.Score != 0 {
+		t.Errorf("Expected score 0 after removal, got %d", stored.Score)
+	}
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// ReadingListInput - Data reading list dari client
+type ReadingListInput struct {
+	Name        string
+	Description string
+	IsPublic    bool
+}
+
+// ReadingListService - Reading list milik user dan link share publiknya
+type ReadingListService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewReadingListService(cfg *config.Config, store repository.Store) *ReadingListService {
+	return &ReadingListService{cfg: cfg, store: store}
+}
+
+func validateReadingListInput(input ReadingListInput) error {
+	valid, errMsg := ValidateRequired(map[string]string{
+		"name": input.Name,
+	})
+	if !valid {
+		return newError(CodeInvalid, errMsg)
+	}
+
+	if !ValidateStringLength(input.Name, 1, 100) {
+		return newError(CodeInvalid, "Name must be between 1 and 100 characters")
+	}
+
+	if !ValidateStringLength(input.Description, 0, 1000) {
+		return newError(CodeInvalid, "Description must be at most 1000 characters")
+	}
+	return nil
+}
+
+// Create - Buat reading list baru milik user
+func (s *ReadingListService) Create(ctx context.Context, userID uint, input ReadingListInput) (models.ReadingList, error) {
+	if err := validateReadingListInput(input); err != nil {
+		return models.ReadingList{}, err
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return models.ReadingList{}, newError(CodeInternal, "Failed to generate share token")
+	}
+
+	list := models.ReadingList{
+		UserID:      userID,
+		Name:        input.Name,
+		Description: input.Description,
+		IsPublic:    input.IsPublic,
+		ShareToken:  token,
+	}
+	if err := s.store.ReadingLists().Create(ctx, &list); err != nil {
+		return models.ReadingList{}, newError(CodeInternal, "Failed to create reading list")
+	}
+	return list, nil
+}
+
+// List - Semua reading list milik user
+func (s *ReadingListService) List(ctx context.Context, userID uint) ([]models.ReadingList, error) {
+	lists, err := s.store.ReadingLists().ListByUser(ctx, userID)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch reading lists")
+	}
+	return lists, nil
+}
+
+// Get - Reading list milik user beserta post di dalamnya
+func (s *ReadingListService) Get(ctx context.Context, userID, listID uint) (models.ReadingList, error) {
+	list, err := s.findOwn(ctx, userID, listID)
+	if err != nil {
+		return list, err
+	}
+	return list, s.loadItems(ctx, &list)
+}
+
+// GetShared - Reading list publik lewat share token, pemilik (viewerID) tetap bisa melihat
+// list miliknya yang belum publik
+func (s *ReadingListService) GetShared(ctx context.Context, viewerID uint, token string) (models.ReadingList, error) {
+	list, err := s.store.ReadingLists().FindByShareToken(ctx, token)
+	if err != nil {
+		return list, newError(CodeNotFound, "Reading list not found")
+	}
+
+	if !list.IsPublic && (viewerID == 0 || viewerID != list.UserID) {
+		return models.ReadingList{}, newError(CodeNotFound, "Reading list not found")
+	}
+	return list, s.loadItems(ctx, &list)
+}
+
+// Update - Update nama, deskripsi, dan visibilitas reading list
+func (s *ReadingListService) Update(ctx context.Context, userID, listID uint, input ReadingListInput) (models.ReadingList, error) {
+	if err := validateReadingListInput(input); err != nil {
+		return models.ReadingList{}, err
+	}
+
+	list, err := s.findOwn(ctx, userID, listID)
+	if err != nil {
+		return list, err
+	}
+
+	list.Name = input.Name
+	list.Description = input.Description
+	list.IsPublic = input.IsPublic
+	if err := s.store.ReadingLists().Update(ctx, &list); err != nil {
+		return list, newError(CodeInternal, "Failed to update reading list")
+	}
+	return list, nil
+}
+
+// Delete - Hapus reading list (soft delete)
+func (s *ReadingListService) Delete(ctx context.Context, userID, listID uint) error {
+	list, err := s.findOwn(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.ReadingLists().Delete(ctx, &list); err != nil {
+		return newError(CodeInternal, "Failed to delete reading list")
+	}
+	return nil
+}
+
+// AddItem - Tambah post ke reading list (idempotent)
+func (s *ReadingListService) AddItem(ctx context.Context, userID, listID, postID uint) error {
+	list, err := s.findOwn(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.store.Posts().Find(ctx, postID); err != nil {
+		return newError(CodeNotFound, "Post not found")
+	}
+
+	if err := s.store.ReadingLists().AddItem(ctx, list.ID, postID); err != nil {
+		return newError(CodeInternal, "Failed to add post to reading list")
+	}
+	return nil
+}
+
+// RemoveItem - Hapus post dari reading list (idempotent)
+func (s *ReadingListService) RemoveItem(ctx context.Context, userID, listID, postID uint) error {
+	list, err := s.findOwn(ctx, userID, listID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.ReadingLists().RemoveItem(ctx, list.ID, postID); err != nil {
+		return newError(CodeInternal, "Failed to remove post from reading list")
+	}
+	return nil
+}
+
+// findOwn - Ambil reading list dan cek ownership
+func (s *ReadingListService) findOwn(ctx context.Context, userID, listID uint) (models.ReadingList, error) {
+	list, err := s.store.ReadingLists().Find(ctx, listID)
+	if err != nil {
+		return list, newError(CodeNotFound, "Reading list not found")
+	}
+
+	// Cek ownership
+	if list.UserID != userID {
+		return list, newError(CodeForbidden, "You can only manage your own reading lists")
+	}
+	return list, nil
+}
+
+// loadItems - Load item reading list beserta post yang masih ada
+func (s *ReadingListService) loadItems(ctx context.Context, list *models.ReadingList) error {
+	items, err := s.store.ReadingLists().Items(ctx, list.ID)
+	if err != nil {
+		return newError(CodeInternal, "Failed to fetch reading list items")
+	}
+	list.Items = items
+	return nil
+}
+
+// randomToken - Token acak untuk link share reading list dan nama file media
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
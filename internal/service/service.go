@@ -0,0 +1,100 @@
+// Package service berisi logika bisnis blog (validasi, ownership, transaksi dan efek samping
+// seperti webhook, notifikasi dan event realtime) yang dipakai bersama oleh transport
+// REST, GraphQL dan gRPC. Akses data lewat repository.Store yang di-inject ke tiap service.
+package service
+
+import (
+	"context"
+	"errors"
+
+	"blog-api/internal/config"
+	"blog-api/internal/ratelimit"
+)
+
+// Code - Kategori error bisnis, dipetakan masing-masing transport ke status HTTP / kode gRPC
+type Code int
+
+const (
+	CodeInvalid Code = iota + 1
+	CodeUnauthenticated
+	CodeForbidden
+	CodeNotFound
+	CodeConflict
+	CodeInternal
+	CodeRateLimited
+)
+
+// Error - Error bisnis dengan pesan yang aman ditampilkan ke client
+type Error struct {
+	Code    Code
+	Message string
+	// RateLimit - Untuk CodeRateLimited: status bucket yang menolak request
+	RateLimit *RateLimit
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func newError(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// orInternal - Error bisnis diteruskan apa adanya, error lain (database) menjadi CodeInternal
+func orInternal(err error, message string) error {
+	var svcErr *Error
+	if errors.As(err, &svcErr) {
+		return svcErr
+	}
+	return newError(CodeInternal, message)
+}
+
+// ErrorCode - Kategori error, CodeInternal untuk error yang bukan *Error
+func ErrorCode(err error) Code {
+	var svcErr *Error
+	if errors.As(err, &svcErr) {
+		return svcErr.Code
+	}
+	return CodeInternal
+}
+
+// RateLimit - Status bucket rate limit setelah request (policy dan hasil Take), dipakai transport
+// untuk header RateLimit-* dan Retry-After
+type RateLimit struct {
+	Policy config.RateLimitPolicy
+	ratelimit.Result
+}
+
+// RateLimitOf - Status bucket dari error CodeRateLimited, nil untuk error lain
+func RateLimitOf(err error) *RateLimit {
+	var svcErr *Error
+	if errors.As(err, &svcErr) {
+		return svcErr.RateLimit
+	}
+	return nil
+}
+
+// Publisher - Kirim event ke subscriber stream realtime (realtime.Hub), nil jika realtime tidak aktif
+type Publisher interface {
+	Publish(ctx context.Context, topic, event string, data interface{}) error
+}
+
+// Waker - Background worker yang dibangunkan setelah transaksi berisi job di-commit
+// (webhook.Dispatcher), nil jika tidak ada worker
+type Waker interface {
+	Wake()
+}
+
+// wake - Bangunkan worker jika ada, job tetap diambil poll berikutnya
+func wake(w Waker) {
+	if w != nil {
+		w.Wake()
+	}
+}
+
+// Origin - Info dari transport untuk efek samping: base URL link publik di payload webhook
+// dan session editor (header X-Editor-Session) yang diteruskan ke koneksi presence
+type Origin struct {
+	BaseURL       string
+	EditorSession string
+}
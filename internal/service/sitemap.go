@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"blog-api/internal/config"
+	"blog-api/internal/repository"
+)
+
+// SitemapSections - Section sitemap sesuai urutan di /sitemap.xml
+var SitemapSections = []string{repository.SitemapPosts, repository.SitemapAuthors, repository.SitemapTags}
+
+// SitemapService - Halaman publik (post, author, tag) untuk sitemap
+type SitemapService struct {
+	cfg   *config.Config
+	store repository.Store
+}
+
+func NewSitemapService(cfg *config.Config, store repository.Store) *SitemapService {
+	return &SitemapService{cfg: cfg, store: store}
+}
+
+// Counts - Jumlah entry per section
+func (s *SitemapService) Counts(ctx context.Context) (map[string]int64, error) {
+	counts := make(map[string]int64, len(SitemapSections))
+	for _, section := range SitemapSections {
+		count, err := s.store.Sitemap().Count(ctx, section)
+		if err != nil {
+			return nil, newError(CodeInternal, "Failed to build sitemap")
+		}
+		counts[section] = count
+	}
+	return counts, nil
+}
+
+// Entries - Halaman ke-page (mulai 1) section dengan perPage entry, kosong untuk section
+// yang tidak dikenal atau halaman di luar jangkauan
+func (s *SitemapService) Entries(ctx context.Context, section string, page, perPage int) ([]repository.SitemapEntry, error) {
+	entries, err := s.store.Sitemap().Entries(ctx, section, (page-1)*perPage, perPage)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to build sitemap")
+	}
+	return entries, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"blog-api/internal/metrics"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+	"blog-api/internal/tracing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// RegisterInput - Data registrasi dari client
+type RegisterInput struct {
+	Email    string
+	Password string
+	Name     string
+	Username string // Opsional, dibuat dari email jika kosong
+}
+
+// UserService - Registrasi, login dan profil user
+type UserService struct {
+	store repository.Store
+}
+
+func NewUserService(store repository.Store) *UserService {
+	return &UserService{store: store}
+}
+
+// Register - Validasi dan buat user baru dengan password yang di-hash
+func (s *UserService) Register(ctx context.Context, input RegisterInput) (models.User, error) {
+	// Validasi input menggunakan validator
+	valid, errMsg := ValidateRequired(map[string]string{
+		"email":    input.Email,
+		"password": input.Password,
+		"name":     input.Name,
+	})
+	if !valid {
+		return models.User{}, newError(CodeInvalid, errMsg)
+	}
+
+	if !ValidateEmail(input.Email) {
+		return models.User{}, newError(CodeInvalid, "Invalid email format")
+	}
+
+	if !ValidatePassword(input.Password) {
+		return models.User{}, newError(CodeInvalid, "Password must be at least 6 characters")
+	}
+
+	if !ValidateStringLength(input.Name, 2, 100) {
+		return models.User{}, newError(CodeInvalid, "Name must be between 2 and 100 characters")
+	}
+
+	username := NormalizeUsername(input.Username)
+	if username != "" {
+		if valid, errMsg := ValidateUsername(username); !valid {
+			return models.User{}, newError(CodeInvalid, errMsg)
+		}
+	} else {
+		generated, err := s.generateUsername(ctx, input.Email)
+		if err != nil {
+			return models.User{}, newError(CodeInternal, "Failed to generate username")
+		}
+		username = generated
+	}
+
+	// Hash password
+	_, span := tracing.Tracer().Start(ctx, "bcrypt.GenerateFromPassword")
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	span.End()
+	if err != nil {
+		return models.User{}, newError(CodeInternal, "Failed to hash password")
+	}
+
+	// Buat user baru
+	user := models.User{
+		Email:    input.Email,
+		Password: string(hashedPassword),
+		Name:     input.Name,
+		Username: username,
+	}
+
+	err = s.store.Users().Create(ctx, &user)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return user, newError(CodeInvalid, "Email or username already exists")
+	}
+	if err != nil {
+		return user, newError(CodeInternal, "Failed to create user")
+	}
+	metrics.Registrations.Inc()
+	return user, nil
+}
+
+// Login - Cari user berdasarkan email dan verifikasi password
+func (s *UserService) Login(ctx context.Context, email, password string) (models.User, error) {
+	// Validasi input
+	valid, errMsg := ValidateRequired(map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	if !valid {
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return models.User{}, newError(CodeInvalid, errMsg)
+	}
+
+	user, err := s.store.Users().FindByEmail(ctx, email)
+	if err != nil {
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return models.User{}, newError(CodeUnauthenticated, "Invalid credentials")
+	}
+
+	// Verifikasi password
+	_, span := tracing.Tracer().Start(ctx, "bcrypt.CompareHashAndPassword")
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	span.End()
+	if err != nil {
+		metrics.Logins.WithLabelValues("failure").Inc()
+		return models.User{}, newError(CodeUnauthenticated, "Invalid credentials")
+	}
+	metrics.Logins.WithLabelValues("success").Inc()
+	return user, nil
+}
+
+// UpdateUsername - Ganti username user, username harus valid dan belum dipakai
+func (s *UserService) UpdateUsername(ctx context.Context, userID uint, username string) (models.User, error) {
+	username = NormalizeUsername(username)
+	if valid, errMsg := ValidateUsername(username); !valid {
+		return models.User{}, newError(CodeInvalid, errMsg)
+	}
+
+	user, err := s.store.Users().Find(ctx, userID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return user, newError(CodeNotFound, "User not found")
+	}
+	if err != nil {
+		return user, newError(CodeInternal, "Failed to fetch user")
+	}
+
+	err = s.store.Users().UpdateUsername(ctx, &user, username)
+	if errors.Is(err, repository.ErrDuplicate) {
+		return user, newError(CodeConflict, "Username already taken")
+	}
+	if err != nil {
+		return user, newError(CodeInternal, "Failed to update username")
+	}
+	return user, nil
+}
+
+// Search - User dengan prefix username untuk autocomplete @mention (hanya id, username, name)
+func (s *UserService) Search(ctx context.Context, prefix string, limit int) ([]models.User, error) {
+	prefix = NormalizeUsername(prefix)
+	if prefix == "" {
+		return nil, newError(CodeInvalid, "prefix is required")
+	}
+
+	users, err := s.store.Users().Search(ctx, prefix, limit)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to search users")
+	}
+	return users, nil
+}
+
+// FindByIDs - Banyak user sekaligus, urutan tidak dijamin
+func (s *UserService) FindByIDs(ctx context.Context, ids []uint) ([]models.User, error) {
+	users, err := s.store.Users().FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch users")
+	}
+	return users, nil
+}
+
+// FindByUsername - User dengan username (dinormalisasi lebih dulu)
+func (s *UserService) FindByUsername(ctx context.Context, username string) (models.User, error) {
+	user, err := s.store.Users().FindByUsername(ctx, NormalizeUsername(username))
+	if errors.Is(err, repository.ErrNotFound) {
+		return user, newError(CodeNotFound, "User not found")
+	}
+	if err != nil {
+		return user, newError(CodeInternal, "Failed to fetch user")
+	}
+	return user, nil
+}
+
+// generateUsername - Buat username unik dari bagian lokal email saat register tanpa username
+func (s *UserService) generateUsername(ctx context.Context, email string) (string, error) {
+	local := strings.ToLower(strings.SplitN(email, "@", 2)[0])
+
+	var b strings.Builder
+	for _, ch := range local {
+		if (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '_' {
+			b.WriteRune(ch)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	base := b.String()
+	if len(base) > 24 {
+		base = base[:24]
+	}
+	if valid, _ := ValidateUsername(base); !valid {
+		base = "user_" + base
+		if len(base) > 24 {
+			base = base[:24]
+		}
+	}
+
+	candidate := base
+	for i := 1; i <= 20; i++ {
+		taken, err := s.store.Users().UsernameTaken(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = base + strconv.Itoa(i)
+	}
+
+	// Fallback ke suffix acak jika banyak yang bentrok
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	return base + "_" + hex.EncodeToString(suffix), nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+)
+
+// failingUsers - Store yang gagal menyimpan user dengan error selain duplikat (mis. koneksi putus)
+type failingUsers struct {
+	*repository.MemoryStore
+}
+
+func (s failingUsers) Users() repository.UserRepository {
+	return failingUserRepository{s.MemoryStore.Users()}
+}
+
+type failingUserRepository struct {
+	repository.UserRepository
+}
+
+func (failingUserRepository) Create(context.Context, *models.User) error {
+	return errors.New("connection refused")
+}
+
+func TestUserServiceRegisterErrors(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	input := RegisterInput{Email: "jane@example.com", Password: "secret123", Name: "Jane"}
+
+	store := repository.NewMemoryStore()
+	users := NewUserService(store)
+	if _, err := users.Register(ctx, input); err != nil {
+		t.Fatalf("Expected user to be registered, got %v", err)
+	}
+	if _, err := users.Register(ctx, input); ErrorCode(err) != CodeInvalid {
+		t.Errorf("Expected duplicate email to be invalid, got %v", err)
+	}
+
+	broken := NewUserService(failingUsers{repository.NewMemoryStore()})
+	if _, err := broken.Register(ctx, input); ErrorCode(err) != CodeInternal {
+		t.Errorf("Expected storage failure to be internal, got %v", err)
+	}
+}
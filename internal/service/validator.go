@@ -0,0 +1,98 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var usernameRegex = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+
+var tagRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+// maxPostTags - Jumlah tag maksimal per post
+const maxPostTags = 10
+
+// reservedUsernames - Username yang tidak boleh dipakai user
+var reservedUsernames = map[string]bool{
+	"admin": true, "administrator": true, "api": true, "me": true, "root": true,
+	"system": true, "support": true, "help": true, "moderator": true, "staff": true,
+	"everyone": true, "here": true, "all": true, "null": true, "undefined": true,
+	"login": true, "register": true, "settings": true, "feed": true, "swagger": true,
+	"health": true, "about": true, "blog": true,
+}
+
+// ValidateEmail - Validasi format email
+func ValidateEmail(email string) bool {
+	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
+	return emailRegex.MatchString(email)
+}
+
+// ValidatePassword - Validasi password minimal 6 karakter
+func ValidatePassword(password string) bool {
+	return len(password) >= 6
+}
+
+// ValidateStringLength - Validasi panjang string
+func ValidateStringLength(str string, min, max int) bool {
+	length := len(str)
+	return length >= min && length <= max
+}
+
+// ValidateRequired - Validasi field required
+func ValidateRequired(fields map[string]string) (bool, string) {
+	for fieldName, fieldValue := range fields {
+		if fieldValue == "" {
+			return false, fieldName + " is required"
+		}
+	}
+	return true, ""
+}
+
+// ValidateUsername - Validasi username (lowercase, 3-30 karakter, bukan reserved word)
+func ValidateUsername(username string) (bool, string) {
+	if !usernameRegex.MatchString(username) {
+		return false, "Username must be 3-30 characters of lowercase letters, numbers or underscore"
+	}
+	if reservedUsernames[username] {
+		return false, "Username is reserved"
+	}
+	return true, ""
+}
+
+// NormalizeUsername - Username disimpan lowercase, tanpa awalan @
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+}
+
+// ValidateTags - Validasi tag yang sudah di-normalize (format dan jumlah maksimal)
+func ValidateTags(tags []string) (bool, string) {
+	if len(tags) > maxPostTags {
+		return false, fmt.Sprintf("A post can have at most %d tags", maxPostTags)
+	}
+	for _, tag := range tags {
+		if !tagRegex.MatchString(tag) {
+			return false, "Tags must be 1-50 characters of lowercase letters, numbers or dash"
+		}
+	}
+	return true, ""
+}
+
+// NormalizeTags - Tag disimpan lowercase tanpa awalan #, duplikat dibuang
+// (nil tetap nil agar update bisa membedakan "tidak diubah" dan "hapus semua")
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
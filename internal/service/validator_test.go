@@ -1,4 +1,4 @@
-package handlers
+package service
 
 import "testing"
 
@@ -71,3 +71,55 @@ func TestValidateStringLength(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		expected bool
+	}{
+		{"john_doe", true},
+		{"abc", true},
+		{"ab", false},
+		{"John", false},
+		{"john-doe", false},
+		{"admin", false},
+		{"this_username_is_way_too_long_x", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.username, func(t *testing.T) {
+			result, _ := ValidateUsername(tt.username)
+			if result != tt.expected {
+				t.Errorf("ValidateUsername(%s) = %v, expected %v", tt.username, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeAndValidateTags(t *testing.T) {
+	tags := NormalizeTags([]string{" Go ", "#go", "web-dev"})
+	if len(tags) != 2 || tags[0] != "go" || tags[1] != "web-dev" {
+		t.Fatalf("NormalizeTags returned %v", tags)
+	}
+
+	tests := []struct {
+		name     string
+		tags     []string
+		expected bool
+	}{
+		{"Valid", []string{"go", "web-dev"}, true},
+		{"Empty tag", []string{""}, false},
+		{"Leading dash", []string{"-go"}, false},
+		{"Space", []string{"web dev"}, false},
+		{"Too many", []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := ValidateTags(tt.tags)
+			if result != tt.expected {
+				t.Errorf("ValidateTags(%v) = %v, expected %v", tt.tags, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+	"blog-api/internal/repository"
+	"blog-api/internal/webhook"
+)
+
+// maxWebhooksPerUser - Batas subscription webhook per user
+const maxWebhooksPerUser = 10
+
+// WebhookInput - Data webhook dari client, saat update field nil tidak diubah
+type WebhookInput struct {
+	URL    *string
+	Secret *string // Opsional, kosong saat create berarti dibuatkan secret acak
+	Events []string
+	Active *bool
+}
+
+// WebhookService - Subscription webhook milik user, riwayat delivery dan redelivery
+type WebhookService struct {
+	cfg        *config.Config
+	store      repository.Store
+	dispatcher Waker
+}
+
+func NewWebhookService(cfg *config.Config, store repository.Store, dispatcher Waker) *WebhookService {
+	return &WebhookService{cfg: cfg, store: store, dispatcher: dispatcher}
+}
+
+// validateWebhookEvents - Event harus dikenal (atau "*") dan minimal satu
+func validateWebhookEvents(events []string) error {
+	if len(events) == 0 {
+		return newError(CodeInvalid, "At least one event is required")
+	}
+	for _, event := range events {
+		if event == "*" {
+			continue
+		}
+		known := false
+		for _, eventType := range models.WebhookEventTypes {
+			if event == eventType {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return newError(CodeInvalid, "Unknown event: "+event)
+		}
+	}
+	return nil
+}
+
+// validateWebhookURL - Validasi URL tujuan sesuai WEBHOOK_ALLOW_PRIVATE_NETWORKS
+func (s *WebhookService) validateWebhookURL(raw string) error {
+	if len(raw) > 2048 {
+		return newError(CodeInvalid, "URL must be at most 2048 characters")
+	}
+	if err := webhook.ValidateURL(raw, s.cfg.WebhookAllowPrivate); err != nil {
+		return newError(CodeInvalid, "URL must be a public http or https URL")
+	}
+	return nil
+}
+
+// validateWebhookSecret - Secret buatan user minimal 16 karakter
+func validateWebhookSecret(secret string) error {
+	if !ValidateStringLength(secret, 16, 128) {
+		return newError(CodeInvalid, "Secret must be between 16 and 128 characters")
+	}
+	return nil
+}
+
+// Create - Daftarkan endpoint webhook untuk event pada konten milik user
+func (s *WebhookService) Create(ctx context.Context, userID uint, input WebhookInput) (models.Webhook, error) {
+	if input.URL == nil {
+		return models.Webhook{}, newError(CodeInvalid, "url is required")
+	}
+	if err := s.validateWebhookURL(*input.URL); err != nil {
+		return models.Webhook{}, err
+	}
+	if err := validateWebhookEvents(input.Events); err != nil {
+		return models.Webhook{}, err
+	}
+
+	hook := models.Webhook{
+		UserID: userID,
+		URL:    *input.URL,
+		Events: input.Events,
+		Active: input.Active == nil || *input.Active,
+	}
+
+	if input.Secret != nil && *input.Secret != "" {
+		if err := validateWebhookSecret(*input.Secret); err != nil {
+			return models.Webhook{}, err
+		}
+		hook.Secret = *input.Secret
+	} else {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return models.Webhook{}, newError(CodeInternal, "Failed to create webhook")
+		}
+		hook.Secret = secret
+	}
+
+	count, err := s.store.Webhooks().CountByUser(ctx, userID)
+	if err != nil {
+		return models.Webhook{}, newError(CodeInternal, "Failed to create webhook")
+	}
+	if count >= maxWebhooksPerUser {
+		return models.Webhook{}, newError(CodeConflict, "Webhook limit reached")
+	}
+
+	if err := s.store.Webhooks().Create(ctx, &hook); err != nil {
+		return models.Webhook{}, newError(CodeInternal, "Failed to create webhook")
+	}
+	return hook, nil
+}
+
+// List - Daftar webhook milik user
+func (s *WebhookService) List(ctx context.Context, userID uint) ([]models.Webhook, error) {
+	webhooks, err := s.store.Webhooks().ListByUser(ctx, userID)
+	if err != nil {
+		return nil, newError(CodeInternal, "Failed to fetch webhooks")
+	}
+	return webhooks, nil
+}
+
+// Get - Webhook milik user
+func (s *WebhookService) Get(ctx context.Context, userID, webhookID uint) (models.Webhook, error) {
+	hook, err := s.store.Webhooks().Find(ctx, userID, webhookID)
+	if err != nil {
+		return hook, newError(CodeNotFound, "Webhook not found")
+	}
+	return hook, nil
+}
+
+// Update - Ubah URL, event, status aktif atau rotate secret
+func (s *WebhookService) Update(ctx context.Context, userID, webhookID uint, input WebhookInput) (models.Webhook, error) {
+	hook, err := s.Get(ctx, userID, webhookID)
+	if err != nil {
+		return hook, err
+	}
+
+	if input.URL != nil {
+		if err := s.validateWebhookURL(*input.URL); err != nil {
+			return hook, err
+		}
+		hook.URL = *input.URL
+	}
+	if input.Events != nil {
+		if err := validateWebhookEvents(input.Events); err != nil {
+			return hook, err
+		}
+		hook.Events = input.Events
+	}
+	if input.Active != nil {
+		hook.Active = *input.Active
+	}
+	if input.Secret != nil {
+		if err := validateWebhookSecret(*input.Secret); err != nil {
+			return hook, err
+		}
+		hook.Secret = *input.Secret
+	}
+
+	if err := s.store.Webhooks().Update(ctx, &hook); err != nil {
+		return hook, newError(CodeInternal, "Failed to update webhook")
+	}
+	return hook, nil
+}
+
+// Delete - Hapus webhook beserta log delivery-nya (dengan transaksi)
+func (s *WebhookService) Delete(ctx context.Context, userID, webhookID uint) error {
+	hook, err := s.Get(ctx, userID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	err = s.store.Transaction(ctx, func(tx repository.Store) error {
+		return tx.Webhooks().Delete(ctx, &hook)
+	})
+	if err != nil {
+		return newError(CodeInternal, "Failed to delete webhook")
+	}
+	return nil
+}
+
+// Deliveries - Log delivery webhook milik user (terbaru dulu), status kosong berarti semua status
+func (s *WebhookService) Deliveries(ctx context.Context, userID, webhookID uint, status string, opts PageOptions) ([]models.WebhookDelivery, string, error) {
+	hook, err := s.Get(ctx, userID, webhookID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, err := opts.toPage("")
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch status {
+	case "", models.WebhookDeliveryPending, models.WebhookDeliverySucceeded, models.WebhookDeliveryDead:
+	default:
+		return nil, "", newError(CodeInvalid, "Invalid status, must be one of: pending, succeeded, dead")
+	}
+
+	deliveries, err := s.store.Webhooks().Deliveries(ctx, hook.ID, status, page)
+	if err != nil {
+		return nil, "", newError(CodeInternal, "Failed to fetch deliveries")
+	}
+	deliveries, nextCursor := trimPage(deliveries, page.Limit, func(d models.WebhookDelivery) Cursor {
+		return Cursor{ID: d.ID}
+	})
+	return deliveries, nextCursor, nil
+}
+
+// Redeliver - Kirim ulang event sebuah delivery sebagai delivery baru (log lama tetap ada)
+func (s *WebhookService) Redeliver(ctx context.Context, userID, webhookID, deliveryID uint) (models.WebhookDelivery, error) {
+	hook, err := s.Get(ctx, userID, webhookID)
+	if err != nil {
+		return models.WebhookDelivery{}, err
+	}
+
+	delivery, err := s.store.Webhooks().FindDelivery(ctx, hook.ID, deliveryID)
+	if err != nil {
+		return models.WebhookDelivery{}, newError(CodeNotFound, "Delivery not found")
+	}
+
+	event, err := s.store.Webhooks().FindEvent(ctx, delivery.EventID)
+	if err != nil {
+		return models.WebhookDelivery{}, newError(CodeNotFound, "Event not found")
+	}
+
+	redelivery := webhook.NewDelivery(hook.ID, event, time.Now())
+	if err := s.store.Webhooks().CreateDelivery(ctx, &redelivery); err != nil {
+		return models.WebhookDelivery{}, newError(CodeInternal, "Failed to schedule redelivery")
+	}
+
+	wake(s.dispatcher)
+	return redelivery, nil
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStorage - Simpan file di direktori lokal, di-serve oleh handler ServeMediaFile
+type LocalStorage struct {
+	Dir     string
+	BaseURL string // Prefix URL publik, misal /media
+}
+
+// NewLocal - Buat LocalStorage, direktori dibuat jika belum ada
+func NewLocal(dir, baseURL string) (*LocalStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStorage{Dir: dir, BaseURL: strings.TrimRight(baseURL, "/")}, nil
+}
+
+// Path - Path file di disk untuk key, error jika key keluar dari Dir (path traversal)
+func (s *LocalStorage) Path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if clean == "/" {
+		return "", ErrNotFound
+	}
+	return filepath.Join(s.Dir, filepath.FromSlash(clean)), nil
+}
+
+// Put - Tulis ke file sementara lalu rename agar tidak ada file setengah jadi
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Open - Buka file, hasilnya *os.File sehingga bisa dipakai untuk range request
+func (s *LocalStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.Path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+// Delete - Hapus file
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.Path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Check - Pastikan direktori masih ada dan bisa ditulisi (misal volume ter-mount read-only)
+func (s *LocalStorage) Check(ctx context.Context) error {
+	tmp, err := os.CreateTemp(s.Dir, ".check-*")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	return os.Remove(tmp.Name())
+}
+
+// URL - URL publik file
+func (s *LocalStorage) URL(key string) string {
+	return s.BaseURL + "/" + key
+}
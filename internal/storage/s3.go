@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// unsignedPayload - Body tidak ikut di-hash agar upload bisa di-stream
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Config - Konfigurasi bucket S3-compatible (AWS S3, MinIO, R2, ...)
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PublicURL string
+}
+
+// S3Storage - Storage S3-compatible memakai REST API dengan signature V4
+// (path-style URL: endpoint/bucket/key, didukung oleh MinIO dan AWS)
+type S3Storage struct {
+	cfg    S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3 - Buat S3Storage
+func NewS3(cfg S3Config) *S3Storage {
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	cfg.PublicURL = strings.TrimRight(cfg.PublicURL, "/")
+	return &S3Storage{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 60 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// Put - PUT object
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError(resp)
+	}
+	return nil
+}
+
+// Open - GET object
+func (s *S3Storage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s.responseError(resp)
+	}
+}
+
+// Delete - DELETE object (S3 menjawab 204 juga untuk object yang tidak ada)
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return s.responseError(resp)
+	}
+	return nil
+}
+
+// Check - HEAD bucket, memastikan endpoint bisa dihubungi dan credential diterima
+func (s *S3Storage) Check(ctx context.Context) error {
+	path := "/" + encodePath(s.cfg.Bucket)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, s.cfg.Endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.URL.RawPath = path
+	s.sign(req)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("s3: HEAD bucket %s returned %s", s.cfg.Bucket, resp.Status)
+	}
+	return nil
+}
+
+// URL - URL publik object (S3_PUBLIC_URL jika diatur, misal CDN)
+func (s *S3Storage) URL(key string) string {
+	if s.cfg.PublicURL != "" {
+		return s.cfg.PublicURL + "/" + encodePath(key)
+	}
+	return s.cfg.Endpoint + s.objectPath(key)
+}
+
+func (s *S3Storage) objectPath(key string) string {
+	return "/" + encodePath(s.cfg.Bucket) + "/" + encodePath(key)
+}
+
+func (s *S3Storage) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.cfg.Endpoint+s.objectPath(key), body)
+	if err != nil {
+		return nil, err
+	}
+	// Path sudah di-encode sesuai aturan S3, jangan di-encode ulang oleh net/url
+	req.URL.RawPath = s.objectPath(key)
+	return req, nil
+}
+
+// sign - Tambahkan header Authorization AWS Signature Version 4
+func (s *S3Storage) sign(req *http.Request) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + unsignedPayload,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := date + "/" + s.cfg.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex(canonicalRequest),
+	}, "\n")
+
+	key := signingKey(s.cfg.SecretKey, date, s.cfg.Region, "s3")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.cfg.AccessKey, scope, signedHeaders, signature))
+}
+
+func (s *S3Storage) responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3: %s %s: %s", resp.Request.Method, resp.Status, strings.TrimSpace(string(body)))
+}
+
+// encodePath - URI encode sesuai aturan S3: hanya A-Z a-z 0-9 - _ . ~ yang tidak di-encode, / dipertahankan
+func encodePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		ch := path[i]
+		switch {
+		case ch >= 'A' && ch <= 'Z', ch >= 'a' && ch <= 'z', ch >= '0' && ch <= '9',
+			ch == '-', ch == '_', ch == '.', ch == '~', ch == '/':
+			b.WriteByte(ch)
+		default:
+			fmt.Fprintf(&b, "%%%02X", ch)
+		}
+	}
+	return b.String()
+}
+
+// signingKey - Turunan key HMAC per tanggal/region/service (AWS SigV4)
+func signingKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hashHex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"blog-api/internal/config"
+)
+
+// ErrNotFound - Object tidak ada di storage
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage - Backend penyimpanan file media (local filesystem atau S3-compatible)
+type Storage interface {
+	// Put - Simpan object, size -1 jika tidak diketahui
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Open - Baca isi object, caller wajib Close
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete - Hapus object, tidak error jika object sudah tidak ada
+	Delete(ctx context.Context, key string) error
+	// URL - URL publik object
+	URL(key string) string
+}
+
+// Checker - Storage yang bisa diperiksa kesehatannya (dipakai readiness probe)
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// New - Buat storage sesuai STORAGE_DRIVER
+func New(cfg *config.Config) (Storage, error) {
+	var store Storage
+	switch cfg.StorageDriver {
+	case "", "local":
+		local, err := NewLocal(cfg.StorageLocalDir, "/media")
+		if err != nil {
+			return nil, err
+		}
+		store = local
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			return nil, errors.New("S3_ENDPOINT and S3_BUCKET are required for the s3 storage driver")
+		}
+		store = NewS3(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.S3PublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.StorageDriver)
+	}
+
+	slog.Info("media storage ready", "driver", cfg.StorageDriver)
+	return store, nil
+}
+
+// Check - Periksa storage, nil jika storage tidak mendukung Checker
+func Check(ctx context.Context, store Storage) error {
+	if checker, ok := store.(Checker); ok {
+		return checker.Check(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"errors"
+	"regexp"
+
+	"blog-api/internal/database"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey - Span statement yang sedang berjalan, disimpan per instance GORM
+const spanKey = "tracing:span"
+
+// maxStatementLength - SQL yang lebih panjang dipotong agar span tidak membengkak (misal bulk insert)
+const maxStatementLength = 2048
+
+var (
+	stringLiteral = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// Angka yang berdiri sendiri, bukan bagian identifier (t1) atau placeholder Postgres ($1)
+	numericLiteral = regexp.MustCompile(`(^|[^\w$])\d+(?:\.\d+)?\b`)
+)
+
+// GormPlugin - Plugin GORM yang membuat span client per query, anak dari span di context statement
+// (pastikan query memakai db.WithContext(ctx) agar span tersambung ke request)
+type GormPlugin struct{}
+
+func (GormPlugin) Name() string {
+	return "tracing"
+}
+
+func (GormPlugin) Initialize(db *gorm.DB) error {
+	return database.RegisterCallbacks(db, "tracing", startSpan, endSpan)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if !trace.SpanFromContext(ctx).SpanContext().IsValid() {
+			// Query di luar request (worker, startup) tidak membuat trace baru per statement
+			return
+		}
+
+		name := "db." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		_, span := Tracer().Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system.name", db.Dialector.Name()),
+				attribute.String("db.operation.name", operation),
+			),
+		)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func endSpan(string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(spanKey)
+		if !ok {
+			return
+		}
+		span, ok := value.(trace.Span)
+		if !ok {
+			return
+		}
+		defer span.End()
+
+		if db.Statement.Table != "" {
+			span.SetAttributes(attribute.String("db.collection.name", db.Statement.Table))
+		}
+		span.SetAttributes(
+			attribute.String("db.query.text", SanitizeSQL(db.Statement.SQL.String())),
+			attribute.Int64("db.rows_affected", db.RowsAffected),
+		)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			span.RecordError(db.Error)
+			span.SetStatus(codes.Error, db.Error.Error())
+		}
+	}
+}
+
+// SanitizeSQL - Ganti literal string dan angka dengan ? (nilai dari binding GORM sudah berupa
+// placeholder, ini untuk SQL mentah via Exec/Raw yang menulis nilai langsung)
+func SanitizeSQL(sql string) string {
+	sql = stringLiteral.ReplaceAllString(sql, "?")
+	sql = numericLiteral.ReplaceAllString(sql, "${1}?")
+	if len(sql) > maxStatementLength {
+		sql = sql[:maxStatementLength] + "..."
+	}
+	return sql
+}
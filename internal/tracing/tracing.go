@@ -0,0 +1,93 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"blog-api/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName - Nama service default di resource, bisa diganti lewat OTEL_SERVICE_NAME
+const serviceName = "blog-api"
+
+// flushTimeout - Batas waktu mengirim span yang tersisa saat shutdown
+const flushTimeout = 5 * time.Second
+
+// Tracer - Tracer aplikasi, no-op sampai Init memasang provider
+func Tracer() trace.Tracer {
+	return otel.Tracer(serviceName)
+}
+
+// Init - Pasang propagator W3C (traceparent, baggage) dan tracer provider sesuai TRACING_EXPORTER.
+// Fungsi yang dikembalikan mengirim span yang tersisa, dipanggil saat shutdown.
+func Init(ctx context.Context, cfg *config.Config) (shutdown func(), err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+	switch strings.ToLower(cfg.TracingExporter) {
+	case "", "none":
+		return func() {}, nil
+	case "otlp":
+		// Endpoint, header dan TLS dibaca exporter dari OTEL_EXPORTER_OTLP_*
+		exporter, err = otlptracehttp.New(ctx)
+	case "stdout":
+		var w io.Writer = os.Stdout
+		if cfg.TracingFile != "" {
+			file, err = os.OpenFile(cfg.TracingFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, err
+			}
+			w = file
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(w))
+	default:
+		return nil, fmt.Errorf("unknown TRACING_EXPORTER %q", cfg.TracingExporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		// Request dengan traceparent mengikuti keputusan sampling parent
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	slog.Info("tracing enabled", "exporter", cfg.TracingExporter, "sample_ratio", cfg.TracingSampleRatio)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		defer cancel()
+		if err := provider.Shutdown(ctx); err != nil {
+			slog.Error("failed to flush traces", "error", err)
+		}
+		if file != nil {
+			file.Close()
+		}
+	}, nil
+}
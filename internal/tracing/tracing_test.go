@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blog-api/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// recordSpans - Pasang tracer provider yang menyimpan span di memori selama test
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func attributeValue(attrs []attribute.KeyValue, key string) string {
+	for _, attr := range attrs {
+		if string(attr.Key) == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestSanitizeSQL(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM posts WHERE id = $1 AND user_id = ?":    "SELECT * FROM posts WHERE id = $1 AND user_id = ?",
+		"UPDATE users SET role = 'admin' WHERE email = 'a''b'": "UPDATE users SET role = ? WHERE email = ?",
+		"SELECT * FROM t1 LIMIT 10 OFFSET 2.5":                 "SELECT * FROM t1 LIMIT ? OFFSET ?",
+	}
+	for input, expected := range tests {
+		if got := SanitizeSQL(input); got != expected {
+			t.Errorf("SanitizeSQL(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
+
+func TestGormPlugin(t *testing.T) {
+	recorder := recordSpans(t)
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(GormPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+
+	type widget struct {
+		ID   uint
+		Name string
+	}
+	// Tanpa span parent (misal saat startup) tidak ada span yang dibuat
+	db.Exec("CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)")
+	if spans := recorder.Ended(); len(spans) != 0 {
+		t.Fatalf("Expected no spans outside a trace, got %d", len(spans))
+	}
+
+	ctx, parent := Tracer().Start(context.Background(), "request")
+	db.WithContext(ctx).Create(&widget{Name: "secret"})
+	var found widget
+	db.WithContext(ctx).Where("name = ?", "secret").First(&found)
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected 2 query spans and the parent, got %d", len(spans))
+	}
+	create, query := spans[0], spans[1]
+	if create.Name() != "db.create widgets" || query.Name() != "db.query widgets" {
+		t.Errorf("Unexpected span names %q and %q", create.Name(), query.Name())
+	}
+	if create.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("Expected db span to be a child of the request span")
+	}
+	if attributeValue(query.Attributes(), "db.system.name") != "sqlite" {
+		t.Errorf("Expected db.system.name attribute, got %v", query.Attributes())
+	}
+	for _, span := range spans[:2] {
+		if text := attributeValue(span.Attributes(), "db.query.text"); text == "" || strings.Contains(text, "secret") {
+			t.Errorf("Expected statement without bound values, got %q", text)
+		}
+	}
+}
+
+func TestInitStdoutExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	file := filepath.Join(t.TempDir(), "traces.json")
+	flush, err := Init(context.Background(), &config.Config{TracingExporter: "stdout", TracingFile: file, TracingSampleRatio: 1})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	_, span := Tracer().Start(context.Background(), "checkout")
+	span.End()
+	flush()
+
+	content, _ := os.ReadFile(file)
+	if !strings.Contains(string(content), `"Name":"checkout"`) {
+		t.Errorf("Expected span written to trace file, got %s", content)
+	}
+
+	if _, err := Init(context.Background(), &config.Config{TracingExporter: "zipkin"}); err == nil {
+		t.Error("Expected unknown exporter to be rejected")
+	}
+}
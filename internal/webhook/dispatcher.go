@@ -0,0 +1,286 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"blog-api/internal/config"
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// pollInterval - Interval pengecekan outbox dan retry yang jatuh tempo
+	pollInterval = 5 * time.Second
+	// deliveryTimeout - Batas waktu satu request ke endpoint
+	deliveryTimeout = 10 * time.Second
+	// leaseDuration - Delivery yang sedang dikirim di-"sewa" selama ini agar tidak diambil worker lain;
+	// jika worker mati, delivery otomatis dicoba lagi setelah lease habis
+	leaseDuration = time.Minute
+	// batchSize - Jumlah event/delivery yang diambil per putaran
+	batchSize = 50
+	// maxResponseLog - Potongan body response yang disimpan di log delivery
+	maxResponseLog = 1024
+)
+
+// Dispatcher - Worker pengirim webhook: fan-out event outbox menjadi delivery lalu kirim dengan retry
+type Dispatcher struct {
+	cfg  *config.Config
+	db   *gorm.DB
+	wake chan struct{}
+}
+
+// NewDispatcher - Buat Dispatcher, worker baru berjalan setelah Start
+func NewDispatcher(cfg *config.Config, db *gorm.DB) *Dispatcher {
+	return &Dispatcher{cfg: cfg, db: db, wake: make(chan struct{}, 1)}
+}
+
+// Wake - Bangunkan dispatcher setelah transaksi berisi event di-commit, tidak pernah blocking
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start - Jalankan cfg.WebhookWorkers worker pengirim webhook sampai ctx selesai,
+// fungsi yang dikembalikan menunggu semua worker berhenti
+func (d *Dispatcher) Start(ctx context.Context) (wait func()) {
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.WebhookWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			for {
+				if err := d.DispatchPending(ctx); err != nil && ctx.Err() == nil {
+					slog.Error("failed to dispatch webhooks", "error", err)
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-d.wake:
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+	return wg.Wait
+}
+
+// DispatchPending - Fan-out event outbox menjadi delivery, lalu kirim delivery yang jatuh tempo
+func (d *Dispatcher) DispatchPending(ctx context.Context) error {
+	if err := fanOut(d.db); err != nil {
+		return err
+	}
+
+	client := newClient(d.cfg.WebhookAllowPrivate)
+	defer client.CloseIdleConnections()
+
+	var deliveries []models.WebhookDelivery
+	err := d.db.
+		Where("status = ? AND next_attempt_at <= ?", models.WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at ASC, id ASC").Limit(batchSize).Find(&deliveries).Error
+	if err != nil {
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err := attempt(ctx, d.db, client, delivery, d.cfg.WebhookMaxAttempts); err != nil {
+			slog.Warn("webhook delivery failed", "delivery_id", delivery.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// fanOut - Buat delivery untuk setiap webhook yang cocok dengan event outbox yang belum diproses
+func fanOut(db *gorm.DB) error {
+	var events []models.WebhookEvent
+	if err := db.Where("dispatched_at IS NULL").Order("id ASC").Limit(batchSize).Find(&events).Error; err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Claim event agar tidak di-fan-out dua kali oleh worker lain
+			now := time.Now()
+			claim := tx.Model(&models.WebhookEvent{}).
+				Where("id = ? AND dispatched_at IS NULL", event.ID).
+				Update("dispatched_at", now)
+			if claim.Error != nil || claim.RowsAffected == 0 {
+				return claim.Error
+			}
+
+			var webhooks []models.Webhook
+			if err := tx.Where("user_id = ? AND active = ?", event.UserID, true).Order("id ASC").Find(&webhooks).Error; err != nil {
+				return err
+			}
+
+			var deliveries []models.WebhookDelivery
+			for _, hook := range webhooks {
+				if hook.Subscribed(event.Type) {
+					deliveries = append(deliveries, NewDelivery(hook.ID, event, now))
+				}
+			}
+			if len(deliveries) == 0 {
+				return nil
+			}
+			return tx.Create(&deliveries).Error
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewDelivery - Delivery baru yang siap dikirim (juga dipakai untuk redelivery manual)
+func NewDelivery(webhookID uint, event models.WebhookEvent, now time.Time) models.WebhookDelivery {
+	return models.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Status:        models.WebhookDeliveryPending,
+		NextAttemptAt: now,
+	}
+}
+
+// attempt - Kirim satu delivery dan catat hasilnya (sukses, retry dengan backoff, atau dead letter)
+func attempt(ctx context.Context, db *gorm.DB, client *http.Client, delivery models.WebhookDelivery, maxAttempts int) error {
+	now := time.Now()
+
+	claim := db.Model(&models.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", delivery.ID, models.WebhookDeliveryPending, now).
+		Update("next_attempt_at", now.Add(leaseDuration))
+	if claim.Error != nil || claim.RowsAffected == 0 {
+		return claim.Error
+	}
+
+	var hook models.Webhook
+	var event models.WebhookEvent
+	if err := db.First(&hook, delivery.WebhookID).Error; err != nil {
+		return err
+	}
+	if err := db.First(&event, delivery.EventID).Error; err != nil {
+		return err
+	}
+
+	delivery.Attempts++
+	updates := map[string]interface{}{"attempts": delivery.Attempts}
+
+	statusCode, response, duration, err := send(ctx, client, hook, event, delivery.ID)
+	if ctx.Err() != nil {
+		// Server berhenti di tengah pengiriman, biarkan lease habis dan coba lagi nanti
+		return nil
+	}
+	updates["last_status_code"] = statusCode
+	updates["last_response"] = response
+	updates["duration_ms"] = duration.Milliseconds()
+
+	switch {
+	case !hook.Active:
+		updates["status"] = models.WebhookDeliveryDead
+		updates["last_error"] = "webhook is inactive"
+	case err == nil && statusCode >= 200 && statusCode < 300:
+		deliveredAt := time.Now()
+		updates["status"] = models.WebhookDeliverySucceeded
+		updates["last_error"] = ""
+		updates["delivered_at"] = &deliveredAt
+	default:
+		if err != nil {
+			updates["last_error"] = err.Error()
+		} else {
+			updates["last_error"] = fmt.Sprintf("unexpected status %d", statusCode)
+		}
+		if delivery.Attempts >= maxAttempts {
+			updates["status"] = models.WebhookDeliveryDead
+		} else {
+			updates["next_attempt_at"] = time.Now().Add(Backoff(delivery.Attempts))
+		}
+	}
+
+	return db.Model(&models.WebhookDelivery{}).Where("id = ?", delivery.ID).Updates(updates).Error
+}
+
+// send - POST envelope bertanda tangan ke URL webhook (webhook nonaktif tidak dikirim)
+func send(ctx context.Context, client *http.Client, hook models.Webhook, event models.WebhookEvent, deliveryID uint) (int, string, time.Duration, error) {
+	if !hook.Active {
+		return 0, "", 0, nil
+	}
+
+	body, err := json.Marshal(Envelope{
+		ID:        event.ID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.UTC(),
+		Data:      json.RawMessage(event.Payload),
+	})
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "blog-api-webhook/1.0")
+	req.Header.Set(HeaderEvent, event.Type)
+	req.Header.Set(HeaderDelivery, fmt.Sprint(deliveryID))
+	req.Header.Set(HeaderTimestamp, fmt.Sprint(timestamp))
+	req.Header.Set(HeaderSignature, Sign(hook.Secret, timestamp, body))
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", time.Since(start), err
+	}
+	defer resp.Body.Close()
+
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseLog))
+	return resp.StatusCode, string(snippet), time.Since(start), nil
+}
+
+// newClient - HTTP client tanpa redirect; tanpa allowPrivate, koneksi ke alamat privat ditolak
+// setelah DNS resolve (mencegah SSRF lewat hostname yang mengarah ke jaringan internal)
+func newClient(allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !allowPrivate {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: deliveryTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return errors.New("webhook: redirects are not followed")
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-api/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Header yang dikirim bersama setiap delivery
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature - "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body))
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+var errPrivateAddress = errors.New("webhook: destination address is not allowed")
+
+// Envelope - Body JSON yang dikirim ke endpoint
+type Envelope struct {
+	ID        uint            `json:"id"` // ID event, sama untuk setiap redelivery
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Emit - Tulis event ke outbox, dipanggil di dalam transaksi handler agar event
+// hanya terkirim jika perubahan data ikut di-commit. Panggil Wake setelah commit.
+func Emit(tx *gorm.DB, userID uint, eventType string, data interface{}) error {
+	var webhooks []models.Webhook
+	if err := tx.Where("user_id = ? AND active = ?", userID, true).Find(&webhooks).Error; err != nil {
+		return err
+	}
+
+	// Tidak perlu menulis outbox jika tidak ada subscription yang cocok
+	subscribed := false
+	for _, hook := range webhooks {
+		if hook.Subscribed(eventType) {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return nil
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&models.WebhookEvent{
+		UserID:  userID,
+		Type:    eventType,
+		Payload: string(payload),
+	}).Error
+}
+
+// Sign - Signature untuk HeaderSignature
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// GenerateSecret - Secret acak untuk webhook yang dibuat tanpa secret
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// Backoff - Jeda sebelum percobaan berikutnya: 30s, 1m, 2m, ... maksimal 6 jam
+func Backoff(attempt int) time.Duration {
+	const base, max = 30 * time.Second, 6 * time.Hour
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 16 {
+		return max
+	}
+	if delay := base << (attempt - 1); delay < max {
+		return delay
+	}
+	return max
+}
+
+// ValidateURL - URL harus http(s) absolut; tanpa allowPrivate, localhost dan IP privat ditolak
+// (hostname lain dicek lagi saat koneksi dibuat)
+func ValidateURL(raw string, allowPrivate bool) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return errors.New("URL must be an absolute http or https URL")
+	}
+	if allowPrivate {
+		return nil
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errPrivateAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && !publicIP(ip) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+// publicIP - Tolak loopback, jaringan privat, link-local (termasuk metadata cloud) dan unspecified
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast())
+}